@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/converter"
+	networking "k8s.io/api/networking/v1"
+)
+
+var (
+	nginxPlus = flag.Bool("nginx-plus", false, "Convert the annotations that are supported only with NGINX Plus")
+
+	appProtect = flag.Bool("enable-app-protect", false, "Convert the annotations of NGINX App Protect. Requires -nginx-plus.")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage: %s [flags] [file ...]
+
+Converts Ingress resources into VirtualServer, VirtualServerRoute and Policy resources.
+Reads the Ingress resources from the files or, if no files are given, from the standard input.
+Writes the converted resources to the standard output and the warnings about the configuration
+that was not converted to the standard error.
+
+Flags:
+`, os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	err := validateFlags(*nginxPlus, *appProtect)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	ingresses, warnings, err := readIngresses(flag.Args(), os.Stdin)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	result := converter.NewConverter(*nginxPlus, *appProtect).Convert(ingresses)
+
+	for _, w := range append(warnings, result.Warnings...) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+
+	err = converter.WriteResources(os.Stdout, result)
+	if err != nil {
+		exitWithError(err)
+	}
+}
+
+func validateFlags(nginxPlus bool, appProtect bool) error {
+	if appProtect && !nginxPlus {
+		return fmt.Errorf("NGINX App Protect support is for NGINX Plus only")
+	}
+	return nil
+}
+
+// readIngresses reads the Ingress resources from the files. The file "-" and no files at all stand for stdin.
+func readIngresses(files []string, stdin io.Reader) ([]*networking.Ingress, []string, error) {
+	if len(files) == 0 {
+		return converter.ReadIngresses(stdin)
+	}
+
+	var allIngresses []*networking.Ingress
+	var allWarnings []string
+
+	for _, name := range files {
+		ingresses, warnings, err := readIngressesFromFile(name, stdin)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		allIngresses = append(allIngresses, ingresses...)
+		allWarnings = append(allWarnings, warnings...)
+	}
+
+	return allIngresses, allWarnings, nil
+}
+
+func readIngressesFromFile(name string, stdin io.Reader) ([]*networking.Ingress, []string, error) {
+	r := stdin
+
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ingresses, warnings, err := converter.ReadIngresses(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	return ingresses, warnings, nil
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
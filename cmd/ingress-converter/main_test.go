@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networking "k8s.io/api/networking/v1"
+)
+
+func createTestIngressYAML(name string) string {
+	return `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ` + name + `
+  namespace: default
+spec:
+  rules:
+  - host: ` + name + `.example.com
+`
+}
+
+func getIngressNames(ingresses []*networking.Ingress) []string {
+	var names []string
+	for _, ing := range ingresses {
+		names = append(names, ing.Name)
+	}
+	return names
+}
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		nginxPlus  bool
+		appProtect bool
+		wantErr    bool
+	}{
+		{nginxPlus: false, appProtect: false, wantErr: false},
+		{nginxPlus: true, appProtect: false, wantErr: false},
+		{nginxPlus: true, appProtect: true, wantErr: false},
+		{nginxPlus: false, appProtect: true, wantErr: true},
+	}
+
+	for _, test := range tests {
+		err := validateFlags(test.nginxPlus, test.appProtect)
+		if (err != nil) != test.wantErr {
+			t.Errorf("validateFlags(%v, %v) returned %v", test.nginxPlus, test.appProtect, err)
+		}
+	}
+}
+
+func TestReadIngresses(t *testing.T) {
+	dir := t.TempDir()
+
+	teaFile := filepath.Join(dir, "tea.yaml")
+	coffeeFile := filepath.Join(dir, "coffee.yaml")
+	serviceFile := filepath.Join(dir, "service.yaml")
+
+	files := map[string]string{
+		teaFile:     createTestIngressYAML("tea") + "---\n" + createTestIngressYAML("green-tea"),
+		coffeeFile:  createTestIngressYAML("coffee"),
+		serviceFile: "apiVersion: v1\nkind: Service\nmetadata:\n  name: tea-svc\n",
+	}
+	for name, content := range files {
+		err := os.WriteFile(name, []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		files            []string
+		stdin            string
+		expectedNames    []string
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			files:         nil,
+			stdin:         createTestIngressYAML("cafe"),
+			expectedNames: []string{"cafe"},
+			msg:           "no files",
+		},
+		{
+			files:         []string{teaFile, coffeeFile},
+			stdin:         createTestIngressYAML("cafe"),
+			expectedNames: []string{"tea", "green-tea", "coffee"},
+			msg:           "multiple files",
+		},
+		{
+			files:         []string{coffeeFile, "-", teaFile},
+			stdin:         createTestIngressYAML("cafe"),
+			expectedNames: []string{"coffee", "cafe", "tea", "green-tea"},
+			msg:           "files and stdin",
+		},
+		{
+			files:         []string{serviceFile, coffeeFile},
+			expectedNames: []string{"coffee"},
+			expectedWarnings: []string{
+				"skipped v1 Service: only networking.k8s.io/v1 Ingress resources are converted",
+			},
+			msg: "file with a skipped resource",
+		},
+	}
+
+	for _, test := range tests {
+		ingresses, warnings, err := readIngresses(test.files, strings.NewReader(test.stdin))
+		if err != nil {
+			t.Errorf("readIngresses() returned unexpected error %v for the case of %s", err, test.msg)
+			continue
+		}
+
+		if diff := cmp.Diff(test.expectedNames, getIngressNames(ingresses)); diff != "" {
+			t.Errorf("readIngresses() returned unexpected Ingresses for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings); diff != "" {
+			t.Errorf("readIngresses() returned unexpected warnings for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestReadIngressesFails(t *testing.T) {
+	dir := t.TempDir()
+
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	err := os.WriteFile(invalidFile, []byte("apiVersion: [networking.k8s.io/v1"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write %s: %v", invalidFile, err)
+	}
+
+	tests := []struct {
+		files []string
+		stdin string
+		msg   string
+	}{
+		{
+			files: []string{filepath.Join(dir, "missing.yaml")},
+			msg:   "missing file",
+		},
+		{
+			files: []string{invalidFile},
+			msg:   "invalid file",
+		},
+		{
+			files: []string{"-"},
+			stdin: "apiVersion: [networking.k8s.io/v1",
+			msg:   "invalid stdin",
+		},
+	}
+
+	for _, test := range tests {
+		_, _, err := readIngresses(test.files, strings.NewReader(test.stdin))
+		if err == nil {
+			t.Errorf("readIngresses() returned no error for the case of %s", test.msg)
+		}
+	}
+}
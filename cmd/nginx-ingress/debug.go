@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	api_v1 "k8s.io/api/core/v1"
+)
+
+// debugStateEndpoint is the path where the debug API exposes the internal model of the controller
+const debugStateEndpoint = "/debug/state"
+
+// debugAPITokenKey is the key of the Secret data that holds the token of the debug API
+const debugAPITokenKey = "token"
+
+type debugStateGetter interface {
+	GetDebugState() *k8s.DebugState
+}
+
+func runDebugAPIServer(port int, getter debugStateGetter, token string) {
+	s := http.NewServeMux()
+	s.HandleFunc(debugStateEndpoint, debugState(getter, token))
+
+	address := fmt.Sprintf(":%v", port)
+	glog.Infof("Starting debug API listener on: %v%v", address, debugStateEndpoint)
+	glog.Fatal("Error in debug API listener server: ", http.ListenAndServe(address, s))
+}
+
+// debugState returns a handler that responds with the debug state of the controller in JSON.
+// The handler only accepts GET requests with the bearer token in the Authorization header.
+func debugState(getter debugStateGetter, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !isDebugAPIRequestAuthorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(getter.GetDebugState())
+		if err != nil {
+			glog.Warningf("Error while sending a response for the '%v' path: %v", debugStateEndpoint, err)
+		}
+	}
+}
+
+func isDebugAPIRequestAuthorized(r *http.Request, token string) bool {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(header, prefix) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, prefix)), []byte(token)) == 1
+}
+
+// getDebugAPIToken gets the token of the debug API from the secret.
+func getDebugAPIToken(secret *api_v1.Secret) (string, error) {
+	token, exists := secret.Data[debugAPITokenKey]
+	if !exists {
+		return "", fmt.Errorf("secret %v/%v must have the data key %v", secret.Namespace, secret.Name, debugAPITokenKey)
+	}
+
+	trimmed := strings.TrimSpace(string(token))
+	if trimmed == "" {
+		return "", fmt.Errorf("data key %v of secret %v/%v must not be empty", debugAPITokenKey, secret.Namespace, secret.Name)
+	}
+
+	return trimmed, nil
+}
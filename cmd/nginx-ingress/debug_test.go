@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeDebugStateGetter struct {
+	state *k8s.DebugState
+}
+
+func (f *fakeDebugStateGetter) GetDebugState() *k8s.DebugState {
+	return f.state
+}
+
+func TestDebugState(t *testing.T) {
+	state := &k8s.DebugState{
+		Resources: []k8s.DebugResource{
+			{
+				Kind:       "VirtualServer",
+				Namespace:  "default",
+				Name:       "cafe",
+				Hosts:      []string{"cafe.example.com"},
+				ConfigFile: "/etc/nginx/conf.d/vs_default_cafe.conf",
+			},
+		},
+	}
+	handler := debugState(&fakeDebugStateGetter{state: state}, "secret-token")
+
+	tests := []struct {
+		method         string
+		authorization  string
+		expectedStatus int
+		msg            string
+	}{
+		{
+			method:         http.MethodGet,
+			authorization:  "Bearer secret-token",
+			expectedStatus: http.StatusOK,
+			msg:            "valid token",
+		},
+		{
+			method:         http.MethodGet,
+			authorization:  "",
+			expectedStatus: http.StatusUnauthorized,
+			msg:            "no token",
+		},
+		{
+			method:         http.MethodGet,
+			authorization:  "Bearer wrong-token",
+			expectedStatus: http.StatusUnauthorized,
+			msg:            "wrong token",
+		},
+		{
+			method:         http.MethodGet,
+			authorization:  "secret-token",
+			expectedStatus: http.StatusUnauthorized,
+			msg:            "token without bearer scheme",
+		},
+		{
+			method:         http.MethodPost,
+			authorization:  "Bearer secret-token",
+			expectedStatus: http.StatusMethodNotAllowed,
+			msg:            "not allowed method",
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, debugStateEndpoint, nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		rec := httptest.NewRecorder()
+
+		handler(rec, req)
+
+		if rec.Code != test.expectedStatus {
+			t.Errorf("debugState() returned status %v but expected %v for the case of %s", rec.Code, test.expectedStatus, test.msg)
+			continue
+		}
+
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var result k8s.DebugState
+		err := json.Unmarshal(rec.Body.Bytes(), &result)
+		if err != nil {
+			t.Errorf("debugState() returned invalid JSON for the case of %s: %v", test.msg, err)
+			continue
+		}
+		if diff := cmp.Diff(*state, result); diff != "" {
+			t.Errorf("debugState() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetDebugAPIToken(t *testing.T) {
+	secret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: "nginx-ingress",
+			Name:      "debug-api",
+		},
+		Data: map[string][]byte{
+			"token": []byte("secret-token\n"),
+		},
+	}
+
+	token, err := getDebugAPIToken(secret)
+	if err != nil {
+		t.Errorf("getDebugAPIToken() returned unexpected error: %v", err)
+	}
+	if token != "secret-token" {
+		t.Errorf("getDebugAPIToken() returned %q but expected %q", token, "secret-token")
+	}
+
+	invalidSecrets := []*api_v1.Secret{
+		{
+			ObjectMeta: secret.ObjectMeta,
+		},
+		{
+			ObjectMeta: secret.ObjectMeta,
+			Data: map[string][]byte{
+				"token": []byte(" "),
+			},
+		},
+	}
+
+	for _, s := range invalidSecrets {
+		_, err := getDebugAPIToken(s)
+		if err == nil {
+			t.Errorf("getDebugAPIToken() returned no error for the invalid secret %v", s.Data)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"net"
 	"net/http"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
 	util_version "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/dynamic"
@@ -59,7 +61,16 @@ var (
 	The Ingress controller does not start NGINX and does not write any generated NGINX configuration files to disk`)
 
 	watchNamespace = flag.String("watch-namespace", api_v1.NamespaceAll,
-		`Namespace to watch for Ingress resources. By default the Ingress controller watches all namespaces`)
+		`Comma separated list of namespaces to watch for Ingress resources. By default the Ingress controller watches all namespaces`)
+
+	watchNamespaceLabelSelector = flag.String("watch-namespace-label-selector", "",
+		`Label selector of the namespaces to watch for Ingress resources, for example, "team=payments". The namespaces are selected at runtime,
+	as namespaces are created, deleted or labeled. Can be combined with -watch-namespace to also watch the listed namespaces. Requires the permission to list and watch namespaces`)
+
+	watchLabelSelector = flag.String("watch-label-selector", "",
+		`Label selector to watch a subset of Ingress, VirtualServer, VirtualServerRoute, TransportServer and Policy resources, for example, "shard=shard-a".
+	Allows splitting the resources of the same class across multiple deployments of the Ingress Controller. If set, the name of the leader election lock gets
+	a suffix unique for the label selector, so that each deployment elects its own leader to report the status of its resources. By default, all resources are watched`)
 
 	nginxConfigMaps = flag.String("nginx-configmaps", "",
 		`A ConfigMap resource for customizing NGINX configuration. If a ConfigMap is set,
@@ -87,6 +98,12 @@ var (
 
 	versionFlag = flag.Bool("version", false, "Print the version, git-commit hash and build date and exit")
 
+	supportBundle = flag.String("support-bundle", "",
+		`Create a support bundle with the given file name and exit. The bundle is a gzipped tarball with the NGINX configuration (with secrets redacted),
+	the output of nginx -T, the NGINX version, the flags of the running Ingress Controller and the Prometheus metrics. If the debug API is enabled, the bundle also includes
+	the internal model of the Ingress Controller, which requires the token of the debug API in the DEBUG_API_TOKEN environment variable.
+	Must be run inside the container of the Ingress Controller, for example, with kubectl exec`)
+
 	mainTemplatePath = flag.String("main-template-path", "",
 		`Path to the main NGINX configuration template. (default for NGINX "nginx.tmpl"; default for NGINX Plus "nginx-plus.tmpl")`)
 
@@ -156,12 +173,25 @@ var (
 	enableSnippets = flag.Bool("enable-snippets", false,
 		"Enable custom NGINX configuration snippets in VirtualServer, VirtualServerRoute and TransportServer resources.")
 
+	enableIngressNginxAnnotations = flag.Bool("enable-ingress-nginx-annotations", false,
+		"Enable the translation of a subset of the nginx.ingress.kubernetes.io annotations of the kubernetes/ingress-nginx controller in Ingress resources.")
+
 	globalConfiguration = flag.String("global-configuration", "",
 		`The namespace/name of the GlobalConfiguration resource for global configuration of the Ingress Controller. Requires -enable-custom-resources. Format: <namespace>/<name>`)
 
 	enableTLSPassthrough = flag.Bool("enable-tls-passthrough", false,
 		"Enable TLS Passthrough on port 443. Requires -enable-custom-resources")
 
+	enableReferenceGrants = flag.Bool("enable-reference-grants", false,
+		`Enable ReferenceGrant resources. When enabled, VirtualServers and VirtualServerRoutes can only reference VirtualServerRoutes, Policies and the Secrets of Policies
+	from other namespaces if a ReferenceGrant in the namespace of the referenced resource allows it. Requires -enable-custom-resources`)
+
+	enableDefaultPolicies = flag.Bool("enable-default-policies", false,
+		"Enable DefaultPolicy resources, which apply Policies to all the VirtualServer and VirtualServerRoute resources of namespaces. Requires -enable-custom-resources")
+
+	enableHostPolicies = flag.Bool("enable-host-policies", false,
+		"Enable HostPolicy resources, which restrict the namespaces whose Ingress, VirtualServer and TransportServer resources can claim hosts. Requires -enable-custom-resources")
+
 	spireAgentAddress = flag.String("spire-agent-address", "",
 		`Specifies the address of the running Spire agent. Requires -nginx-plus and is for use with NGINX Service Mesh only. If the flag is set,
 			but the Ingress Controller is not able to connect with the Spire Agent, the Ingress Controller will fail to start.`)
@@ -176,6 +206,15 @@ var (
 	enableLatencyMetrics = flag.Bool("enable-latency-metrics", false,
 		"Enable collection of latency metrics for upstreams. Requires -enable-prometheus-metrics")
 
+	enableDebugAPI = flag.Bool("enable-debug-api", false,
+		`Enable the read-only debug API '/debug/state' that exposes the internal model of the Ingress Controller in JSON. Requires -debug-api-secret`)
+
+	debugAPIPort = flag.Int("debug-api-port", 8082, "Set the port where the debug API is exposed. [1024 - 65535]")
+
+	debugAPISecret = flag.String("debug-api-secret", "",
+		`A Secret with the token for the debug API in the format namespace/name. The token must be stored under the 'token' key of the Secret.
+	Clients must send the token in the 'Authorization: Bearer <token>' header`)
+
 	startupCheckFn func() error
 )
 
@@ -192,6 +231,15 @@ func main() {
 		fmt.Println(versionInfo)
 		os.Exit(0)
 	}
+
+	if *supportBundle != "" {
+		err := createSupportBundle(*supportBundle)
+		if err != nil {
+			glog.Fatalf("Error creating the support bundle: %v", err)
+		}
+		fmt.Printf("Support bundle created: %v\n", *supportBundle)
+		os.Exit(0)
+	}
 	glog.Infof("Starting NGINX Ingress controller %v PlusFlag=%v", versionInfo, *nginxPlus)
 
 	if startupCheckFn != nil {
@@ -211,6 +259,24 @@ func main() {
 		glog.Fatalf("Invalid value for leader-election-lock-name: %v", statusLockNameValidationError)
 	}
 
+	watchNamespaces, watchNamespaceValidationError := parseWatchNamespaces(*watchNamespace)
+	if watchNamespaceValidationError != nil {
+		glog.Fatalf("Invalid value for watch-namespace: %v", watchNamespaceValidationError)
+	}
+
+	var namespaceLabelSelector labels.Selector
+	if *watchNamespaceLabelSelector != "" {
+		namespaceLabelSelector, err = labels.Parse(*watchNamespaceLabelSelector)
+		if err != nil {
+			glog.Fatalf("Invalid value for watch-namespace-label-selector: %v", err)
+		}
+	}
+
+	watchLabelSelectorValidationError := validateLabelSelector(*watchLabelSelector)
+	if watchLabelSelectorValidationError != nil {
+		glog.Fatalf("Invalid value for watch-label-selector: %v", watchLabelSelectorValidationError)
+	}
+
 	statusPortValidationError := validatePort(*nginxStatusPort)
 	if statusPortValidationError != nil {
 		glog.Fatalf("Invalid value for nginx-status-port: %v", statusPortValidationError)
@@ -226,6 +292,15 @@ func main() {
 		glog.Fatalf("Invalid value for ready-status-port: %v", readyStatusPortValidationError)
 	}
 
+	debugAPIPortValidationError := validatePort(*debugAPIPort)
+	if debugAPIPortValidationError != nil {
+		glog.Fatalf("Invalid value for debug-api-port: %v", debugAPIPortValidationError)
+	}
+
+	if *enableDebugAPI && *debugAPISecret == "" {
+		glog.Fatal("enable-debug-api flag requires -debug-api-secret")
+	}
+
 	allowedCIDRs, err := parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
 	if err != nil {
 		glog.Fatalf(`Invalid value for nginx-status-allow-cidrs: %v`, err)
@@ -235,6 +310,18 @@ func main() {
 		glog.Fatal("enable-tls-passthrough flag requires -enable-custom-resources")
 	}
 
+	if *enableReferenceGrants && !*enableCustomResources {
+		glog.Fatal("enable-reference-grants flag requires -enable-custom-resources")
+	}
+
+	if *enableDefaultPolicies && !*enableCustomResources {
+		glog.Fatal("enable-default-policies flag requires -enable-custom-resources")
+	}
+
+	if *enableHostPolicies && !*enableCustomResources {
+		glog.Fatal("enable-host-policies flag requires -enable-custom-resources")
+	}
+
 	if *appProtect && !*nginxPlus {
 		glog.Fatal("NGINX App Protect support is for NGINX Plus only")
 	}
@@ -463,6 +550,22 @@ func main() {
 		}
 	}
 
+	var debugAPIToken string
+	if *enableDebugAPI {
+		ns, name, err := k8s.ParseNamespaceName(*debugAPISecret)
+		if err != nil {
+			glog.Fatalf("Error parsing the debug-api-secret argument: %v", err)
+		}
+		secret, err := kubeClient.CoreV1().Secrets(ns).Get(context.TODO(), name, meta_v1.GetOptions{})
+		if err != nil {
+			glog.Fatalf("Error trying to get the debug API secret %v: %v", *debugAPISecret, err)
+		}
+		debugAPIToken, err = getDebugAPIToken(secret)
+		if err != nil {
+			glog.Fatalf("Invalid debug API secret %v: %v", *debugAPISecret, err)
+		}
+	}
+
 	globalConfigurationValidator := createGlobalConfigurationValidator()
 
 	if *globalConfiguration != "" {
@@ -523,6 +626,7 @@ func main() {
 		EnableLatencyMetrics:           *enableLatencyMetrics,
 		EnablePreviewPolicies:          *enablePreviewPolicies,
 		SSLRejectHandshake:             sslRejectHandshake,
+		EnableIngressNginxAnnotations:  *enableIngressNginxAnnotations,
 	}
 
 	ngxConfig := configs.GenerateNginxMainConfig(staticCfgParams, cfgParams)
@@ -612,7 +716,9 @@ func main() {
 		ConfClient:                   confClient,
 		DynClient:                    dynClient,
 		ResyncPeriod:                 30 * time.Second,
-		Namespace:                    *watchNamespace,
+		Namespaces:                   watchNamespaces,
+		NamespaceLabelSelector:       namespaceLabelSelector,
+		WatchLabelSelector:           *watchLabelSelector,
 		NginxConfigurator:            cnf,
 		DefaultServerSecret:          *defaultServerSecret,
 		AppProtectEnabled:            *appProtect,
@@ -623,12 +729,15 @@ func main() {
 		ControllerNamespace:          controllerNamespace,
 		ReportIngressStatus:          *reportIngressStatus,
 		IsLeaderElectionEnabled:      *leaderElectionEnabled,
-		LeaderElectionLockName:       *leaderElectionLockName,
+		LeaderElectionLockName:       getLeaderElectionLockName(*leaderElectionLockName, *watchLabelSelector),
 		WildcardTLSSecret:            *wildcardTLSSecret,
 		ConfigMaps:                   *nginxConfigMaps,
 		GlobalConfiguration:          *globalConfiguration,
 		AreCustomResourcesEnabled:    *enableCustomResources,
 		EnablePreviewPolicies:        *enablePreviewPolicies,
+		AreHostPoliciesEnabled:       *enableHostPolicies,
+		AreReferenceGrantsEnabled:    *enableReferenceGrants,
+		AreDefaultPoliciesEnabled:    *enableDefaultPolicies,
 		MetricsCollector:             controllerCollector,
 		GlobalConfigurationValidator: globalConfigurationValidator,
 		TransportServerValidator:     transportServerValidator,
@@ -638,6 +747,7 @@ func main() {
 		IsPrometheusEnabled:          *enablePrometheusMetrics,
 		IsLatencyMetricsEnabled:      *enableLatencyMetrics,
 		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
+		IsDebugAPIEnabled:            *enableDebugAPI,
 	}
 
 	lbc := k8s.NewLoadBalancerController(lbcInput)
@@ -651,6 +761,10 @@ func main() {
 		}()
 	}
 
+	if *enableDebugAPI {
+		go runDebugAPIServer(*debugAPIPort, lbc, debugAPIToken)
+	}
+
 	if *appProtect {
 		go handleTerminationWithAppProtect(lbc, nginxManager, syslogListener, nginxDone, aPAgentDone, aPPluginDone)
 	} else {
@@ -677,6 +791,9 @@ func createGlobalConfigurationValidator() *cr_validation.GlobalConfigurationVali
 	if *enablePrometheusMetrics {
 		forbiddenListenerPorts[*prometheusMetricsListenPort] = true
 	}
+	if *enableDebugAPI {
+		forbiddenListenerPorts[*debugAPIPort] = true
+	}
 
 	return cr_validation.NewGlobalConfigurationValidator(forbiddenListenerPorts)
 }
@@ -735,6 +852,58 @@ func validateResourceName(lock string) error {
 	return nil
 }
 
+// parseWatchNamespaces converts a comma separated list of namespaces into an array of namespaces.
+// It returns an empty array for all namespaces or an error if given an invalid namespace.
+func parseWatchNamespaces(input string) (namespaces []string, err error) {
+	if strings.TrimSpace(input) == api_v1.NamespaceAll {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, ns := range strings.Split(input, ",") {
+		trimmedNs := strings.TrimSpace(ns)
+		allErrs := validation.IsDNS1123Label(trimmedNs)
+		if len(allErrs) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %v", trimmedNs, allErrs)
+		}
+		if !seen[trimmedNs] {
+			seen[trimmedNs] = true
+			namespaces = append(namespaces, trimmedNs)
+		}
+	}
+
+	return namespaces, nil
+}
+
+// validateLabelSelector makes sure a given label selector is valid
+func validateLabelSelector(selector string) error {
+	_, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return nil
+}
+
+// getLeaderElectionLockName returns the name of the leader election lock.
+// If the label selector is set, the name gets a suffix unique for the label selector,
+// so that the Ingress Controllers which watch different subsets of resources don't share the same lock.
+func getLeaderElectionLockName(lockName string, labelSelector string) string {
+	if labelSelector == "" {
+		return lockName
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return lockName
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(selector.String()))
+
+	return fmt.Sprintf("%s-%08x", lockName, h.Sum32())
+}
+
 // validatePort makes sure a given port is inside the valid port range for its usage
 func validatePort(port int) error {
 	if port < 1024 || port > 65535 {
@@ -123,3 +123,95 @@ func TestValidateLocation(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLabelSelector(t *testing.T) {
+	badSelectors := []string{
+		"shard==a,",
+		"shard in (a",
+		"!",
+	}
+	for _, badSelector := range badSelectors {
+		err := validateLabelSelector(badSelector)
+		if err == nil {
+			t.Errorf("validateLabelSelector(%v) returned no error when it should have returned an error", badSelector)
+		}
+	}
+
+	goodSelectors := []string{
+		"",
+		"shard=a",
+		"shard in (a, b),team!=payments",
+	}
+	for _, goodSelector := range goodSelectors {
+		err := validateLabelSelector(goodSelector)
+		if err != nil {
+			t.Errorf("validateLabelSelector(%v) returned an error when it should have returned no error: %v", goodSelector, err)
+		}
+	}
+}
+
+func TestGetLeaderElectionLockName(t *testing.T) {
+	lockName := "nginx-ingress-leader-election"
+
+	result := getLeaderElectionLockName(lockName, "")
+	if result != lockName {
+		t.Errorf("getLeaderElectionLockName() returned %q but expected %q for an empty selector", result, lockName)
+	}
+
+	shardA := getLeaderElectionLockName(lockName, "shard=a")
+	shardB := getLeaderElectionLockName(lockName, "shard=b")
+	if shardA == lockName || shardA == shardB {
+		t.Errorf("getLeaderElectionLockName() returned %q and %q, expected unique names for different selectors", shardA, shardB)
+	}
+	if err := validateResourceName(shardA); err != nil {
+		t.Errorf("getLeaderElectionLockName() returned an invalid name %q: %v", shardA, err)
+	}
+
+	// equivalent selectors must result in the same lock name
+	result = getLeaderElectionLockName(lockName, "team=a,shard=b")
+	expected := getLeaderElectionLockName(lockName, "shard=b, team=a")
+	if result != expected {
+		t.Errorf("getLeaderElectionLockName() returned %q but expected %q for an equivalent selector", result, expected)
+	}
+}
+
+func TestParseWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "",
+			expected: nil,
+		},
+		{
+			input:    "default",
+			expected: []string{"default"},
+		},
+		{
+			input:    "team-a, team-b,team-a",
+			expected: []string{"team-a", "team-b"},
+		},
+	}
+	for _, test := range tests {
+		result, err := parseWatchNamespaces(test.input)
+		if err != nil {
+			t.Errorf("parseWatchNamespaces(%q) returned an error when it should have returned no error: %v", test.input, err)
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("parseWatchNamespaces(%q) returned %v but expected %v", test.input, result, test.expected)
+		}
+	}
+
+	badInputs := []string{
+		"team-a,",
+		"Team-A",
+		"team_a,team-b",
+	}
+	for _, badInput := range badInputs {
+		_, err := parseWatchNamespaces(badInput)
+		if err == nil {
+			t.Errorf("parseWatchNamespaces(%q) returned no error when it should have returned an error", badInput)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+)
+
+const (
+	// supportBundleConfPath is the folder with the NGINX configuration managed by the LocalManager
+	supportBundleConfPath = "/etc/nginx"
+
+	// supportBundleDebugAPITokenEnv is the environment variable with the token for the debug API
+	supportBundleDebugAPITokenEnv = "DEBUG_API_TOKEN"
+
+	supportBundleHTTPTimeout = 10 * time.Second
+
+	redactedValue = "<redacted>"
+)
+
+// sensitiveConfigDirectives matches the values of the NGINX directives that can include secret data.
+var sensitiveConfigDirectives = []*regexp.Regexp{
+	regexp.MustCompile(`(?im)^(\s*set\s+\$\S*(?:secret|password|token)\S*\s+)("[^"]*"|'[^']*'|[^\s;]+)`),
+}
+
+// apiKeyClientMap matches the maps of the API key policies, which map the hashes of the API keys to the client IDs.
+var apiKeyClientMap = regexp.MustCompile(`(?s)map\s+\$apikey_auth_hash\s+\S+\s*\{[^}]*\}`)
+
+// apiKeyClientMapHash matches the hash of an API key in an entry of an API key client map.
+var apiKeyClientMapHash = regexp.MustCompile(`(?m)^(\s*)("[^"]*"|[0-9a-fA-F]+)(\s+"[^"]*"\s*;)`)
+
+// supportBundleCollector collects the diagnostics of the Ingress Controller into a gzipped tarball.
+// Failures of the individual steps don't stop the collection: they're recorded in the errors.txt file of the bundle.
+type supportBundleCollector struct {
+	tw       *tar.Writer
+	modTime  time.Time
+	errors   []string
+	confPath string
+}
+
+// createSupportBundle creates a support bundle in the file with the given name.
+// It is meant to be run inside the container of the Ingress Controller: the bundle is collected from
+// the local file system, the local NGINX binary and the local endpoints of the running Ingress Controller.
+func createSupportBundle(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %v: %w", filename, err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	b := &supportBundleCollector{
+		tw:       tar.NewWriter(gw),
+		modTime:  time.Now(),
+		confPath: supportBundleConfPath,
+	}
+
+	b.collect()
+
+	err = b.tw.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write %v: %w", filename, err)
+	}
+
+	err = gw.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write %v: %w", filename, err)
+	}
+
+	return f.Close()
+}
+
+func (b *supportBundleCollector) collect() {
+	args, err := getRunningControllerArgs()
+	if err != nil {
+		b.addError("flags", err)
+	} else {
+		err = parseRunningControllerFlags(args)
+		if err != nil {
+			b.addError("flags", err)
+		}
+	}
+
+	b.addFile("flags.txt", []byte(getFlagsInEffect()))
+
+	b.addConfTree()
+
+	binaryFilename := nginx.GetBinaryFileName(*nginxDebug)
+	b.addCommandOutput("nginx-version.txt", binaryFilename, "-v")
+	b.addCommandOutput("nginx-T.txt", binaryFilename, "-T")
+
+	if *enableDebugAPI {
+		b.addDebugState(*debugAPIPort, os.Getenv(supportBundleDebugAPITokenEnv))
+	}
+
+	if *enablePrometheusMetrics {
+		scheme := "http"
+		if *prometheusTLSSecretName != "" {
+			scheme = "https"
+		}
+		url := fmt.Sprintf("%v://127.0.0.1:%v/metrics", scheme, *prometheusMetricsListenPort)
+		b.addHTTPResponse("metrics.txt", getSupportBundleHTTPClient(), url, "")
+	}
+
+	if *nginxStatus && !*nginxPlus {
+		b.addHTTPResponse("stub-status.txt", getSocketClient("/var/lib/nginx/nginx-status.sock"), "http://config-status/stub_status", "")
+	}
+
+	b.addFile("errors.txt", []byte(strings.Join(b.errors, "\n")))
+}
+
+func (b *supportBundleCollector) addError(step string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%v: %v", step, err))
+}
+
+func (b *supportBundleCollector) addFile(name string, content []byte) {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: b.modTime,
+	}
+
+	err := b.tw.WriteHeader(header)
+	if err == nil {
+		_, err = b.tw.Write(content)
+	}
+	if err != nil {
+		b.addError(name, err)
+	}
+}
+
+// addConfTree adds the files of the NGINX configuration. The content of the files from the secrets folder
+// is replaced and the sensitive values of the other files are redacted.
+func (b *supportBundleCollector) addConfTree() {
+	secretsPath := filepath.Join(b.confPath, "secrets")
+
+	err := filepath.Walk(b.confPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			b.addError("conf", err)
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.confPath, path)
+		if err != nil {
+			b.addError("conf", err)
+			return nil
+		}
+		name := filepath.ToSlash(filepath.Join("conf", rel))
+
+		if strings.HasPrefix(path, secretsPath+string(filepath.Separator)) {
+			b.addFile(name, []byte(redactedValue))
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			b.addError("conf", err)
+			return nil
+		}
+
+		b.addFile(name, redactConfig(content))
+		return nil
+	})
+	if err != nil {
+		b.addError("conf", err)
+	}
+}
+
+func (b *supportBundleCollector) addCommandOutput(name string, command string, args ...string) {
+	out, err := exec.Command(command, args...).CombinedOutput()
+	if err != nil {
+		b.addError(name, fmt.Errorf("%v %v failed: %w", command, strings.Join(args, " "), err))
+	}
+	if len(out) > 0 {
+		b.addFile(name, redactConfig(out))
+	}
+}
+
+func (b *supportBundleCollector) addDebugState(port int, token string) {
+	if token == "" {
+		b.addError("debug-state.json", fmt.Errorf("the %v environment variable with the token for the debug API is not set", supportBundleDebugAPITokenEnv))
+		return
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%v%v", port, debugStateEndpoint)
+	b.addHTTPResponse("debug-state.json", getSupportBundleHTTPClient(), url, token)
+}
+
+func (b *supportBundleCollector) addHTTPResponse(name string, client *http.Client, url string, token string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		b.addError(name, fmt.Errorf("%v returned %v", url, resp.Status))
+		return
+	}
+
+	b.addFile(name, body)
+}
+
+func getSupportBundleHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: supportBundleHTTPTimeout,
+		Transport: &http.Transport{
+			// the client only connects to the local endpoints of the Ingress Controller,
+			// which might use a certificate that is not valid for 127.0.0.1
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+}
+
+// redactConfig redacts the values of the sensitive directives in NGINX configuration.
+func redactConfig(content []byte) []byte {
+	for _, re := range sensitiveConfigDirectives {
+		content = re.ReplaceAll(content, []byte(`${1}"`+redactedValue+`"`))
+	}
+	content = apiKeyClientMap.ReplaceAllFunc(content, func(m []byte) []byte {
+		return apiKeyClientMapHash.ReplaceAll(m, []byte(`${1}"`+redactedValue+`"${3}`))
+	})
+	return content
+}
+
+// getRunningControllerArgs finds the arguments of the running Ingress Controller in the container.
+func getRunningControllerArgs() ([]string, error) {
+	cmdlines, err := filepath.Glob("/proc/[0-9]*/cmdline")
+	if err != nil {
+		return nil, err
+	}
+
+	self := strconv.Itoa(os.Getpid())
+	binary := filepath.Base(os.Args[0])
+
+	for _, cmdline := range cmdlines {
+		if filepath.Base(filepath.Dir(cmdline)) == self {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(cmdline)
+		if err != nil {
+			continue
+		}
+
+		args := parseCmdline(content)
+		if len(args) > 0 && filepath.Base(args[0]) == binary {
+			return args[1:], nil
+		}
+	}
+
+	return nil, fmt.Errorf("the running %v process was not found, using the default values of the flags", binary)
+}
+
+// parseCmdline parses the NUL-separated arguments of /proc/<pid>/cmdline.
+func parseCmdline(content []byte) []string {
+	var args []string
+	for _, arg := range bytes.Split(bytes.TrimRight(content, "\x00"), []byte{0}) {
+		args = append(args, string(arg))
+	}
+	if len(args) == 1 && args[0] == "" {
+		return nil
+	}
+	return args
+}
+
+// parseRunningControllerFlags sets the flags to the values of the running Ingress Controller.
+func parseRunningControllerFlags(args []string) error {
+	fs := flag.NewFlagSet("running", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	flag.VisitAll(func(f *flag.Flag) {
+		if f.Name != "support-bundle" {
+			fs.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+
+	return fs.Parse(args)
+}
+
+func getFlagsInEffect() string {
+	var lines []string
+	flag.VisitAll(func(f *flag.Flag) {
+		if f.Name != "support-bundle" {
+			lines = append(lines, fmt.Sprintf("-%v=%v", f.Name, f.Value))
+		}
+	})
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n") + "\n"
+}
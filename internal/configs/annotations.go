@@ -1,12 +1,47 @@
 package configs
 
 import (
+	"sort"
+
 	"github.com/golang/glog"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
 )
 
 // JWTKeyAnnotation is the annotation where the Secret with a JWK is specified.
 const JWTKeyAnnotation = "nginx.com/jwt-key"
 
+// PoliciesAnnotation is the annotation where the Policies of an Ingress are specified.
+const PoliciesAnnotation = "nginx.org/policies"
+
+// CanaryAnnotation marks an Ingress as a canary of the Ingress with the same host and path.
+const CanaryAnnotation = "nginx.org/canary"
+
+// CanaryWeightAnnotation is the annotation where the percentage of requests for a canary Ingress is specified.
+const CanaryWeightAnnotation = "nginx.org/canary-weight"
+
+// CanaryByHeaderAnnotation is the annotation where the header that routes requests to a canary Ingress is specified.
+const CanaryByHeaderAnnotation = "nginx.org/canary-by-header"
+
+// CanaryByHeaderValueAnnotation is the annotation where the value of the canary header is specified.
+const CanaryByHeaderValueAnnotation = "nginx.org/canary-by-header-value"
+
+// CanaryByCookieAnnotation is the annotation where the cookie that routes requests to a canary Ingress is specified.
+const CanaryByCookieAnnotation = "nginx.org/canary-by-cookie"
+
+// MinionNamespacesAnnotation is the annotation of a master Ingress where the namespaces that can contribute minions are specified.
+const MinionNamespacesAnnotation = "nginx.org/mergeable-ingress-minion-namespaces"
+
+// PathRegexAnnotation is the annotation where the regular expression matching of the ImplementationSpecific paths of an Ingress is specified.
+const PathRegexAnnotation = "nginx.org/path-regex"
+
+const (
+	// PathRegexCaseSensitive makes the ImplementationSpecific paths case-sensitive regular expressions.
+	PathRegexCaseSensitive = "case_sensitive"
+	// PathRegexCaseInsensitive makes the ImplementationSpecific paths case-insensitive regular expressions.
+	PathRegexCaseInsensitive = "case_insensitive"
+)
+
 // AppProtectPolicyAnnotation is where the NGINX App Protect policy is specified
 const AppProtectPolicyAnnotation = "appprotect.f5.com/app-protect-policy"
 
@@ -28,6 +63,7 @@ var masterBlacklist = map[string]bool{
 	"nginx.com/health-checks":                 true,
 	"nginx.com/health-checks-mandatory":       true,
 	"nginx.com/health-checks-mandatory-queue": true,
+	"nginx.org/path-regex":                    true,
 }
 
 var minionBlacklist = map[string]bool{
@@ -428,7 +464,8 @@ func getSessionPersistenceServices(ingEx *IngressEx) map[string]string {
 	return nil
 }
 
-func filterMasterAnnotations(annotations map[string]string) []string {
+// FilterMasterAnnotations removes the annotations that are not allowed in a master Ingress and returns the sorted removed annotations.
+func FilterMasterAnnotations(annotations map[string]string) []string {
 	var removedAnnotations []string
 
 	for key := range annotations {
@@ -438,10 +475,13 @@ func filterMasterAnnotations(annotations map[string]string) []string {
 		}
 	}
 
+	sort.Strings(removedAnnotations)
+
 	return removedAnnotations
 }
 
-func filterMinionAnnotations(annotations map[string]string) []string {
+// FilterMinionAnnotations removes the annotations that are not allowed in a minion Ingress and returns the sorted removed annotations.
+func FilterMinionAnnotations(annotations map[string]string) []string {
 	var removedAnnotations []string
 
 	for key := range annotations {
@@ -451,10 +491,13 @@ func filterMinionAnnotations(annotations map[string]string) []string {
 		}
 	}
 
+	sort.Strings(removedAnnotations)
+
 	return removedAnnotations
 }
 
-func mergeMasterAnnotationsIntoMinion(minionAnnotations map[string]string, masterAnnotations map[string]string) {
+// MergeMasterAnnotationsIntoMinion copies the annotations of a master Ingress that a minion inherits, unless the minion sets them.
+func MergeMasterAnnotationsIntoMinion(minionAnnotations map[string]string, masterAnnotations map[string]string) {
 	for key, val := range masterAnnotations {
 		if _, exists := minionAnnotations[key]; !exists {
 			if _, allowed := minionInheritanceList[key]; allowed {
@@ -463,3 +506,48 @@ func mergeMasterAnnotationsIntoMinion(minionAnnotations map[string]string, maste
 		}
 	}
 }
+
+// GetPolicyReferences returns the Policies referenced in the annotation of the Ingress.
+// An invalid annotation doesn't reference any Policies.
+func GetPolicyReferences(ing *networking.Ingress) []conf_v1.PolicyReference {
+	value, exists := ing.Annotations[PoliciesAnnotation]
+	if !exists {
+		return nil
+	}
+
+	policies, err := ParsePolicyList(value)
+	if err != nil {
+		glog.Errorf("Ingress %s/%s: Invalid value for the %s: got %q: %v", ing.Namespace, ing.Name, PoliciesAnnotation, value, err)
+		return nil
+	}
+
+	return policies
+}
+
+// IsMinionNamespaceAllowed tells if a minion in the namespace can be merged into the master Ingress.
+// The namespace of the master is always allowed. If the master doesn't specify the namespaces in the annotation,
+// all namespaces are allowed. An invalid annotation allows only the namespace of the master.
+func IsMinionNamespaceAllowed(master *networking.Ingress, namespace string) bool {
+	if namespace == master.Namespace {
+		return true
+	}
+
+	value, exists := master.Annotations[MinionNamespacesAnnotation]
+	if !exists {
+		return true
+	}
+
+	namespaces, err := ParseNamespaceList(value)
+	if err != nil {
+		glog.Errorf("Ingress %s/%s: Invalid value for the %s: got %q: %v", master.Namespace, master.Name, MinionNamespacesAnnotation, value, err)
+		return false
+	}
+
+	for _, ns := range namespaces {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
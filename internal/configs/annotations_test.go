@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestParseRewrites(t *testing.T) {
@@ -70,7 +73,7 @@ func TestFilterMasterAnnotations(t *testing.T) {
 		"nginx.org/hsts-max-age":            "2700000",
 		"nginx.org/hsts-include-subdomains": "True",
 	}
-	removedAnnotations := filterMasterAnnotations(masterAnnotations)
+	removedAnnotations := FilterMasterAnnotations(masterAnnotations)
 
 	expectedfilteredMasterAnnotations := map[string]string{
 		"nginx.org/hsts":                    "True",
@@ -86,10 +89,10 @@ func TestFilterMasterAnnotations(t *testing.T) {
 	sort.Strings(expectedRemovedAnnotations)
 
 	if !reflect.DeepEqual(expectedfilteredMasterAnnotations, masterAnnotations) {
-		t.Errorf("filterMasterAnnotations returned %v, but expected %v", masterAnnotations, expectedfilteredMasterAnnotations)
+		t.Errorf("FilterMasterAnnotations returned %v, but expected %v", masterAnnotations, expectedfilteredMasterAnnotations)
 	}
 	if !reflect.DeepEqual(expectedRemovedAnnotations, removedAnnotations) {
-		t.Errorf("filterMasterAnnotations returned %v, but expected %v", removedAnnotations, expectedRemovedAnnotations)
+		t.Errorf("FilterMasterAnnotations returned %v, but expected %v", removedAnnotations, expectedRemovedAnnotations)
 	}
 }
 
@@ -101,7 +104,7 @@ func TestFilterMinionAnnotations(t *testing.T) {
 		"nginx.org/hsts-max-age":            "2700000",
 		"nginx.org/hsts-include-subdomains": "True",
 	}
-	removedAnnotations := filterMinionAnnotations(minionAnnotations)
+	removedAnnotations := FilterMinionAnnotations(minionAnnotations)
 
 	expectedfilteredMinionAnnotations := map[string]string{
 		"nginx.org/rewrites":     "serviceName=service1 rewrite=rewrite1",
@@ -117,10 +120,10 @@ func TestFilterMinionAnnotations(t *testing.T) {
 	sort.Strings(expectedRemovedAnnotations)
 
 	if !reflect.DeepEqual(expectedfilteredMinionAnnotations, minionAnnotations) {
-		t.Errorf("filterMinionAnnotations returned %v, but expected %v", minionAnnotations, expectedfilteredMinionAnnotations)
+		t.Errorf("FilterMinionAnnotations returned %v, but expected %v", minionAnnotations, expectedfilteredMinionAnnotations)
 	}
 	if !reflect.DeepEqual(expectedRemovedAnnotations, removedAnnotations) {
-		t.Errorf("filterMinionAnnotations returned %v, but expected %v", removedAnnotations, expectedRemovedAnnotations)
+		t.Errorf("FilterMinionAnnotations returned %v, but expected %v", removedAnnotations, expectedRemovedAnnotations)
 	}
 }
 
@@ -138,7 +141,7 @@ func TestMergeMasterAnnotationsIntoMinion(t *testing.T) {
 		"nginx.org/client-max-body-size":  "2m",
 		"nginx.org/proxy-connect-timeout": "20s",
 	}
-	mergeMasterAnnotationsIntoMinion(minionAnnotations, masterAnnotations)
+	MergeMasterAnnotationsIntoMinion(minionAnnotations, masterAnnotations)
 
 	expectedMergedAnnotations := map[string]string{
 		"nginx.org/proxy-buffering":       "True",
@@ -148,6 +151,83 @@ func TestMergeMasterAnnotationsIntoMinion(t *testing.T) {
 		"nginx.org/proxy-connect-timeout": "20s",
 	}
 	if !reflect.DeepEqual(expectedMergedAnnotations, minionAnnotations) {
-		t.Errorf("mergeMasterAnnotationsIntoMinion returned %v, but expected %v", minionAnnotations, expectedMergedAnnotations)
+		t.Errorf("MergeMasterAnnotationsIntoMinion returned %v, but expected %v", minionAnnotations, expectedMergedAnnotations)
+	}
+}
+
+func TestIsMinionNamespaceAllowed(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		namespace   string
+		expected    bool
+		msg         string
+	}{
+		{
+			annotations: map[string]string{},
+			namespace:   "default",
+			expected:    true,
+			msg:         "same namespace",
+		},
+		{
+			annotations: map[string]string{},
+			namespace:   "tea",
+			expected:    true,
+			msg:         "no annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea,coffee",
+			},
+			namespace: "coffee",
+			expected:  true,
+			msg:       "allowed namespace",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea",
+			},
+			namespace: "coffee",
+			expected:  false,
+			msg:       "not allowed namespace",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea",
+			},
+			namespace: "default",
+			expected:  true,
+			msg:       "namespace of the master",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-minion-namespaces": "*",
+			},
+			namespace: "coffee",
+			expected:  true,
+			msg:       "all namespaces",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea/coffee",
+			},
+			namespace: "coffee",
+			expected:  false,
+			msg:       "invalid annotation",
+		},
+	}
+
+	for _, test := range tests {
+		master := &networking.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "cafe-master",
+				Namespace:   "default",
+				Annotations: test.annotations,
+			},
+		}
+
+		result := IsMinionNamespaceAllowed(master, test.namespace)
+		if result != test.expected {
+			t.Errorf("IsMinionNamespaceAllowed() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
@@ -0,0 +1,158 @@
+package configs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	networking "k8s.io/api/networking/v1"
+)
+
+// CanaryPathKey returns the key of the path of the host in the Canaries of an IngressEx.
+func CanaryPathKey(host string, path string) string {
+	return host + path
+}
+
+// canaryConfig holds the rules of a canary Ingress that route requests to the canary.
+type canaryConfig struct {
+	weight      int
+	header      string
+	headerValue string
+	cookie      string
+}
+
+func parseCanaryAnnotations(ing *networking.Ingress) canaryConfig {
+	var cfg canaryConfig
+
+	if weight, exists := ing.Annotations[CanaryWeightAnnotation]; exists {
+		if parsedWeight, err := ParseInt(weight); err != nil {
+			glog.Errorf("Ingress %s/%s: Invalid value for the %s: got %q: %v", ing.Namespace, ing.Name, CanaryWeightAnnotation, weight, err)
+		} else {
+			cfg.weight = parsedWeight
+		}
+	}
+
+	cfg.header = ing.Annotations[CanaryByHeaderAnnotation]
+	cfg.headerValue = ing.Annotations[CanaryByHeaderValueAnnotation]
+	cfg.cookie = ing.Annotations[CanaryByCookieAnnotation]
+
+	return cfg
+}
+
+// FindCanaryBackend returns the backend of the path of the host in the canary Ingress.
+func FindCanaryBackend(canary *networking.Ingress, host string, path string) *networking.IngressBackend {
+	for _, rule := range canary.Spec.Rules {
+		if rule.Host != host || rule.HTTP == nil {
+			continue
+		}
+
+		for i := range rule.HTTP.Paths {
+			if rule.HTTP.Paths[i].Path == path {
+				return &rule.HTTP.Paths[i].Backend
+			}
+		}
+	}
+
+	return nil
+}
+
+// getNameForCanaryVariable returns the name of the variable of the canary of a location of the Ingress. The name is unique
+// for every Ingress, because the Ingresses of different namespaces can't share the variables.
+func getNameForCanaryVariable(ing *networking.Ingress, index int) string {
+	return fmt.Sprintf("$ing_%s_canary_%d", toVariableName(fmt.Sprintf("%s_%s", ing.Namespace, ing.Name)), index)
+}
+
+// generateCanary generates the split_clients and maps that choose between the upstream of a location and the upstream
+// of the canary for every request. A request is routed by the header first, then by the cookie and then by the weight.
+// It returns the variable that holds the name of the chosen upstream or an empty string if the canary gets no requests.
+func generateCanary(cfg canaryConfig, upstreamName string, canaryUpstreamName string, variable string) (string, []version1.SplitClient, []version1.Map) {
+	var splitClients []version1.SplitClient
+	var maps []version1.Map
+
+	result := upstreamName
+
+	if cfg.weight > 0 {
+		distributions := []version1.Distribution{
+			{
+				Weight: fmt.Sprintf("%d%%", cfg.weight),
+				Value:  canaryUpstreamName,
+			},
+		}
+		if cfg.weight < 100 {
+			distributions = append(distributions, version1.Distribution{
+				Weight: "*",
+				Value:  upstreamName,
+			})
+		}
+
+		splitClientVariable := variable + "_weight"
+		splitClients = append(splitClients, version1.SplitClient{
+			Source:        "$request_id",
+			Variable:      splitClientVariable,
+			Distributions: distributions,
+		})
+		result = splitClientVariable
+	}
+
+	if cfg.cookie != "" {
+		cookieVariable := variable + "_cookie"
+		maps = append(maps, version1.Map{
+			Source:   fmt.Sprintf("$cookie_%s", cfg.cookie),
+			Variable: cookieVariable,
+			Parameters: []version1.Parameter{
+				{
+					Value:  `"always"`,
+					Result: canaryUpstreamName,
+				},
+				{
+					Value:  `"never"`,
+					Result: upstreamName,
+				},
+				{
+					Value:  "default",
+					Result: result,
+				},
+			},
+		})
+		result = cookieVariable
+	}
+
+	if cfg.header != "" {
+		var params []version1.Parameter
+		if cfg.headerValue != "" {
+			params = append(params, version1.Parameter{
+				Value:  fmt.Sprintf(`"%s"`, cfg.headerValue),
+				Result: canaryUpstreamName,
+			})
+		} else {
+			params = append(params,
+				version1.Parameter{
+					Value:  `"always"`,
+					Result: canaryUpstreamName,
+				},
+				version1.Parameter{
+					Value:  `"never"`,
+					Result: upstreamName,
+				})
+		}
+		params = append(params, version1.Parameter{
+			Value:  "default",
+			Result: result,
+		})
+
+		headerVariable := variable + "_header"
+		maps = append(maps, version1.Map{
+			Source:     fmt.Sprintf("$http_%s", strings.ReplaceAll(strings.ToLower(cfg.header), "-", "_")),
+			Variable:   headerVariable,
+			Parameters: params,
+		})
+		result = headerVariable
+	}
+
+	if result == upstreamName {
+		return "", nil, nil
+	}
+
+	return result, splitClients, maps
+}
@@ -0,0 +1,197 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateCanary(t *testing.T) {
+	upstream := "default-cafe-ingress-cafe.example.com-coffee-svc-80"
+	canaryUpstream := "default-cafe-canary-cafe.example.com-coffee-v2-svc-80"
+	variable := "$ing_default_cafe_ingress_canary_0"
+
+	tests := []struct {
+		cfg                  canaryConfig
+		expectedVariable     string
+		expectedSplitClients []version1.SplitClient
+		expectedMaps         []version1.Map
+		msg                  string
+	}{
+		{
+			cfg:              canaryConfig{},
+			expectedVariable: "",
+			msg:              "no rules",
+		},
+		{
+			cfg: canaryConfig{
+				weight: 20,
+			},
+			expectedVariable: "$ing_default_cafe_ingress_canary_0_weight",
+			expectedSplitClients: []version1.SplitClient{
+				{
+					Source:   "$request_id",
+					Variable: "$ing_default_cafe_ingress_canary_0_weight",
+					Distributions: []version1.Distribution{
+						{
+							Weight: "20%",
+							Value:  canaryUpstream,
+						},
+						{
+							Weight: "*",
+							Value:  upstream,
+						},
+					},
+				},
+			},
+			msg: "weight",
+		},
+		{
+			cfg: canaryConfig{
+				weight: 100,
+			},
+			expectedVariable: "$ing_default_cafe_ingress_canary_0_weight",
+			expectedSplitClients: []version1.SplitClient{
+				{
+					Source:   "$request_id",
+					Variable: "$ing_default_cafe_ingress_canary_0_weight",
+					Distributions: []version1.Distribution{
+						{
+							Weight: "100%",
+							Value:  canaryUpstream,
+						},
+					},
+				},
+			},
+			msg: "weight of 100",
+		},
+		{
+			cfg: canaryConfig{
+				header:      "X-Canary",
+				headerValue: "v2",
+			},
+			expectedVariable: "$ing_default_cafe_ingress_canary_0_header",
+			expectedMaps: []version1.Map{
+				{
+					Source:   "$http_x_canary",
+					Variable: "$ing_default_cafe_ingress_canary_0_header",
+					Parameters: []version1.Parameter{
+						{
+							Value:  `"v2"`,
+							Result: canaryUpstream,
+						},
+						{
+							Value:  "default",
+							Result: upstream,
+						},
+					},
+				},
+			},
+			msg: "header with value",
+		},
+		{
+			cfg: canaryConfig{
+				weight: 10,
+				header: "X-Canary",
+				cookie: "canary",
+			},
+			expectedVariable: "$ing_default_cafe_ingress_canary_0_header",
+			expectedSplitClients: []version1.SplitClient{
+				{
+					Source:   "$request_id",
+					Variable: "$ing_default_cafe_ingress_canary_0_weight",
+					Distributions: []version1.Distribution{
+						{
+							Weight: "10%",
+							Value:  canaryUpstream,
+						},
+						{
+							Weight: "*",
+							Value:  upstream,
+						},
+					},
+				},
+			},
+			expectedMaps: []version1.Map{
+				{
+					Source:   "$cookie_canary",
+					Variable: "$ing_default_cafe_ingress_canary_0_cookie",
+					Parameters: []version1.Parameter{
+						{
+							Value:  `"always"`,
+							Result: canaryUpstream,
+						},
+						{
+							Value:  `"never"`,
+							Result: upstream,
+						},
+						{
+							Value:  "default",
+							Result: "$ing_default_cafe_ingress_canary_0_weight",
+						},
+					},
+				},
+				{
+					Source:   "$http_x_canary",
+					Variable: "$ing_default_cafe_ingress_canary_0_header",
+					Parameters: []version1.Parameter{
+						{
+							Value:  `"always"`,
+							Result: canaryUpstream,
+						},
+						{
+							Value:  `"never"`,
+							Result: upstream,
+						},
+						{
+							Value:  "default",
+							Result: "$ing_default_cafe_ingress_canary_0_cookie",
+						},
+					},
+				},
+			},
+			msg: "header, cookie and weight",
+		},
+	}
+
+	for _, test := range tests {
+		resultVariable, resultSplitClients, resultMaps := generateCanary(test.cfg, upstream, canaryUpstream, variable)
+		if resultVariable != test.expectedVariable {
+			t.Errorf("generateCanary() returned %q but expected %q for the case of %s", resultVariable, test.expectedVariable, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedSplitClients, resultSplitClients); diff != "" {
+			t.Errorf("generateCanary() returned unexpected split clients for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, resultMaps); diff != "" {
+			t.Errorf("generateCanary() returned unexpected maps for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetNameForCanaryVariable(t *testing.T) {
+	first := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: "team-a",
+			Name:      "web",
+		},
+	}
+	second := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: "team",
+			Name:      "a-web",
+		},
+	}
+
+	firstVariable := getNameForCanaryVariable(first, 0)
+	secondVariable := getNameForCanaryVariable(second, 0)
+
+	if firstVariable != "$ing_team_a_web_375f2753_canary_0" {
+		t.Errorf("getNameForCanaryVariable() returned %q for Ingress %s/%s", firstVariable, first.Namespace, first.Name)
+	}
+	if secondVariable != "$ing_team_a_web_803b66af_canary_0" {
+		t.Errorf("getNameForCanaryVariable() returned %q for Ingress %s/%s", secondVariable, second.Namespace, second.Name)
+	}
+}
@@ -118,6 +118,7 @@ type StaticConfigParams struct {
 	EnableLatencyMetrics           bool
 	EnablePreviewPolicies          bool
 	SSLRejectHandshake             bool
+	EnableIngressNginxAnnotations  bool
 }
 
 // GlobalConfigParams holds global configuration parameters. For now, it only holds listeners.
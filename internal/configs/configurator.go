@@ -116,6 +116,10 @@ type Configurator struct {
 	latencyCollector        latCollector.LatencyCollector
 	isLatencyMetricsEnabled bool
 	isReloadsEnabled        bool
+	// crlSecrets stores the file names of the CA secrets that have a certificate revocation list
+	crlSecrets map[string]bool
+	// policyWarnings stores the warnings of the Policy references of the Ingresses and VirtualServers by the names of their config files
+	policyWarnings map[string]PolicyWarnings
 }
 
 // NewConfigurator creates a new Configurator.
@@ -152,6 +156,8 @@ func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigPa
 		latencyCollector:        latencyCollector,
 		isLatencyMetricsEnabled: isLatencyMetricsEnabled,
 		isReloadsEnabled:        false,
+		crlSecrets:              make(map[string]bool),
+		policyWarnings:          make(map[string]PolicyWarnings),
 	}
 	return &cnf
 }
@@ -272,9 +278,10 @@ func (cnf *Configurator) addOrUpdateIngress(ingEx *IngressEx) (Warnings, error)
 	}
 
 	isMinion := false
-	nginxCfg, warnings := generateNginxCfg(ingEx, apResources, isMinion, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(),
+	nginxCfg, warnings, policyWarnings := generateNginxCfg(ingEx, apResources, isMinion, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(),
 		cnf.staticCfgParams, cnf.isWildcardEnabled)
 	name := objectMetaToFileName(&ingEx.Ingress.ObjectMeta)
+	cnf.policyWarnings[name] = policyWarnings
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating Ingress Config %v: %w", name, err)
@@ -317,10 +324,11 @@ func (cnf *Configurator) addOrUpdateMergeableIngress(mergeableIngs *MergeableIng
 		}
 	}
 
-	nginxCfg, warnings := generateNginxCfgForMergeableIngresses(mergeableIngs, masterApResources, cnf.cfgParams, cnf.isPlus,
+	nginxCfg, warnings, policyWarnings := generateNginxCfgForMergeableIngresses(mergeableIngs, masterApResources, cnf.cfgParams, cnf.isPlus,
 		cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled)
 
 	name := objectMetaToFileName(&mergeableIngs.Master.Ingress.ObjectMeta)
+	cnf.policyWarnings[name] = policyWarnings
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating Ingress Config %v: %w", name, err)
@@ -445,6 +453,7 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 
 	vsc := newVirtualServerConfigurator(cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled)
 	vsCfg, warnings := vsc.GenerateVirtualServerConfig(virtualServerEx, apResources)
+	cnf.policyWarnings[name] = vsc.policyWarnings
 	content, err := cnf.templateExecutorV2.ExecuteVirtualServerTemplate(&vsCfg)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating VirtualServer config: %v: %w", name, err)
@@ -627,7 +636,24 @@ func generateTLSPassthroughHostsConfig(tlsPassthroughPairs map[string]tlsPassthr
 func (cnf *Configurator) addOrUpdateCASecret(secret *api_v1.Secret) string {
 	name := objectMetaToFileName(&secret.ObjectMeta)
 	data := GenerateCAFileContent(secret)
-	return cnf.nginxManager.CreateSecret(name, data, nginx.TLSSecretFileMode)
+	filename := cnf.nginxManager.CreateSecret(name, data, nginx.TLSSecretFileMode)
+
+	if crl, exists := secret.Data[secrets.CRLKey]; exists {
+		cnf.nginxManager.CreateSecret(generateCRLFileName(name), crl, nginx.TLSSecretFileMode)
+		cnf.crlSecrets[name] = true
+	} else if cnf.crlSecrets[name] {
+		cnf.nginxManager.DeleteSecret(generateCRLFileName(name))
+		delete(cnf.crlSecrets, name)
+	}
+
+	return filename
+}
+
+// generateCRLFileName generates the name of the file with the certificate revocation list of a CA secret
+// from the name of the file with the CA. The underscore can't appear in the names of namespaces and secrets,
+// so the name doesn't collide with the files of other secrets.
+func generateCRLFileName(caFileName string) string {
+	return caFileName + "_" + secrets.CRLKey
 }
 
 func (cnf *Configurator) addOrUpdateJWKSecret(secret *api_v1.Secret) string {
@@ -726,6 +752,7 @@ func (cnf *Configurator) DeleteIngress(key string) error {
 
 	delete(cnf.ingresses, name)
 	delete(cnf.minions, name)
+	delete(cnf.policyWarnings, name)
 
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteIngressMetricsLabels(key)
@@ -744,6 +771,7 @@ func (cnf *Configurator) DeleteVirtualServer(key string) error {
 	cnf.nginxManager.DeleteConfig(name)
 
 	delete(cnf.virtualServers, name)
+	delete(cnf.policyWarnings, name)
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteVirtualServerMetricsLabels(key)
 	}
@@ -1149,6 +1177,18 @@ func generateNamespaceNameKey(objectMeta *meta_v1.ObjectMeta) string {
 	return fmt.Sprintf("%s/%s", objectMeta.Namespace, objectMeta.Name)
 }
 
+// GetIngressPolicyWarnings returns the warnings of the Policy references of the Ingress and its minions found during the generation
+// of the configuration of the Ingress.
+func (cnf *Configurator) GetIngressPolicyWarnings(ing *networking.Ingress) PolicyWarnings {
+	return cnf.policyWarnings[objectMetaToFileName(&ing.ObjectMeta)]
+}
+
+// GetVirtualServerPolicyWarnings returns the warnings of the Policy references of the VirtualServer and its VirtualServerRoutes
+// found during the generation of the configuration of the VirtualServer.
+func (cnf *Configurator) GetVirtualServerPolicyWarnings(vs *conf_v1.VirtualServer) PolicyWarnings {
+	return cnf.policyWarnings[getFileNameForVirtualServer(vs)]
+}
+
 func getFileNameForVirtualServer(virtualServer *conf_v1.VirtualServer) string {
 	return fmt.Sprintf("vs_%s_%s", virtualServer.Namespace, virtualServer.Name)
 }
@@ -1167,6 +1207,21 @@ func getFileNameForTransportServerFromKey(key string) string {
 	return fmt.Sprintf("ts_%s", replaced)
 }
 
+// GetConfigFilenameForIngress returns the filename of the NGINX configuration generated for a regular or a master Ingress.
+func (cnf *Configurator) GetConfigFilenameForIngress(ing *networking.Ingress) string {
+	return cnf.nginxManager.GetFilenameForConfig(objectMetaToFileName(&ing.ObjectMeta))
+}
+
+// GetConfigFilenameForVirtualServer returns the filename of the NGINX configuration generated for a VirtualServer.
+func (cnf *Configurator) GetConfigFilenameForVirtualServer(vs *conf_v1.VirtualServer) string {
+	return cnf.nginxManager.GetFilenameForConfig(getFileNameForVirtualServer(vs))
+}
+
+// GetConfigFilenameForTransportServer returns the filename of the NGINX configuration generated for a TransportServer.
+func (cnf *Configurator) GetConfigFilenameForTransportServer(ts *conf_v1alpha1.TransportServer) string {
+	return cnf.nginxManager.GetFilenameForStreamConfig(getFileNameForTransportServer(ts))
+}
+
 // HasIngress checks if the Ingress resource is present in NGINX configuration.
 func (cnf *Configurator) HasIngress(ing *networking.Ingress) bool {
 	name := objectMetaToFileName(&ing.ObjectMeta)
@@ -1510,6 +1565,9 @@ func (cnf *Configurator) AddOrUpdateSecret(secret *api_v1.Secret) string {
 	case secrets.SecretTypeOIDC:
 		// OIDC ClientSecret is not required on the filesystem, it is written directly to the config file.
 		return ""
+	case secrets.SecretTypeAPIKey:
+		// The hashes of the API keys are not required on the filesystem, they are written directly to the config file.
+		return ""
 	default:
 		return cnf.addOrUpdateTLSSecret(secret)
 	}
@@ -1517,5 +1575,11 @@ func (cnf *Configurator) AddOrUpdateSecret(secret *api_v1.Secret) string {
 
 // DeleteSecret deletes a secret.
 func (cnf *Configurator) DeleteSecret(key string) {
-	cnf.nginxManager.DeleteSecret(keyToFileName(key))
+	name := keyToFileName(key)
+	cnf.nginxManager.DeleteSecret(name)
+
+	if cnf.crlSecrets[name] {
+		cnf.nginxManager.DeleteSecret(generateCRLFileName(name))
+		delete(cnf.crlSecrets, name)
+	}
 }
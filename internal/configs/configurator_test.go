@@ -6,12 +6,14 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
@@ -222,6 +224,57 @@ func TestUpdateEndpointsMergeableIngressFailsWithInvalidTemplate(t *testing.T) {
 	}
 }
 
+func TestAddOrUpdateCASecretWithCRL(t *testing.T) {
+	cnf, err := createTestConfigurator()
+	if err != nil {
+		t.Fatalf("Failed to create a test configurator: %v", err)
+	}
+
+	secret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "ingress-mtls-secret",
+			Namespace: "default",
+		},
+		Type: secrets.SecretTypeCA,
+		Data: map[string][]byte{
+			"ca.crt": []byte("ca"),
+			"ca.crl": []byte("crl"),
+		},
+	}
+
+	path := cnf.AddOrUpdateSecret(secret)
+	if path != "/etc/nginx/secrets/default-ingress-mtls-secret" {
+		t.Errorf("AddOrUpdateSecret() returned %q", path)
+	}
+	if !cnf.crlSecrets["default-ingress-mtls-secret"] {
+		t.Errorf("AddOrUpdateSecret() didn't write the CRL of the secret")
+	}
+
+	delete(secret.Data, "ca.crl")
+
+	cnf.AddOrUpdateSecret(secret)
+	if cnf.crlSecrets["default-ingress-mtls-secret"] {
+		t.Errorf("AddOrUpdateSecret() didn't delete the CRL removed from the secret")
+	}
+
+	secret.Data["ca.crl"] = []byte("crl")
+
+	cnf.AddOrUpdateSecret(secret)
+	cnf.DeleteSecret("default/ingress-mtls-secret")
+	if cnf.crlSecrets["default-ingress-mtls-secret"] {
+		t.Errorf("DeleteSecret() didn't delete the CRL of the secret")
+	}
+}
+
+func TestGenerateCRLFileName(t *testing.T) {
+	expected := "/etc/nginx/secrets/default-ingress-mtls-secret_ca.crl"
+
+	result := generateCRLFileName("/etc/nginx/secrets/default-ingress-mtls-secret")
+	if result != expected {
+		t.Errorf("generateCRLFileName() returned %v, but expected %v", result, expected)
+	}
+}
+
 func TestGetVirtualServerConfigFileName(t *testing.T) {
 	vs := conf_v1.VirtualServer{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -15,6 +15,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 )
 
 const emptyHost = ""
@@ -43,6 +45,11 @@ type IngressEx struct {
 	AppProtectPolicy *unstructured.Unstructured
 	AppProtectLogs   []AppProtectLog
 	SecretRefs       map[string]*secrets.SecretReference
+	Policies         map[string]*conf_v1.Policy
+	// Canaries holds the canary Ingresses of the paths of a regular Ingress. The key is CanaryPathKey(host, path).
+	Canaries map[string]*IngressEx
+	// AnnotationWarnings holds the warnings about the annotations of the Ingress that the Ingress Controller doesn't recognize.
+	AnnotationWarnings []string
 }
 
 // JWTKey represents a secret that holds JSON Web Key.
@@ -66,13 +73,17 @@ type MergeableIngresses struct {
 }
 
 func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinion bool, baseCfgParams *ConfigParams, isPlus bool,
-	isResolverConfigured bool, staticParams *StaticConfigParams, isWildcardEnabled bool) (version1.IngressNginxConfig, Warnings) {
+	isResolverConfigured bool, staticParams *StaticConfigParams, isWildcardEnabled bool) (version1.IngressNginxConfig, Warnings, PolicyWarnings) {
+	originalIng := ingEx.Ingress
+	ingEx, ingressNginxWarnings := applyIngressNginxAnnotations(ingEx, staticParams.EnableIngressNginxAnnotations)
+
 	hasAppProtect := staticParams.MainAppProtectLoadModule
 	cfgParams := parseAnnotations(ingEx, baseCfgParams, isPlus, hasAppProtect, staticParams.EnableInternalRoutes)
 
 	wsServices := getWebsocketServices(ingEx)
 	spServices := getSessionPersistenceServices(ingEx)
 	rewrites := getRewrites(ingEx)
+	pathRegex := ingEx.Ingress.Annotations[PathRegexAnnotation]
 	sslServices := getSSLServices(ingEx)
 	grpcServices := getGrpcServices(ingEx)
 
@@ -100,7 +111,27 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 
 	allWarnings := newWarnings()
 
+	policyContext := specContext
+	if isMinion {
+		policyContext = routeContext
+	}
+	policyCfg, policyWarnings, warningsByPolicy := generateIngressPolicies(ingEx, policyContext)
+
+	spiffeClientCerts := staticParams.NginxServiceMesh && !cfgParams.SpiffeServerCerts
+	if policyCfg.EgressMTLS != nil && spiffeClientCerts {
+		policyWarnings = append(policyWarnings, "EgressMTLS policy is not allowed when NGINX Service Mesh is enabled")
+		policyCfg = policiesCfg{ErrorReturn: &version2.Return{Code: 500}}
+	}
+
+	if policyCfg.JWTAuth != nil && cfgParams.JWTKey != "" {
+		policyWarnings = append(policyWarnings, fmt.Sprintf("The %s annotation is ignored, because JWT authentication is configured by a JWT policy", JWTKeyAnnotation))
+		cfgParams.JWTKey = ""
+	}
+
 	var servers []version1.Server
+	var splitClients []version1.SplitClient
+	var canaryMaps []version1.Map
+	canaryIndex := 0
 
 	for _, rule := range ingEx.Ingress.Spec.Rules {
 		// skipping invalid hosts
@@ -153,6 +184,17 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 			server.AppProtectLogConfs = apResources.AppProtectLogconfs
 		}
 
+		if !isMinion {
+			addPoliciesCfgToIngressServer(policyCfg, &server)
+			if server.IngressMTLS != nil && !server.SSL {
+				policyWarnings = append(policyWarnings, fmt.Sprintf("TLS must be enabled for host %s for IngressMTLS policy", rule.Host))
+				server.IngressMTLS = nil
+				server.PoliciesErrorReturn = &version1.Return{Code: 500}
+			}
+		} else {
+			server.JwksURIs = generateIngressJwksURIs(policyCfg.JWTAuth)
+		}
+
 		if !isMinion && cfgParams.JWTKey != "" {
 			jwtAuth, redirectLoc, warnings := generateJWTConfig(ingEx.Ingress, ingEx.SecretRefs, &cfgParams, getNameForRedirectLocation(ingEx.Ingress))
 			server.JWTAuth = jwtAuth
@@ -201,7 +243,14 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 			ssl := isSSLEnabled(sslServices[path.Backend.Service.Name], cfgParams, staticParams)
 			proxySSLName := generateProxySSLName(path.Backend.Service.Name, ingEx.Ingress.Namespace)
 			loc := createLocation(pathOrDefault(path.Path), upstreams[upsName], &cfgParams, wsServices[path.Backend.Service.Name], rewrites[path.Backend.Service.Name],
-				ssl, grpcServices[path.Backend.Service.Name], proxySSLName, path.PathType, path.Backend.Service.Name)
+				ssl, grpcServices[path.Backend.Service.Name], proxySSLName, path.PathType, pathRegex, path.Backend.Service.Name)
+
+			// NGINX doesn't allow a URI in proxy_pass in a regular expression location
+			if isRegexLocationPath(loc.Path) && loc.Rewrite != "" {
+				allWarnings.AddWarningf(ingEx.Ingress, "rewrite for service %s is ignored for path %s of host %s, because the path is a regular expression",
+					path.Backend.Service.Name, path.Path, rule.Host)
+				loc.Rewrite = ""
+			}
 
 			if isMinion && cfgParams.JWTKey != "" {
 				jwtAuth, redirectLoc, warnings := generateJWTConfig(ingEx.Ingress, ingEx.SecretRefs, &cfgParams, getNameForRedirectLocation(ingEx.Ingress))
@@ -212,6 +261,39 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 				allWarnings.Add(warnings)
 			}
 
+			if isMinion {
+				addPoliciesCfgToIngressLocation(policyCfg, &loc)
+			}
+
+			if canaryEx, exists := ingEx.Canaries[CanaryPathKey(rule.Host, path.Path)]; exists {
+				canaryBackend := FindCanaryBackend(canaryEx.Ingress, rule.Host, path.Path)
+
+				if loc.Rewrite != "" {
+					allWarnings.AddWarning(canaryEx.Ingress, fmt.Sprintf("canary for path %s of host %s is ignored, because the path of Ingress %s/%s has a rewrite",
+						path.Path, rule.Host, ingEx.Ingress.Namespace, ingEx.Ingress.Name))
+				} else if canaryBackend != nil {
+					canaryUpsName := getNameForUpstream(canaryEx.Ingress, rule.Host, canaryBackend)
+
+					if cfgParams.HealthCheckEnabled {
+						if hc, exists := canaryEx.HealthChecks[canaryBackend.Service.Name+GetBackendPortAsString(canaryBackend.Service.Port)]; exists {
+							healthChecks[canaryUpsName] = createHealthCheck(hc, canaryUpsName, &cfgParams)
+						}
+					}
+
+					if _, exists := upstreams[canaryUpsName]; !exists {
+						upstreams[canaryUpsName] = createUpstream(canaryEx, canaryUpsName, canaryBackend, "", &cfgParams, isPlus, isResolverConfigured, staticParams.EnableLatencyMetrics)
+					}
+
+					variable, scs, maps := generateCanary(parseCanaryAnnotations(canaryEx.Ingress), upsName, canaryUpsName, getNameForCanaryVariable(ingEx.Ingress, canaryIndex))
+					if variable != "" {
+						loc.CanaryVariable = variable
+						splitClients = append(splitClients, scs...)
+						canaryMaps = append(canaryMaps, maps...)
+						canaryIndex++
+					}
+				}
+			}
+
 			locations = append(locations, loc)
 
 			if loc.Path == "/" {
@@ -226,7 +308,7 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 			pathtype := networking.PathTypePrefix
 
 			loc := createLocation(pathOrDefault("/"), upstreams[upsName], &cfgParams, wsServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], rewrites[ingEx.Ingress.Spec.DefaultBackend.Service.Name],
-				ssl, grpcServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], proxySSLName, &pathtype, ingEx.Ingress.Spec.DefaultBackend.Service.Name)
+				ssl, grpcServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], proxySSLName, &pathtype, "", ingEx.Ingress.Spec.DefaultBackend.Service.Name)
 			locations = append(locations, loc)
 
 			if cfgParams.HealthCheckEnabled {
@@ -240,6 +322,7 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 			}
 		}
 
+		sortLocations(locations)
 		server.Locations = locations
 		server.HealthChecks = healthChecks
 		server.GRPCOnly = grpcOnly
@@ -252,6 +335,41 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 		keepalive = fmt.Sprint(cfgParams.Keepalive)
 	}
 
+	for _, w := range policyWarnings {
+		allWarnings.AddWarning(ingEx.Ingress, w)
+	}
+
+	// because ingEx.Ingress can be a copy of the original Ingress with the translated ingress-nginx annotations
+	// we need to change the key in the warnings to the original Ingress
+	if ingEx.Ingress != originalIng {
+		if w, exists := allWarnings[ingEx.Ingress]; exists {
+			allWarnings[originalIng] = append(allWarnings[originalIng], w...)
+			delete(allWarnings, ingEx.Ingress)
+		}
+	}
+	for _, w := range ingressNginxWarnings {
+		allWarnings.AddWarning(originalIng, w)
+	}
+	for _, w := range ingEx.AnnotationWarnings {
+		allWarnings.AddWarning(originalIng, w)
+	}
+	// a canary can have several paths, but the warnings about its annotations are reported once
+	reportedCanaries := make(map[*networking.Ingress]bool)
+	for _, canaryEx := range ingEx.Canaries {
+		if reportedCanaries[canaryEx.Ingress] {
+			continue
+		}
+		reportedCanaries[canaryEx.Ingress] = true
+		for _, w := range canaryEx.AnnotationWarnings {
+			allWarnings.AddWarning(canaryEx.Ingress, w)
+		}
+	}
+
+	allPolicyWarnings := newPolicyWarnings()
+	for key, msgs := range warningsByPolicy {
+		allPolicyWarnings.AddWarnings(originalIng, key, msgs)
+	}
+
 	return version1.IngressNginxConfig{
 		Upstreams: upstreamMapToSlice(upstreams),
 		Servers:   servers,
@@ -261,8 +379,211 @@ func generateNginxCfg(ingEx *IngressEx, apResources AppProtectResources, isMinio
 			Namespace:   ingEx.Ingress.Namespace,
 			Annotations: ingEx.Ingress.Annotations,
 		},
-		SpiffeClientCerts: staticParams.NginxServiceMesh && !cfgParams.SpiffeServerCerts,
-	}, allWarnings
+		SpiffeClientCerts: spiffeClientCerts,
+		Maps:              append(generateIngressMaps(policyCfg.Maps), canaryMaps...),
+		LimitReqZones:     generateIngressLimitReqZones(policyCfg.LimitReqZones),
+		SplitClients:      splitClients,
+	}, allWarnings, allPolicyWarnings
+}
+
+// generateIngressPolicies generates the configuration of the Policies referenced in the annotation of the Ingress.
+// The Policies of a regular or a master Ingress apply to its servers, while the Policies of a minion apply to its locations.
+// The warnings are returned both in order and by the keys of the Policies.
+func generateIngressPolicies(ingEx *IngressEx, context string) (policiesCfg, []string, map[string][]string) {
+	config := newPoliciesConfig()
+	var warnings []string
+	warningsByPolicy := make(map[string][]string)
+
+	// the names of Ingress resources can't contain underscores, so the prefix keeps the names of the zones of an Ingress
+	// different from the names of the zones of a VirtualServer with the same name
+	ownerName := "ing_" + ingEx.Ingress.Name
+
+	for _, p := range GetPolicyReferences(ingEx.Ingress) {
+		polNamespace := p.Namespace
+		if polNamespace == "" {
+			polNamespace = ingEx.Ingress.Namespace
+		}
+
+		key := fmt.Sprintf("%s/%s", polNamespace, p.Name)
+
+		pol, exists := ingEx.Policies[key]
+		if !exists {
+			msg := fmt.Sprintf("Policy %s is missing or invalid", key)
+			warnings = append(warnings, msg)
+			warningsByPolicy[key] = append(warningsByPolicy[key], msg)
+			return policiesCfg{ErrorReturn: &version2.Return{Code: 500}}, warnings, warningsByPolicy
+		}
+
+		var res *validationResults
+		switch {
+		case pol.Spec.AccessControl != nil:
+			res = config.addAccessControlConfig(pol.Spec.AccessControl)
+		case pol.Spec.RateLimit != nil:
+			res = config.addRateLimitConfig(pol.Spec.RateLimit, key, polNamespace, p.Name, ingEx.Ingress.Namespace, ownerName)
+		case pol.Spec.JWTAuth != nil:
+			res = config.addJWTAuthConfig(pol.Spec.JWTAuth, key, polNamespace, p.Name, ingEx.Ingress.Namespace, ownerName, ingEx.SecretRefs)
+		case pol.Spec.IngressMTLS != nil:
+			// TLS is checked for every host of the Ingress
+			tls := true
+			res = config.addIngressMTLSConfig(pol.Spec.IngressMTLS, key, polNamespace, context, tls, ingEx.SecretRefs)
+		case pol.Spec.EgressMTLS != nil:
+			res = config.addEgressMTLSConfig(pol.Spec.EgressMTLS, key, polNamespace, ingEx.SecretRefs)
+		default:
+			res = newValidationResults()
+			res.addWarningf("Policy %s is not supported in Ingress resources", key)
+			res.isError = true
+		}
+
+		warnings = append(warnings, res.warnings...)
+		if len(res.warnings) > 0 {
+			warningsByPolicy[key] = append(warningsByPolicy[key], res.warnings...)
+		}
+		if res.isError {
+			return policiesCfg{ErrorReturn: &version2.Return{Code: 500}}, warnings, warningsByPolicy
+		}
+	}
+
+	return *config, warnings, warningsByPolicy
+}
+
+func addPoliciesCfgToIngressServer(cfg policiesCfg, server *version1.Server) {
+	server.Allow = cfg.Allow
+	server.Deny = cfg.Deny
+	server.LimitReqOptions = generateIngressLimitReqOptions(cfg.LimitReqOptions)
+	server.LimitReqs = generateIngressLimitReqs(cfg.LimitReqs)
+	if cfg.JWTAuth != nil {
+		server.JWTAuth = generateIngressJWTAuth(cfg.JWTAuth)
+	}
+	server.JwksURIs = generateIngressJwksURIs(cfg.JWTAuth)
+	server.IngressMTLS = generateIngressMTLS(cfg.IngressMTLS)
+	server.EgressMTLS = generateIngressEgressMTLS(cfg.EgressMTLS)
+	server.PoliciesErrorReturn = generateIngressReturn(cfg.ErrorReturn)
+}
+
+func addPoliciesCfgToIngressLocation(cfg policiesCfg, location *version1.Location) {
+	location.Allow = cfg.Allow
+	location.Deny = cfg.Deny
+	location.LimitReqOptions = generateIngressLimitReqOptions(cfg.LimitReqOptions)
+	location.LimitReqs = generateIngressLimitReqs(cfg.LimitReqs)
+	if cfg.JWTAuth != nil {
+		location.JWTAuth = generateIngressJWTAuth(cfg.JWTAuth)
+	}
+	location.EgressMTLS = generateIngressEgressMTLS(cfg.EgressMTLS)
+	location.PoliciesErrorReturn = generateIngressReturn(cfg.ErrorReturn)
+}
+
+func generateIngressMaps(maps []version2.Map) []version1.Map {
+	var result []version1.Map
+	for _, m := range maps {
+		var params []version1.Parameter
+		for _, p := range m.Parameters {
+			params = append(params, version1.Parameter{Value: p.Value, Result: p.Result})
+		}
+		result = append(result, version1.Map{Source: m.Source, Variable: m.Variable, Parameters: params})
+	}
+	return result
+}
+
+func generateIngressLimitReqZones(zones []version2.LimitReqZone) []version1.LimitReqZone {
+	var result []version1.LimitReqZone
+	for _, z := range zones {
+		result = append(result, version1.LimitReqZone{Key: z.Key, ZoneName: z.ZoneName, ZoneSize: z.ZoneSize, Rate: z.Rate})
+	}
+	return result
+}
+
+func generateIngressLimitReqs(limitReqs []version2.LimitReq) []version1.LimitReq {
+	var result []version1.LimitReq
+	for _, rl := range limitReqs {
+		result = append(result, version1.LimitReq{ZoneName: rl.ZoneName, Burst: rl.Burst, NoDelay: rl.NoDelay, Delay: rl.Delay})
+	}
+	return result
+}
+
+func generateIngressLimitReqOptions(options version2.LimitReqOptions) version1.LimitReqOptions {
+	return version1.LimitReqOptions{
+		DryRun:     options.DryRun,
+		LogLevel:   options.LogLevel,
+		RejectCode: options.RejectCode,
+	}
+}
+
+func generateIngressJWTAuth(jwtAuth *version2.JWTAuth) *version1.JWTAuth {
+	result := &version1.JWTAuth{
+		Key:     jwtAuth.Secret,
+		Realm:   jwtAuth.Realm,
+		Token:   jwtAuth.Token,
+		Require: jwtAuth.Require,
+	}
+	if jwtAuth.JwksURI != nil {
+		result.JwksURI = &generateIngressJwksURIs(jwtAuth)[0]
+	}
+	return result
+}
+
+func generateIngressJwksURIs(jwtAuth *version2.JWTAuth) []version1.JwksURI {
+	if jwtAuth == nil || jwtAuth.JwksURI == nil {
+		return nil
+	}
+
+	return []version1.JwksURI{
+		{
+			Location:    jwtAuth.JwksURI.Location,
+			URI:         jwtAuth.JwksURI.URI,
+			CacheZone:   jwtAuth.JwksURI.CacheZone,
+			KeyCache:    jwtAuth.JwksURI.KeyCache,
+			SNIEnabled:  jwtAuth.JwksURI.SNIEnabled,
+			SNIName:     jwtAuth.JwksURI.SNIName,
+			SSLVerify:   jwtAuth.JwksURI.SSLVerify,
+			TrustedCert: jwtAuth.JwksURI.TrustedCert,
+			SSLName:     jwtAuth.JwksURI.SSLName,
+		},
+	}
+}
+
+func generateIngressMTLS(ingressMTLS *version2.IngressMTLS) *version1.IngressMTLS {
+	if ingressMTLS == nil {
+		return nil
+	}
+
+	var headers []version1.Header
+	for _, h := range ingressMTLS.ClientCertHeaders {
+		headers = append(headers, version1.Header{Name: h.Name, Value: h.Value})
+	}
+
+	return &version1.IngressMTLS{
+		ClientCert:        ingressMTLS.ClientCert,
+		ClientCRL:         ingressMTLS.ClientCRL,
+		VerifyClient:      ingressMTLS.VerifyClient,
+		VerifyDepth:       ingressMTLS.VerifyDepth,
+		ClientCertHeaders: headers,
+	}
+}
+
+func generateIngressEgressMTLS(egressMTLS *version2.EgressMTLS) *version1.EgressMTLS {
+	if egressMTLS == nil {
+		return nil
+	}
+
+	return &version1.EgressMTLS{
+		Certificate:    egressMTLS.Certificate,
+		CertificateKey: egressMTLS.CertificateKey,
+		VerifyServer:   egressMTLS.VerifyServer,
+		VerifyDepth:    egressMTLS.VerifyDepth,
+		Ciphers:        egressMTLS.Ciphers,
+		Protocols:      egressMTLS.Protocols,
+		TrustedCert:    egressMTLS.TrustedCert,
+		SessionReuse:   egressMTLS.SessionReuse,
+		ServerName:     egressMTLS.ServerName,
+		SSLName:        egressMTLS.SSLName,
+	}
+}
+
+func generateIngressReturn(r *version2.Return) *version1.Return {
+	if r == nil {
+		return nil
+	}
+	return &version1.Return{Code: r.Code}
 }
 
 func generateJWTConfig(owner runtime.Object, secretRefs map[string]*secrets.SecretReference, cfgParams *ConfigParams,
@@ -355,20 +676,61 @@ func addSSLConfig(server *version1.Server, owner runtime.Object, host string, in
 	return warnings
 }
 
-func generateIngressPath(path string, pathType *networking.PathType) string {
+// generateIngressPath generates the path of the location for the path of an Ingress. An ImplementationSpecific path is
+// a regular expression if pathRegex is set. The regular expression is wrapped in double quotes to avoid NGINX parsing errors.
+func generateIngressPath(path string, pathType *networking.PathType, pathRegex string) string {
 	if pathType == nil {
 		return path
 	}
-	if *pathType == networking.PathTypeExact {
-		path = "= " + path
+
+	switch *pathType {
+	case networking.PathTypeExact:
+		return "= " + path
+	case networking.PathTypeImplementationSpecific:
+		switch pathRegex {
+		case PathRegexCaseSensitive:
+			return fmt.Sprintf(`~ "%s"`, path)
+		case PathRegexCaseInsensitive:
+			return fmt.Sprintf(`~* "%s"`, path)
+		}
 	}
 
 	return path
 }
 
-func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams, websocket bool, rewrite string, ssl bool, grpc bool, proxySSLName string, pathType *networking.PathType, serviceName string) version1.Location {
+// isRegexLocationPath checks if the path of a location is a regular expression.
+func isRegexLocationPath(path string) bool {
+	return strings.HasPrefix(path, "~")
+}
+
+// isExactLocationPath checks if the path of a location is an exact match.
+func isExactLocationPath(path string) bool {
+	return strings.HasPrefix(path, "=")
+}
+
+// sortLocations orders the locations so that the exact locations come first, then the prefix locations and then the
+// regular expression locations. NGINX checks the regular expression locations in the order they appear in
+// the configuration, so the order of the regular expression locations among themselves is kept.
+func sortLocations(locations []version1.Location) {
+	rank := func(path string) int {
+		if isExactLocationPath(path) {
+			return 0
+		}
+		if isRegexLocationPath(path) {
+			return 2
+		}
+		return 1
+	}
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		return rank(locations[i].Path) < rank(locations[j].Path)
+	})
+}
+
+func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams, websocket bool, rewrite string, ssl bool, grpc bool, proxySSLName string, pathType *networking.PathType,
+	pathRegex string, serviceName string) version1.Location {
 	loc := version1.Location{
-		Path:                 generateIngressPath(path, pathType),
+		Path:                 generateIngressPath(path, pathType, pathRegex),
 		Upstream:             upstream,
 		ProxyConnectTimeout:  cfg.ProxyConnectTimeout,
 		ProxyReadTimeout:     cfg.ProxyReadTimeout,
@@ -512,26 +874,31 @@ func upstreamMapToSlice(upstreams map[string]version1.Upstream) []version1.Upstr
 
 func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, masterApResources AppProtectResources,
 	baseCfgParams *ConfigParams, isPlus bool, isResolverConfigured bool, staticParams *StaticConfigParams,
-	isWildcardEnabled bool) (version1.IngressNginxConfig, Warnings) {
+	isWildcardEnabled bool) (version1.IngressNginxConfig, Warnings, PolicyWarnings) {
 
 	var masterServer version1.Server
 	var locations []version1.Location
 	var upstreams []version1.Upstream
 	healthChecks := make(map[string]version1.HealthCheck)
 	var keepalive string
+	var maps []version1.Map
+	var limitReqZones []version1.LimitReqZone
 
 	// replace master with a deepcopy because we will modify it
 	originalMaster := mergeableIngs.Master.Ingress
 	mergeableIngs.Master.Ingress = mergeableIngs.Master.Ingress.DeepCopy()
 
-	removedAnnotations := filterMasterAnnotations(mergeableIngs.Master.Ingress.Annotations)
-	if len(removedAnnotations) != 0 {
-		glog.Errorf("Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'master' cannot contain the '%v' annotation(s). They will be ignored",
-			mergeableIngs.Master.Ingress.Namespace, mergeableIngs.Master.Ingress.Name, strings.Join(removedAnnotations, ","))
+	// translate the ingress-nginx annotations before filtering, so that the translated annotations are filtered and inherited
+	// like the annotations of the Ingress Controller
+	var masterIngressNginxWarnings []string
+	if staticParams.EnableIngressNginxAnnotations {
+		masterIngressNginxWarnings = translateIngressNginxAnnotations(mergeableIngs.Master.Ingress)
 	}
+
+	removedAnnotations := FilterMasterAnnotations(mergeableIngs.Master.Ingress.Annotations)
 	isMinion := false
 
-	masterNginxCfg, warnings := generateNginxCfg(mergeableIngs.Master, masterApResources, isMinion, baseCfgParams, isPlus, isResolverConfigured, staticParams, isWildcardEnabled)
+	masterNginxCfg, warnings, policyWarnings := generateNginxCfg(mergeableIngs.Master, masterApResources, isMinion, baseCfgParams, isPlus, isResolverConfigured, staticParams, isWildcardEnabled)
 
 	// because mergeableIngs.Master.Ingress is a deepcopy of the original master
 	// we need to change the key in the warnings to the original master
@@ -539,11 +906,20 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 		warnings[originalMaster] = warnings[mergeableIngs.Master.Ingress]
 		delete(warnings, mergeableIngs.Master.Ingress)
 	}
+	policyWarnings.replaceObject(mergeableIngs.Master.Ingress, originalMaster)
+	for _, w := range masterIngressNginxWarnings {
+		warnings.AddWarning(originalMaster, w)
+	}
+	if len(removedAnnotations) != 0 {
+		warnings.AddWarningf(originalMaster, "annotation(s) %s are ignored in a master Ingress", strings.Join(removedAnnotations, ","))
+	}
 
 	masterServer = masterNginxCfg.Servers[0]
 	masterServer.Locations = []version1.Location{}
 
 	upstreams = append(upstreams, masterNginxCfg.Upstreams...)
+	maps = append(maps, masterNginxCfg.Maps...)
+	limitReqZones = append(limitReqZones, masterNginxCfg.LimitReqZones...)
 
 	if masterNginxCfg.Keepalive != "" {
 		keepalive = masterNginxCfg.Keepalive
@@ -558,20 +934,23 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 		// Remove the default backend so that "/" will not be generated
 		minion.Ingress.Spec.DefaultBackend = nil
 
+		var minionIngressNginxWarnings []string
+		if staticParams.EnableIngressNginxAnnotations {
+			minionIngressNginxWarnings = translateIngressNginxAnnotations(minion.Ingress)
+		}
+
 		// Add acceptable master annotations to minion
-		mergeMasterAnnotationsIntoMinion(minion.Ingress.Annotations, mergeableIngs.Master.Ingress.Annotations)
+		MergeMasterAnnotationsIntoMinion(minion.Ingress.Annotations, mergeableIngs.Master.Ingress.Annotations)
 
-		removedAnnotations = filterMinionAnnotations(minion.Ingress.Annotations)
-		if len(removedAnnotations) != 0 {
-			glog.Errorf("Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'minion' cannot contain the %v annotation(s). They will be ignored",
-				minion.Ingress.Namespace, minion.Ingress.Name, strings.Join(removedAnnotations, ","))
-		}
+		removedMinionAnnotations := FilterMinionAnnotations(minion.Ingress.Annotations)
 
 		isMinion := true
 		// App Protect Resources not allowed in minions - pass empty struct
 		dummyApResources := AppProtectResources{}
-		nginxCfg, minionWarnings := generateNginxCfg(minion, dummyApResources, isMinion, baseCfgParams, isPlus, isResolverConfigured, staticParams, isWildcardEnabled)
+		nginxCfg, minionWarnings, minionPolicyWarnings := generateNginxCfg(minion, dummyApResources, isMinion, baseCfgParams, isPlus, isResolverConfigured, staticParams, isWildcardEnabled)
 		warnings.Add(minionWarnings)
+		minionPolicyWarnings.replaceObject(minion.Ingress, originalMinion)
+		policyWarnings.Add(minionPolicyWarnings)
 
 		// because minion.Ingress is a deepcopy of the original minion
 		// we need to change the key in the warnings to the original minion
@@ -579,6 +958,12 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 			warnings[originalMinion] = warnings[minion.Ingress]
 			delete(warnings, minion.Ingress)
 		}
+		for _, w := range minionIngressNginxWarnings {
+			warnings.AddWarning(originalMinion, w)
+		}
+		if len(removedMinionAnnotations) != 0 {
+			warnings.AddWarningf(originalMinion, "annotation(s) %s are ignored in a minion Ingress", strings.Join(removedMinionAnnotations, ","))
+		}
 
 		for _, server := range nginxCfg.Servers {
 			for _, loc := range server.Locations {
@@ -589,11 +974,15 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 				healthChecks[hcName] = healthCheck
 			}
 			masterServer.JWTRedirectLocations = append(masterServer.JWTRedirectLocations, server.JWTRedirectLocations...)
+			masterServer.JwksURIs = append(masterServer.JwksURIs, server.JwksURIs...)
 		}
 
 		upstreams = append(upstreams, nginxCfg.Upstreams...)
+		maps = append(maps, nginxCfg.Maps...)
+		limitReqZones = append(limitReqZones, nginxCfg.LimitReqZones...)
 	}
 
+	sortLocations(locations)
 	masterServer.HealthChecks = healthChecks
 	masterServer.Locations = locations
 
@@ -603,7 +992,9 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ma
 		Keepalive:         keepalive,
 		Ingress:           masterNginxCfg.Ingress,
 		SpiffeClientCerts: staticParams.NginxServiceMesh && !baseCfgParams.SpiffeServerCerts,
-	}, warnings
+		Maps:              maps,
+		LimitReqZones:     limitReqZones,
+	}, warnings, policyWarnings
 }
 
 func isSSLEnabled(isSSLService bool, cfgParams ConfigParams, staticCfgParams *StaticConfigParams) bool {
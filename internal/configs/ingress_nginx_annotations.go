@@ -0,0 +1,305 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+)
+
+// ingressNginxAnnotationPrefix is the prefix of the annotations of the kubernetes/ingress-nginx controller.
+const ingressNginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+
+const defaultIngressNginxSessionCookieName = "INGRESSCOOKIE"
+
+// ingressNginxTimeoutAnnotations maps the ingress-nginx timeout annotations to the annotations of the Ingress Controller.
+var ingressNginxTimeoutAnnotations = map[string]string{
+	ingressNginxAnnotationPrefix + "proxy-connect-timeout": "nginx.org/proxy-connect-timeout",
+	ingressNginxAnnotationPrefix + "proxy-read-timeout":    "nginx.org/proxy-read-timeout",
+	ingressNginxAnnotationPrefix + "proxy-send-timeout":    "nginx.org/proxy-send-timeout",
+}
+
+// ingressNginxCookieAnnotations are the ingress-nginx annotations that configure the cookie of the affinity annotation.
+var ingressNginxCookieAnnotations = map[string]bool{
+	ingressNginxAnnotationPrefix + "session-cookie-name":    true,
+	ingressNginxAnnotationPrefix + "session-cookie-path":    true,
+	ingressNginxAnnotationPrefix + "session-cookie-max-age": true,
+}
+
+// ingressNginxPathRegexp matches the paths of the rewrite-target and session-cookie-path annotations. The paths can't
+// include the characters that are special in the NGINX configuration or in the annotations of the Ingress Controller.
+var ingressNginxPathRegexp = regexp.MustCompile(`^/[^\s"'\\{};=$]*$`)
+
+// ingressNginxCookieNameRegexp matches the names of the session cookies.
+var ingressNginxCookieNameRegexp = regexp.MustCompile(`^[-._A-Za-z0-9]+$`)
+
+// ingressNginxAnnotationValidators validate the values of the ingress-nginx annotations. An ingress-nginx annotation
+// with an invalid value is not translated, so that the value never reaches the NGINX configuration.
+var ingressNginxAnnotationValidators = map[string]func(string) error{
+	ingressNginxAnnotationPrefix + "rewrite-target":         validateIngressNginxRewriteTarget,
+	ingressNginxAnnotationPrefix + "ssl-redirect":           validateIngressNginxBool,
+	ingressNginxAnnotationPrefix + "proxy-body-size":        validateIngressNginxOffset,
+	ingressNginxAnnotationPrefix + "proxy-connect-timeout":  validateIngressNginxTimeout,
+	ingressNginxAnnotationPrefix + "proxy-read-timeout":     validateIngressNginxTimeout,
+	ingressNginxAnnotationPrefix + "proxy-send-timeout":     validateIngressNginxTimeout,
+	ingressNginxAnnotationPrefix + "session-cookie-name":    validateIngressNginxCookieName,
+	ingressNginxAnnotationPrefix + "session-cookie-path":    validateIngressNginxPath,
+	ingressNginxAnnotationPrefix + "session-cookie-max-age": validateIngressNginxTimeout,
+}
+
+func validateIngressNginxRewriteTarget(value string) error {
+	if strings.Contains(value, "$") {
+		return errors.New("capture groups are not supported")
+	}
+	return validateIngressNginxPath(value)
+}
+
+func validateIngressNginxPath(value string) error {
+	if !ingressNginxPathRegexp.MatchString(value) {
+		return fmt.Errorf("must be a path that starts with / and doesn't include whitespace or any of the characters %s", `"'\{};=$`)
+	}
+	return nil
+}
+
+func validateIngressNginxBool(value string) error {
+	_, err := ParseBool(value)
+	return err
+}
+
+func validateIngressNginxOffset(value string) error {
+	_, err := ParseOffset(value)
+	return err
+}
+
+func validateIngressNginxTimeout(value string) error {
+	_, err := ParseTime(translateIngressNginxTimeout(value))
+	return err
+}
+
+func validateIngressNginxCookieName(value string) error {
+	if !ingressNginxCookieNameRegexp.MatchString(value) {
+		return errors.New("a valid cookie name must consist of alphanumeric characters, '-', '.' or '_'")
+	}
+	return nil
+}
+
+// getIngressNginxAnnotations returns the sorted ingress-nginx annotations of the Ingress.
+func getIngressNginxAnnotations(ing *networking.Ingress) []string {
+	var keys []string
+
+	for key := range ing.Annotations {
+		if strings.HasPrefix(key, ingressNginxAnnotationPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// translateIngressNginxAnnotations replaces the supported ingress-nginx annotations of the Ingress with the corresponding
+// annotations of the Ingress Controller and removes the unsupported ones. The annotations of the Ingress Controller
+// already present in the Ingress take precedence. The ingress-nginx annotations with invalid values are not translated.
+// The function modifies the Ingress and returns the warnings about the ingress-nginx annotations that were not translated
+// or that were translated with a different behavior.
+func translateIngressNginxAnnotations(ing *networking.Ingress) []string {
+	keys := getIngressNginxAnnotations(ing)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	var unsupported []string
+	translated := make(map[string]string)
+	invalid := make(map[string]bool)
+
+	for _, key := range keys {
+		validate, exists := ingressNginxAnnotationValidators[key]
+		if !exists {
+			continue
+		}
+		if err := validate(ing.Annotations[key]); err != nil {
+			warnings = append(warnings, fmt.Sprintf("annotation %s: invalid value %q: %v", key, ing.Annotations[key], err))
+			invalid[key] = true
+		}
+	}
+
+	services := getIngressBackendServices(ing)
+
+	for _, key := range keys {
+		value := ing.Annotations[key]
+
+		if invalid[key] {
+			continue
+		}
+
+		switch key {
+		case ingressNginxAnnotationPrefix + "rewrite-target":
+			warnings = append(warnings, fmt.Sprintf("annotation %s: the rewrite replaces only the path of the location that matches the request instead of the whole URI", key))
+			var rewrites []string
+			for _, svc := range services {
+				rewrites = append(rewrites, fmt.Sprintf("serviceName=%s rewrite=%s", svc, value))
+			}
+			translated["nginx.org/rewrites"] = strings.Join(rewrites, ";")
+		case ingressNginxAnnotationPrefix + "ssl-redirect":
+			translated["ingress.kubernetes.io/ssl-redirect"] = value
+		case ingressNginxAnnotationPrefix + "proxy-body-size":
+			translated["nginx.org/client-max-body-size"] = value
+		case ingressNginxAnnotationPrefix + "proxy-connect-timeout",
+			ingressNginxAnnotationPrefix + "proxy-read-timeout",
+			ingressNginxAnnotationPrefix + "proxy-send-timeout":
+			translated[ingressNginxTimeoutAnnotations[key]] = translateIngressNginxTimeout(value)
+		case ingressNginxAnnotationPrefix + "backend-protocol":
+			serviceList := strings.Join(services, ",")
+			switch strings.ToUpper(value) {
+			case "HTTP":
+			case "HTTPS":
+				translated["nginx.org/ssl-services"] = serviceList
+			case "GRPC":
+				translated["nginx.org/grpc-services"] = serviceList
+			case "GRPCS":
+				translated["nginx.org/grpc-services"] = serviceList
+				translated["nginx.org/ssl-services"] = serviceList
+			default:
+				warnings = append(warnings, fmt.Sprintf("annotation %s: protocol %q is not supported", key, value))
+			}
+		case ingressNginxAnnotationPrefix + "affinity":
+			if value != "cookie" {
+				warnings = append(warnings, fmt.Sprintf("annotation %s: affinity type %q is not supported", key, value))
+				break
+			}
+			if hasInvalidIngressNginxCookieAnnotation(invalid) {
+				warnings = append(warnings, fmt.Sprintf("annotation %s was not translated, because the session cookie annotations are invalid", key))
+				break
+			}
+			cookie := generateIngressNginxStickyCookie(ing.Annotations)
+			var stickyServices []string
+			for _, svc := range services {
+				stickyServices = append(stickyServices, fmt.Sprintf("serviceName=%s %s", svc, cookie))
+			}
+			translated["nginx.com/sticky-cookie-services"] = strings.Join(stickyServices, ";")
+		default:
+			if !ingressNginxCookieAnnotations[key] {
+				unsupported = append(unsupported, key)
+			}
+		}
+	}
+
+	for _, key := range keys {
+		delete(ing.Annotations, key)
+	}
+
+	if len(unsupported) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the ingress-nginx annotations %s are not supported and were ignored", strings.Join(unsupported, ",")))
+	}
+
+	var translatedKeys []string
+	for key := range translated {
+		translatedKeys = append(translatedKeys, key)
+	}
+	sort.Strings(translatedKeys)
+
+	for _, key := range translatedKeys {
+		if _, exists := ing.Annotations[key]; exists {
+			warnings = append(warnings, fmt.Sprintf("the ingress-nginx annotations were not translated into annotation %s, because the Ingress already has it", key))
+			continue
+		}
+		// a translation for the services of an Ingress without backends is empty
+		if translated[key] == "" {
+			continue
+		}
+		ing.Annotations[key] = translated[key]
+	}
+
+	return warnings
+}
+
+// translateIngressNginxTimeout converts an ingress-nginx timeout, which is a number of seconds, into an NGINX time.
+func translateIngressNginxTimeout(value string) string {
+	if _, err := strconv.Atoi(value); err == nil {
+		return value + "s"
+	}
+	return value
+}
+
+func hasInvalidIngressNginxCookieAnnotation(invalid map[string]bool) bool {
+	for key := range ingressNginxCookieAnnotations {
+		if invalid[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// generateIngressNginxStickyCookie generates the sticky cookie of the nginx.com/sticky-cookie-services annotation
+// from the ingress-nginx session cookie annotations.
+func generateIngressNginxStickyCookie(annotations map[string]string) string {
+	name := defaultIngressNginxSessionCookieName
+	if value, exists := annotations[ingressNginxAnnotationPrefix+"session-cookie-name"]; exists {
+		name = value
+	}
+
+	cookie := []string{name}
+
+	if value, exists := annotations[ingressNginxAnnotationPrefix+"session-cookie-max-age"]; exists {
+		cookie = append(cookie, "expires="+translateIngressNginxTimeout(value))
+	}
+	if value, exists := annotations[ingressNginxAnnotationPrefix+"session-cookie-path"]; exists {
+		cookie = append(cookie, "path="+value)
+	}
+
+	return strings.Join(cookie, " ")
+}
+
+// getIngressBackendServices returns the sorted names of the services of the backends of the Ingress.
+func getIngressBackendServices(ing *networking.Ingress) []string {
+	seen := make(map[string]bool)
+	var services []string
+
+	addService := func(backend *networking.IngressBackend) {
+		if backend == nil || backend.Service == nil || seen[backend.Service.Name] {
+			return
+		}
+		seen[backend.Service.Name] = true
+		services = append(services, backend.Service.Name)
+	}
+
+	addService(ing.Spec.DefaultBackend)
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addService(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+
+	sort.Strings(services)
+
+	return services
+}
+
+// applyIngressNginxAnnotations returns the IngressEx with the ingress-nginx annotations translated, if the translation
+// is enabled, along with the warnings about the ingress-nginx annotations. The original Ingress is not modified.
+func applyIngressNginxAnnotations(ingEx *IngressEx, enabled bool) (*IngressEx, []string) {
+	keys := getIngressNginxAnnotations(ingEx.Ingress)
+	if len(keys) == 0 {
+		return ingEx, nil
+	}
+
+	if !enabled {
+		return ingEx, []string{fmt.Sprintf("the ingress-nginx annotations %s are ignored, because the translation of the ingress-nginx annotations is not enabled",
+			strings.Join(keys, ","))}
+	}
+
+	translatedEx := *ingEx
+	translatedEx.Ingress = ingEx.Ingress.DeepCopy()
+	warnings := translateIngressNginxAnnotations(translatedEx.Ingress)
+
+	return &translatedEx, warnings
+}
@@ -0,0 +1,219 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createIngressWithIngressNginxAnnotations(annotations map[string]string) *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "cafe-ingress",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "tea-svc",
+				},
+			},
+			Rules: []networking.IngressRule{
+				{
+					Host: "cafe.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/coffee",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "coffee-svc",
+										},
+									},
+								},
+								{
+									Path: "/tea",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "tea-svc",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTranslateIngressNginxAnnotations(t *testing.T) {
+	tests := []struct {
+		annotations         map[string]string
+		expectedAnnotations map[string]string
+		expectedWarnings    []string
+		msg                 string
+	}{
+		{
+			annotations: map[string]string{
+				"kubernetes.io/ingress.class":                    "nginx",
+				"nginx.ingress.kubernetes.io/rewrite-target":     "/",
+				"nginx.ingress.kubernetes.io/ssl-redirect":       "false",
+				"nginx.ingress.kubernetes.io/proxy-body-size":    "8m",
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "120",
+				"nginx.ingress.kubernetes.io/proxy-send-timeout": "30s",
+				"nginx.ingress.kubernetes.io/backend-protocol":   "GRPCS",
+			},
+			expectedAnnotations: map[string]string{
+				"kubernetes.io/ingress.class":        "nginx",
+				"nginx.org/rewrites":                 "serviceName=coffee-svc rewrite=/;serviceName=tea-svc rewrite=/",
+				"ingress.kubernetes.io/ssl-redirect": "false",
+				"nginx.org/client-max-body-size":     "8m",
+				"nginx.org/proxy-read-timeout":       "120s",
+				"nginx.org/proxy-send-timeout":       "30s",
+				"nginx.org/grpc-services":            "coffee-svc,tea-svc",
+				"nginx.org/ssl-services":             "coffee-svc,tea-svc",
+			},
+			expectedWarnings: []string{
+				"annotation nginx.ingress.kubernetes.io/rewrite-target: the rewrite replaces only the path of the location that matches the request instead of the whole URI",
+			},
+			msg: "supported annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/affinity":               "cookie",
+				"nginx.ingress.kubernetes.io/session-cookie-name":    "route",
+				"nginx.ingress.kubernetes.io/session-cookie-max-age": "3600",
+				"nginx.ingress.kubernetes.io/session-cookie-path":    "/",
+			},
+			expectedAnnotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=coffee-svc route expires=3600s path=/;serviceName=tea-svc route expires=3600s path=/",
+			},
+			expectedWarnings: nil,
+			msg:              "cookie affinity",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/affinity": "cookie",
+			},
+			expectedAnnotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=coffee-svc INGRESSCOOKIE;serviceName=tea-svc INGRESSCOOKIE",
+			},
+			expectedWarnings: nil,
+			msg:              "cookie affinity with the default cookie",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target":     "/$2",
+				"nginx.ingress.kubernetes.io/backend-protocol":   "FCGI",
+				"nginx.ingress.kubernetes.io/affinity":           "balanced",
+				"nginx.ingress.kubernetes.io/enable-cors":        "true",
+				"nginx.ingress.kubernetes.io/use-regex":          "true",
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "120",
+				"nginx.org/proxy-read-timeout":                   "60s",
+			},
+			expectedAnnotations: map[string]string{
+				"nginx.org/proxy-read-timeout": "60s",
+			},
+			expectedWarnings: []string{
+				`annotation nginx.ingress.kubernetes.io/rewrite-target: invalid value "/$2": capture groups are not supported`,
+				`annotation nginx.ingress.kubernetes.io/affinity: affinity type "balanced" is not supported`,
+				`annotation nginx.ingress.kubernetes.io/backend-protocol: protocol "FCGI" is not supported`,
+				"the ingress-nginx annotations nginx.ingress.kubernetes.io/enable-cors,nginx.ingress.kubernetes.io/use-regex are not supported and were ignored",
+				"the ingress-nginx annotations were not translated into annotation nginx.org/proxy-read-timeout, because the Ingress already has it",
+			},
+			msg: "unsupported annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target":        "/tea; return 200",
+				"nginx.ingress.kubernetes.io/ssl-redirect":          "no",
+				"nginx.ingress.kubernetes.io/proxy-body-size":       "8m; include /etc/passwd",
+				"nginx.ingress.kubernetes.io/proxy-connect-timeout": "10;",
+				"nginx.ingress.kubernetes.io/proxy-read-timeout":    "60",
+			},
+			expectedAnnotations: map[string]string{
+				"nginx.org/proxy-read-timeout": "60s",
+			},
+			expectedWarnings: []string{
+				`annotation nginx.ingress.kubernetes.io/proxy-body-size: invalid value "8m; include /etc/passwd": Invalid offset string`,
+				`annotation nginx.ingress.kubernetes.io/proxy-connect-timeout: invalid value "10;": invalid time string`,
+				`annotation nginx.ingress.kubernetes.io/rewrite-target: invalid value "/tea; return 200": must be a path that starts with / and doesn't include whitespace or any of the characters "'\{};=$`,
+				`annotation nginx.ingress.kubernetes.io/ssl-redirect: invalid value "no": strconv.ParseBool: parsing "no": invalid syntax`,
+			},
+			msg: "invalid values",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/affinity":               "cookie",
+				"nginx.ingress.kubernetes.io/session-cookie-name":    "route;",
+				"nginx.ingress.kubernetes.io/session-cookie-max-age": "1h",
+				"nginx.ingress.kubernetes.io/session-cookie-path":    "/ httponly",
+			},
+			expectedAnnotations: map[string]string{},
+			expectedWarnings: []string{
+				`annotation nginx.ingress.kubernetes.io/session-cookie-name: invalid value "route;": a valid cookie name must consist of alphanumeric characters, '-', '.' or '_'`,
+				`annotation nginx.ingress.kubernetes.io/session-cookie-path: invalid value "/ httponly": must be a path that starts with / and doesn't include whitespace or any of the characters "'\{};=$`,
+				"annotation nginx.ingress.kubernetes.io/affinity was not translated, because the session cookie annotations are invalid",
+			},
+			msg: "cookie affinity with invalid session cookie annotations",
+		},
+	}
+
+	for _, test := range tests {
+		ing := createIngressWithIngressNginxAnnotations(test.annotations)
+
+		warnings := translateIngressNginxAnnotations(ing)
+
+		if diff := cmp.Diff(test.expectedAnnotations, ing.Annotations); diff != "" {
+			t.Errorf("translateIngressNginxAnnotations() returned unexpected annotations for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings); diff != "" {
+			t.Errorf("translateIngressNginxAnnotations() returned unexpected warnings for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestApplyIngressNginxAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/proxy-connect-timeout": "10",
+		"nginx.ingress.kubernetes.io/enable-cors":           "true",
+	}
+
+	ingEx := &IngressEx{
+		Ingress: createIngressWithIngressNginxAnnotations(annotations),
+	}
+
+	result, warnings := applyIngressNginxAnnotations(ingEx, false)
+	if result != ingEx {
+		t.Errorf("applyIngressNginxAnnotations() returned a different IngressEx when the translation is disabled")
+	}
+	expectedWarnings := []string{
+		"the ingress-nginx annotations nginx.ingress.kubernetes.io/enable-cors,nginx.ingress.kubernetes.io/proxy-connect-timeout are ignored, because the translation of the ingress-nginx annotations is not enabled",
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("applyIngressNginxAnnotations() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+
+	result, warnings = applyIngressNginxAnnotations(ingEx, true)
+	expectedAnnotations := map[string]string{
+		"nginx.org/proxy-connect-timeout": "10s",
+	}
+	if diff := cmp.Diff(expectedAnnotations, result.Ingress.Annotations); diff != "" {
+		t.Errorf("applyIngressNginxAnnotations() returned unexpected annotations (-want +got):\n%s", diff)
+	}
+	expectedWarnings = []string{
+		"the ingress-nginx annotations nginx.ingress.kubernetes.io/enable-cors are not supported and were ignored",
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("applyIngressNginxAnnotations() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+	if len(ingEx.Ingress.Annotations) != 2 {
+		t.Errorf("applyIngressNginxAnnotations() modified the annotations of the original Ingress: %v", ingEx.Ingress.Annotations)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,7 +24,7 @@ func TestGenerateNginxCfg(t *testing.T) {
 	expected := createExpectedConfigForCafeIngressEx(isPlus)
 
 	apRes := AppProtectResources{}
-	result, warnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, isPlus, false, &StaticConfigParams{}, false)
 
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateNginxCfg() returned unexpected result (-want +got):\n%s", diff)
@@ -64,7 +65,7 @@ func TestGenerateNginxCfgForJWT(t *testing.T) {
 	}
 
 	apRes := AppProtectResources{}
-	result, warnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, true, false, &StaticConfigParams{}, false)
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, true, false, &StaticConfigParams{}, false)
 
 	if !reflect.DeepEqual(result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth) {
 		t.Errorf("generateNginxCfg returned \n%v,  but expected \n%v", result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth)
@@ -83,7 +84,7 @@ func TestGenerateNginxCfgWithMissingTLSSecret(t *testing.T) {
 	configParams := NewDefaultConfigParams(false)
 
 	apRes := AppProtectResources{}
-	result, resultWarnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, false, false, &StaticConfigParams{}, false)
+	result, resultWarnings, _ := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, false, false, &StaticConfigParams{}, false)
 
 	expectedSSLRejectHandshake := true
 	expectedWarnings := Warnings{
@@ -107,7 +108,7 @@ func TestGenerateNginxCfgWithWildcardTLSSecret(t *testing.T) {
 	configParams := NewDefaultConfigParams(false)
 
 	apRes := AppProtectResources{}
-	result, warnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, false, false, &StaticConfigParams{}, true)
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, false, false, &StaticConfigParams{}, true)
 
 	resultServer := result.Servers[0]
 	if !reflect.DeepEqual(resultServer.SSLCertificate, pemFileNameForWildcardTLSSecret) {
@@ -121,6 +122,109 @@ func TestGenerateNginxCfgWithWildcardTLSSecret(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgWithPolicies(t *testing.T) {
+	tests := []struct {
+		policies               map[string]*conf_v1.Policy
+		annotation             string
+		expectedServer         func(server *version1.Server)
+		expectedWarnings       []string
+		expectedPolicyWarnings map[string][]string
+		msg                    string
+	}{
+		{
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"127.0.0.1"},
+						},
+					},
+				},
+			},
+			annotation: "allow-policy",
+			expectedServer: func(server *version1.Server) {
+				server.Allow = []string{"127.0.0.1"}
+			},
+			msg: "access control policy",
+		},
+		{
+			policies:   map[string]*conf_v1.Policy{},
+			annotation: "allow-policy",
+			expectedServer: func(server *version1.Server) {
+				server.PoliciesErrorReturn = &version1.Return{Code: 500}
+			},
+			expectedWarnings: []string{
+				"Policy default/allow-policy is missing or invalid",
+			},
+			expectedPolicyWarnings: map[string][]string{
+				"default/allow-policy": {"Policy default/allow-policy is missing or invalid"},
+			},
+			msg: "missing policy",
+		},
+		{
+			policies: map[string]*conf_v1.Policy{
+				"policies/waf-policy": {
+					Spec: conf_v1.PolicySpec{
+						WAF: &conf_v1.WAF{
+							Enable: true,
+						},
+					},
+				},
+			},
+			annotation: "policies/waf-policy",
+			expectedServer: func(server *version1.Server) {
+				server.PoliciesErrorReturn = &version1.Return{Code: 500}
+			},
+			expectedWarnings: []string{
+				"Policy policies/waf-policy is not supported in Ingress resources",
+			},
+			expectedPolicyWarnings: map[string][]string{
+				"policies/waf-policy": {"Policy policies/waf-policy is not supported in Ingress resources"},
+			},
+			msg: "unsupported policy",
+		},
+	}
+
+	for _, test := range tests {
+		cafeIngressEx := createCafeIngressEx()
+		cafeIngressEx.Ingress.Annotations["nginx.org/policies"] = test.annotation
+		cafeIngressEx.Policies = test.policies
+		isPlus := false
+		configParams := NewDefaultConfigParams(isPlus)
+
+		expected := createExpectedConfigForCafeIngressEx(isPlus)
+		expected.Ingress.Annotations["nginx.org/policies"] = test.annotation
+		test.expectedServer(&expected.Servers[0])
+
+		var expectedWarnings Warnings
+		if test.expectedWarnings != nil {
+			expectedWarnings = Warnings{
+				cafeIngressEx.Ingress: test.expectedWarnings,
+			}
+		} else {
+			expectedWarnings = newWarnings()
+		}
+
+		expectedPolicyWarnings := newPolicyWarnings()
+		if test.expectedPolicyWarnings != nil {
+			expectedPolicyWarnings[cafeIngressEx.Ingress] = test.expectedPolicyWarnings
+		}
+
+		apRes := AppProtectResources{}
+		result, warnings, policyWarnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+		if diff := cmp.Diff(expected, result); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected result (-want +got) for the case of %s:\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got) for the case of %s:\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(expectedPolicyWarnings, policyWarnings); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected policy warnings (-want +got) for the case of %s:\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestPathOrDefaultReturnDefault(t *testing.T) {
 	path := ""
 	expected := "/"
@@ -141,15 +245,40 @@ func TestGenerateIngressPath(t *testing.T) {
 	prefix := networking.PathTypePrefix
 	impSpec := networking.PathTypeImplementationSpecific
 	tests := []struct {
-		pathType *networking.PathType
-		path     string
-		expected string
+		pathType  *networking.PathType
+		path      string
+		pathRegex string
+		expected  string
 	}{
 		{
 			pathType: &exact,
 			path:     "/path/to/resource",
 			expected: "= /path/to/resource",
 		},
+		{
+			pathType:  &exact,
+			path:      "/path/to/resource",
+			pathRegex: PathRegexCaseSensitive,
+			expected:  "= /path/to/resource",
+		},
+		{
+			pathType:  &prefix,
+			path:      "/path/to/resource",
+			pathRegex: PathRegexCaseInsensitive,
+			expected:  "/path/to/resource",
+		},
+		{
+			pathType:  &impSpec,
+			path:      "/path/to/.*[.]jpg$",
+			pathRegex: PathRegexCaseSensitive,
+			expected:  `~ "/path/to/.*[.]jpg$"`,
+		},
+		{
+			pathType:  &impSpec,
+			path:      "/path/to/.*[.]jpg$",
+			pathRegex: PathRegexCaseInsensitive,
+			expected:  `~* "/path/to/.*[.]jpg$"`,
+		},
 		{
 			pathType: &prefix,
 			path:     "/path/to/resource",
@@ -167,9 +296,9 @@ func TestGenerateIngressPath(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		result := generateIngressPath(test.path, test.pathType)
+		result := generateIngressPath(test.path, test.pathType, test.pathRegex)
 		if result != test.expected {
-			t.Errorf("generateIngressPath(%v, %v) returned %v, but expected %v", test.path, test.pathType, result, test.expected)
+			t.Errorf("generateIngressPath(%v, %v, %q) returned %v, but expected %v", test.path, test.pathType, test.pathRegex, result, test.expected)
 		}
 	}
 }
@@ -363,7 +492,7 @@ func TestGenerateNginxCfgForMergeableIngresses(t *testing.T) {
 	configParams := NewDefaultConfigParams(isPlus)
 
 	masterApRes := AppProtectResources{}
-	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, false, false, &StaticConfigParams{}, false)
+	result, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, false, false, &StaticConfigParams{}, false)
 
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected result (-want +got):\n%s", diff)
@@ -373,6 +502,38 @@ func TestGenerateNginxCfgForMergeableIngresses(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgForMergeableIngressesWithIgnoredAnnotations(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/ssl-services"] = "coffee-svc"
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/rewrites"] = "serviceName=coffee-svc rewrite=/"
+	mergeableIngresses.Minions[0].Ingress.Annotations["nginx.org/hsts"] = "true"
+	mergeableIngresses.Master.AnnotationWarnings = []string{"annotation nginx.org/proxy-read-timout is not recognized and is ignored; did you mean nginx.org/proxy-read-timeout?"}
+	mergeableIngresses.Minions[0].AnnotationWarnings = []string{"annotation nginx.org/unknown is not recognized and is ignored"}
+	master := mergeableIngresses.Master.Ingress
+	minion := mergeableIngresses.Minions[0].Ingress
+
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedWarnings := Warnings{
+		master: {
+			"annotation nginx.org/proxy-read-timout is not recognized and is ignored; did you mean nginx.org/proxy-read-timeout?",
+			"annotation(s) nginx.org/rewrites,nginx.org/ssl-services are ignored in a master Ingress",
+		},
+		minion: {
+			"annotation nginx.org/unknown is not recognized and is ignored",
+			"annotation(s) nginx.org/hsts are ignored in a minion Ingress",
+		},
+	}
+
+	masterApRes := AppProtectResources{}
+	_, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateNginxConfigForCrossNamespaceMergeableIngresses(t *testing.T) {
 	mergeableIngresses := createMergeableCafeIngress()
 	// change the namespaces of the minions to be coffee and tea
@@ -388,7 +549,7 @@ func TestGenerateNginxConfigForCrossNamespaceMergeableIngresses(t *testing.T) {
 	configParams := NewDefaultConfigParams(false)
 
 	emptyApResources := AppProtectResources{}
-	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, emptyApResources, configParams, false, false, &StaticConfigParams{}, false)
+	result, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, emptyApResources, configParams, false, false, &StaticConfigParams{}, false)
 
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected result (-want +got):\n%s", diff)
@@ -453,7 +614,7 @@ func TestGenerateNginxCfgForMergeableIngressesForJWT(t *testing.T) {
 	configParams := NewDefaultConfigParams(isPlus)
 
 	masterApRes := AppProtectResources{}
-	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, isPlus, false, &StaticConfigParams{}, false)
+	result, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, isPlus, false, &StaticConfigParams{}, false)
 
 	if !reflect.DeepEqual(result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth) {
 		t.Errorf("generateNginxCfgForMergeableIngresses returned \n%v,  but expected \n%v", result.Servers[0].JWTAuth, expected.Servers[0].JWTAuth)
@@ -861,7 +1022,7 @@ func TestGenerateNginxCfgForSpiffe(t *testing.T) {
 	}
 
 	apResources := AppProtectResources{}
-	result, warnings := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, false, false,
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, false, false,
 		&StaticConfigParams{NginxServiceMesh: true}, false)
 
 	if diff := cmp.Diff(expected, result); diff != "" {
@@ -884,7 +1045,7 @@ func TestGenerateNginxCfgForInternalRoute(t *testing.T) {
 	expected.Ingress.Annotations[internalRouteAnnotation] = "true"
 
 	apResources := AppProtectResources{}
-	result, warnings := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, false, false,
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, false, false,
 		&StaticConfigParams{NginxServiceMesh: true, EnableInternalRoutes: true}, false)
 
 	if diff := cmp.Diff(expected, result); diff != "" {
@@ -895,6 +1056,317 @@ func TestGenerateNginxCfgForInternalRoute(t *testing.T) {
 	}
 }
 
+func createCafeCanaryIngressEx(annotations map[string]string) *IngressEx {
+	canaryAnnotations := map[string]string{
+		"kubernetes.io/ingress.class": "nginx",
+		"nginx.org/canary":            "true",
+	}
+	for k, v := range annotations {
+		canaryAnnotations[k] = v
+	}
+
+	canaryIngress := networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "cafe-canary",
+			Namespace:   "default",
+			Annotations: canaryAnnotations,
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "cafe.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/coffee",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "coffee-v2-svc",
+											Port: networking.ServiceBackendPort{
+												Number: 80,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &IngressEx{
+		Ingress: &canaryIngress,
+		Endpoints: map[string][]string{
+			"coffee-v2-svc80": {"10.0.0.3:80"},
+		},
+		ExternalNameSvcs: map[string]bool{},
+		ValidHosts: map[string]bool{
+			"cafe.example.com": true,
+		},
+	}
+}
+
+func TestGenerateNginxCfgForCanary(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	canaryEx := createCafeCanaryIngressEx(map[string]string{
+		"nginx.org/canary-weight": "20",
+	})
+	cafeIngressEx.Canaries = map[string]*IngressEx{
+		CanaryPathKey("cafe.example.com", "/coffee"): canaryEx,
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedSplitClients := []version1.SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$ing_default_cafe_ingress_c073cf0c_canary_0_weight",
+			Distributions: []version1.Distribution{
+				{
+					Weight: "20%",
+					Value:  "default-cafe-canary-cafe.example.com-coffee-v2-svc-80",
+				},
+				{
+					Weight: "*",
+					Value:  "default-cafe-ingress-cafe.example.com-coffee-svc-80",
+				},
+			},
+		},
+	}
+
+	apResources := AppProtectResources{}
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedSplitClients, result.SplitClients); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected split clients (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned warnings: %v", warnings)
+	}
+
+	locations := result.Servers[0].Locations
+	if locations[0].CanaryVariable != "$ing_default_cafe_ingress_c073cf0c_canary_0_weight" {
+		t.Errorf("generateNginxCfg() returned location %s with the canary variable %q", locations[0].Path, locations[0].CanaryVariable)
+	}
+	if locations[1].CanaryVariable != "" {
+		t.Errorf("generateNginxCfg() returned location %s with the canary variable %q", locations[1].Path, locations[1].CanaryVariable)
+	}
+
+	foundCanaryUpstream := false
+	for _, u := range result.Upstreams {
+		if u.Name == "default-cafe-canary-cafe.example.com-coffee-v2-svc-80" {
+			foundCanaryUpstream = true
+		}
+	}
+	if !foundCanaryUpstream {
+		t.Errorf("generateNginxCfg() didn't return the upstream of the canary")
+	}
+}
+
+func TestGenerateNginxCfgForCanaryWithRewrite(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/rewrites"] = "serviceName=coffee-svc rewrite=/beans"
+	canaryEx := createCafeCanaryIngressEx(map[string]string{
+		"nginx.org/canary-weight": "20",
+	})
+	cafeIngressEx.Canaries = map[string]*IngressEx{
+		CanaryPathKey("cafe.example.com", "/coffee"): canaryEx,
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedWarnings := Warnings{
+		canaryEx.Ingress: {
+			"canary for path /coffee of host cafe.example.com is ignored, because the path of Ingress default/cafe-ingress has a rewrite",
+		},
+	}
+
+	apResources := AppProtectResources{}
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if len(result.SplitClients) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected split clients: %v", result.SplitClients)
+	}
+	if result.Servers[0].Locations[0].CanaryVariable != "" {
+		t.Errorf("generateNginxCfg() returned the canary variable %q", result.Servers[0].Locations[0].CanaryVariable)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgWithAnnotationWarnings(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.AnnotationWarnings = []string{
+		"annotation nginx.org/proxy-read-timout is not recognized and is ignored; did you mean nginx.org/proxy-read-timeout?",
+	}
+	canaryEx := createCafeCanaryIngressEx(map[string]string{
+		"nginx.org/canary-weight": "20",
+	})
+	canaryEx.AnnotationWarnings = []string{
+		"annotation nginx.org/canary-wieght is not recognized and is ignored; did you mean nginx.org/canary-weight?",
+	}
+	cafeIngressEx.Canaries = map[string]*IngressEx{
+		CanaryPathKey("cafe.example.com", "/coffee"): canaryEx,
+		CanaryPathKey("cafe.example.com", "/tea"):    canaryEx,
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedWarnings := Warnings{
+		cafeIngressEx.Ingress: {
+			"annotation nginx.org/proxy-read-timout is not recognized and is ignored; did you mean nginx.org/proxy-read-timeout?",
+		},
+		canaryEx.Ingress: {
+			"annotation nginx.org/canary-wieght is not recognized and is ignored; did you mean nginx.org/canary-weight?",
+		},
+	}
+
+	apResources := AppProtectResources{}
+	_, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgWithIngressNginxAnnotations(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/beans"
+	cafeIngressEx.Ingress.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"] = "120"
+	cafeIngressEx.Ingress.Annotations["nginx.ingress.kubernetes.io/enable-cors"] = "true"
+	originalIngress := cafeIngressEx.Ingress
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+	staticParams := &StaticConfigParams{
+		EnableIngressNginxAnnotations: true,
+	}
+
+	expectedWarnings := Warnings{
+		originalIngress: {
+			"annotation nginx.ingress.kubernetes.io/rewrite-target: the rewrite replaces only the path of the location that matches the request instead of the whole URI",
+			"the ingress-nginx annotations nginx.ingress.kubernetes.io/enable-cors are not supported and were ignored",
+		},
+	}
+
+	apResources := AppProtectResources{}
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, isPlus, false, staticParams, false)
+
+	for _, loc := range result.Servers[0].Locations {
+		if loc.Rewrite != "/beans" {
+			t.Errorf("generateNginxCfg() returned the rewrite %q for the location %s but expected %q", loc.Rewrite, loc.Path, "/beans")
+		}
+		if loc.ProxyReadTimeout != "120s" {
+			t.Errorf("generateNginxCfg() returned the read timeout %q for the location %s but expected %q", loc.ProxyReadTimeout, loc.Path, "120s")
+		}
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+	if _, exists := originalIngress.Annotations["nginx.org/rewrites"]; exists {
+		t.Errorf("generateNginxCfg() modified the annotations of the original Ingress")
+	}
+}
+
+func TestGenerateNginxCfgForMergeableIngressesWithIngressNginxAnnotations(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	mergeableIngresses.Master.Ingress.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"] = "120"
+	mergeableIngresses.Master.Ingress.Annotations["nginx.ingress.kubernetes.io/enable-cors"] = "true"
+	originalMaster := mergeableIngresses.Master.Ingress
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+	staticParams := &StaticConfigParams{
+		EnableIngressNginxAnnotations: true,
+	}
+
+	expectedWarnings := Warnings{
+		originalMaster: {
+			"the ingress-nginx annotations nginx.ingress.kubernetes.io/enable-cors are not supported and were ignored",
+		},
+	}
+
+	masterApRes := AppProtectResources{}
+	result, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, masterApRes, configParams, isPlus, false, staticParams, false)
+
+	for _, loc := range result.Servers[0].Locations {
+		if loc.ProxyReadTimeout != "120s" {
+			t.Errorf("generateNginxCfgForMergeableIngresses() returned the read timeout %q for the location %s but expected %q", loc.ProxyReadTimeout, loc.Path, "120s")
+		}
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgWithPathRegex(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/path-regex"] = "case_insensitive"
+	cafeIngressEx.Ingress.Annotations["nginx.org/rewrites"] = "serviceName=coffee-svc rewrite=/beans"
+	implementationSpecific := networking.PathTypeImplementationSpecific
+	exact := networking.PathTypeExact
+	paths := cafeIngressEx.Ingress.Spec.Rules[0].HTTP.Paths
+	paths[0].Path = "/coffee/[0-9]+$"
+	paths[0].PathType = &implementationSpecific
+	paths[1].PathType = &exact
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedPaths := []string{
+		"= /tea",
+		`~* "/coffee/[0-9]+$"`,
+	}
+	expectedWarnings := Warnings{
+		cafeIngressEx.Ingress: {
+			"rewrite for service coffee-svc is ignored for path /coffee/[0-9]+$ of host cafe.example.com, because the path is a regular expression",
+		},
+	}
+
+	apResources := AppProtectResources{}
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apResources, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	var resultPaths []string
+	for _, loc := range result.Servers[0].Locations {
+		resultPaths = append(resultPaths, loc.Path)
+		if loc.Rewrite != "" {
+			t.Errorf("generateNginxCfg() returned the rewrite %q for the location %s but expected no rewrite", loc.Rewrite, loc.Path)
+		}
+	}
+	if diff := cmp.Diff(expectedPaths, resultPaths); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected location paths (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestSortLocations(t *testing.T) {
+	locations := []version1.Location{
+		{Path: `~ "^/images/.*[.]png$"`},
+		{Path: "/"},
+		{Path: "= /login"},
+		{Path: `~* "[.]jpg$"`},
+		{Path: "/images"},
+		{Path: "= /logout"},
+	}
+	expected := []version1.Location{
+		{Path: "= /login"},
+		{Path: "= /logout"},
+		{Path: "/"},
+		{Path: "/images"},
+		{Path: `~ "^/images/.*[.]png$"`},
+		{Path: `~* "[.]jpg$"`},
+	}
+
+	sortLocations(locations)
+
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("sortLocations() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestIsSSLEnabled(t *testing.T) {
 	type testCase struct {
 		IsSSLService,
@@ -1364,7 +1836,7 @@ func TestGenerateNginxCfgForAppProtect(t *testing.T) {
 	expected.Servers[0].AppProtectLogEnable = "on"
 	expected.Ingress.Annotations = cafeIngressEx.Ingress.Annotations
 
-	result, warnings := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, isPlus, false, staticCfgParams, false)
+	result, warnings, _ := generateNginxCfg(&cafeIngressEx, apRes, false, configParams, isPlus, false, staticCfgParams, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateNginxCfg() returned unexpected result (-want +got):\n%s", diff)
 	}
@@ -1415,7 +1887,7 @@ func TestGenerateNginxCfgForMergeableIngressesForAppProtect(t *testing.T) {
 	expected.Servers[0].AppProtectLogEnable = "on"
 	expected.Ingress.Annotations = mergeableIngresses.Master.Ingress.Annotations
 
-	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, apRes, configParams, isPlus, false, staticCfgParams, false)
+	result, warnings, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, apRes, configParams, isPlus, false, staticCfgParams, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected result (-want +got):\n%s", diff)
 	}
@@ -0,0 +1,18 @@
+/*
+ * JavaScript functions for providing API key authentication with NGINX Plus
+ *
+ * Copyright (C) 2021 Nginx, Inc.
+ */
+import c from 'crypto';
+
+export default { hash };
+
+// hash returns the SHA-256 hash of the API key of the request in the hex format,
+// so that the key can be compared with the hashes of the keys of the clients.
+function hash(r) {
+    var token = r.variables.apikey_auth_token;
+    if (!token) {
+        return "";
+    }
+    return c.createHash('sha256').update(token).digest('hex');
+}
@@ -7,12 +7,32 @@ var newSession = false; // Used by oidcAuth() and validateIdToken()
 
 export default { auth, codeExchange, validateIdToken, logout };
 
+// Several OIDC providers can be used in the same server. The key-value variables and the internal
+// locations of a provider are suffixed with its ID ($oidc_id), and its cookies are prefixed with
+// the name of its session cookie ($oidc_cookie_name).
+function providerVar(r, name) {
+    return name + "_" + r.variables.oidc_id;
+}
+
+function providerLocation(r, location) {
+    return location + "_" + r.variables.oidc_id;
+}
+
+function cookieName(r, suffix) {
+    return r.variables.oidc_cookie_name + suffix;
+}
+
+function getCookie(r, suffix) {
+    return r.variables["cookie_" + cookieName(r, suffix)];
+}
+
 function auth(r) {
-    if (!r.variables.refresh_token || r.variables.refresh_token == "-") {
+    var refreshToken = r.variables[providerVar(r, "refresh_token")];
+    if (!refreshToken || refreshToken == "-") {
         newSession = true;
 
         // Check we have all necessary configuration variables (referenced only by njs)
-        var oidcConfigurables = ["authz_endpoint", "scopes", "hmac_key", "cookie_flags"];
+        var oidcConfigurables = ["id", "cookie_name", "authz_endpoint", "scopes", "hmac_key", "cookie_flags"];
         var missingConfig = [];
         for (var i in oidcConfigurables) {
             if (!r.variables["oidc_" + oidcConfigurables[i]] || r.variables["oidc_" + oidcConfigurables[i]] == "") {
@@ -31,7 +51,7 @@ function auth(r) {
 
     // Pass the refresh token to the /_refresh location so that it can be
     // proxied to the IdP in exchange for a new id_token
-    r.subrequest("/_refresh", "token=" + r.variables.refresh_token,
+    r.subrequest(providerLocation(r, "/_refresh"), "token=" + refreshToken,
         function (reply) {
             if (reply.status != 200) {
                 // Refresh request failed, log the reason
@@ -51,7 +71,7 @@ function auth(r) {
                 r.error(error_log);
 
                 // Clear the refresh token, try again
-                r.variables.refresh_token = "-";
+                r.variables[providerVar(r, "refresh_token")] = "-";
                 r.return(302, r.variables.request_uri);
                 return;
             }
@@ -64,28 +84,28 @@ function auth(r) {
                     if (tokenset.error) {
                         r.error("OIDC " + tokenset.error + " " + tokenset.error_description);
                     }
-                    r.variables.refresh_token = "-";
+                    r.variables[providerVar(r, "refresh_token")] = "-";
                     r.return(302, r.variables.request_uri);
                     return;
                 }
 
                 // Send the new ID Token to auth_jwt location for validation
-                r.subrequest("/_id_token_validation", "token=" + tokenset.id_token,
+                r.subrequest(providerLocation(r, "/_id_token_validation"), "token=" + tokenset.id_token,
                     function (reply) {
                         if (reply.status != 204) {
-                            r.variables.refresh_token = "-";
+                            r.variables[providerVar(r, "refresh_token")] = "-";
                             r.return(302, r.variables.request_uri);
                             return;
                         }
 
                         // ID Token is valid, update keyval
-                        r.log("OIDC refresh success, updating id_token for " + r.variables.cookie_auth_token);
-                        r.variables.session_jwt = tokenset.id_token; // Update key-value store
+                        r.log("OIDC refresh success, updating id_token for " + getCookie(r, ""));
+                        r.variables[providerVar(r, "session_jwt")] = tokenset.id_token; // Update key-value store
 
                         // Update refresh token (if we got a new one)
-                        if (r.variables.refresh_token != tokenset.refresh_token) {
-                            r.log("OIDC replacing previous refresh token (" + r.variables.refresh_token + ") with new value: " + tokenset.refresh_token);
-                            r.variables.refresh_token = tokenset.refresh_token; // Update key-value store
+                        if (refreshToken != tokenset.refresh_token) {
+                            r.log("OIDC replacing previous refresh token (" + refreshToken + ") with new value: " + tokenset.refresh_token);
+                            r.variables[providerVar(r, "refresh_token")] = tokenset.refresh_token; // Update key-value store
                         }
 
                         delete r.headersOut["WWW-Authenticate"]; // Remove evidence of original failed auth_jwt
@@ -93,7 +113,7 @@ function auth(r) {
                     }
                 );
             } catch (e) {
-                r.variables.refresh_token = "-";
+                r.variables[providerVar(r, "refresh_token")] = "-";
                 r.return(302, r.variables.request_uri);
                 return;
             }
@@ -115,7 +135,7 @@ function codeExchange(r) {
 
     // Pass the authorization code to the /_token location so that it can be
     // proxied to the IdP in exchange for a JWT
-    r.subrequest("/_token", idpClientAuth(r), function (reply) {
+    r.subrequest(providerLocation(r, "/_token"), idpClientAuth(r), function (reply) {
         if (reply.status == 504) {
             r.error("OIDC timeout connecting to IdP when sending authorization code");
             r.return(504);
@@ -147,7 +167,7 @@ function codeExchange(r) {
             }
 
             // Send the ID Token to auth_jwt location for validation
-            r.subrequest("/_id_token_validation", "token=" + tokenset.id_token,
+            r.subrequest(providerLocation(r, "/_id_token_validation"), "token=" + tokenset.id_token,
                 function (reply) {
                     if (reply.status != 204) {
                         r.return(500); // validateIdToken() will log errors
@@ -156,7 +176,7 @@ function codeExchange(r) {
 
                     // If the response includes a refresh token then store it
                     if (tokenset.refresh_token) {
-                        r.variables.new_refresh = tokenset.refresh_token; // Create key-value store entry
+                        r.variables[providerVar(r, "new_refresh")] = tokenset.refresh_token; // Create key-value store entry
                         r.log("OIDC refresh token stored");
                     } else {
                         r.warn("OIDC no refresh token");
@@ -164,9 +184,9 @@ function codeExchange(r) {
 
                     // Add opaque token to keyval session store
                     r.log("OIDC success, creating session " + r.variables.request_id);
-                    r.variables.new_session = tokenset.id_token; // Create key-value store entry
-                    r.headersOut["Set-Cookie"] = "auth_token=" + r.variables.request_id + "; " + r.variables.oidc_cookie_flags;
-                    r.return(302, r.variables.redirect_base + r.variables.cookie_auth_redir);
+                    r.variables[providerVar(r, "new_session")] = tokenset.id_token; // Create key-value store entry
+                    r.headersOut["Set-Cookie"] = cookieName(r, "") + "=" + r.variables.request_id + "; " + r.variables.oidc_cookie_flags;
+                    r.return(302, r.variables.redirect_base + getCookie(r, "_redir"));
                 }
             );
         } catch (e) {
@@ -208,14 +228,15 @@ function validateIdToken(r) {
         validToken = false;
     }
 
-    // If we receive a nonce in the ID Token then we will use the auth_nonce cookies
+    // If we receive a nonce in the ID Token then we will use the nonce cookie
     // to check that the JWT can be validated as being directly related to the
     // original request by this client. This mitigates against token replay attacks.
     if (newSession) {
         var client_nonce_hash = "";
-        if (r.variables.cookie_auth_nonce) {
+        var clientNonce = getCookie(r, "_nonce");
+        if (clientNonce) {
             var c = require('crypto');
-            var h = c.createHmac('sha256', r.variables.oidc_hmac_key).update(r.variables.cookie_auth_nonce);
+            var h = c.createHmac('sha256', r.variables.oidc_hmac_key).update(clientNonce);
             client_nonce_hash = h.digest('base64url');
         }
         if (r.variables.jwt_claim_nonce != client_nonce_hash) {
@@ -232,10 +253,26 @@ function validateIdToken(r) {
 }
 
 function logout(r) {
-    r.log("OIDC logout for " + r.variables.cookie_auth_token);
-    r.variables.session_jwt = "-";
-    r.variables.refresh_token = "-";
-    r.return(302, r.variables.oidc_logout_redirect);
+    r.log("OIDC logout for " + getCookie(r, ""));
+    var idToken = r.variables[providerVar(r, "session_jwt")];
+    r.variables[providerVar(r, "session_jwt")] = "-";
+    r.variables[providerVar(r, "refresh_token")] = "-";
+
+    var logoutRedirect = r.variables.oidc_logout_redirect;
+    if (!r.variables.oidc_end_session_endpoint) {
+        r.return(302, logoutRedirect);
+        return;
+    }
+
+    // Perform RP-initiated logout at the IdP, which redirects the client back afterwards
+    if (logoutRedirect.startsWith("/")) {
+        logoutRedirect = r.variables.redirect_base + logoutRedirect;
+    }
+    var logoutArgs = "?client_id=" + encodeURIComponent(r.variables.oidc_client) + "&post_logout_redirect_uri=" + encodeURIComponent(logoutRedirect);
+    if (idToken && idToken != "-") {
+        logoutArgs += "&id_token_hint=" + idToken;
+    }
+    r.return(302, r.variables.oidc_end_session_endpoint + logoutArgs);
 }
 
 function getAuthZArgs(r) {
@@ -247,15 +284,15 @@ function getAuthZArgs(r) {
     var authZArgs = "?response_type=code&scope=" + r.variables.oidc_scopes + "&client_id=" + r.variables.oidc_client + "&redirect_uri=" + r.variables.redirect_base + r.variables.redir_location + "&nonce=" + nonceHash;
 
     r.headersOut['Set-Cookie'] = [
-        "auth_redir=" + r.variables.request_uri + "; " + r.variables.oidc_cookie_flags,
-        "auth_nonce=" + noncePlain + "; " + r.variables.oidc_cookie_flags
+        cookieName(r, "_redir") + "=" + r.variables.request_uri + "; " + r.variables.oidc_cookie_flags,
+        cookieName(r, "_nonce") + "=" + noncePlain + "; " + r.variables.oidc_cookie_flags
     ];
 
     if (r.variables.oidc_pkce_enable == 1) {
         var pkce_code_verifier = c.createHmac('sha256', r.variables.oidc_hmac_key).update(String(Math.random())).digest('hex');
         r.variables.pkce_id = c.createHash('sha256').update(String(Math.random())).digest('base64url');
         var pkce_code_challenge = c.createHash('sha256').update(pkce_code_verifier).digest('base64url');
-        r.variables.pkce_code_verifier = pkce_code_verifier;
+        r.variables[providerVar(r, "pkce_code_verifier")] = pkce_code_verifier;
 
         authZArgs += "&code_challenge_method=S256&code_challenge=" + pkce_code_challenge + "&state=" + r.variables.pkce_id;
     } else {
@@ -268,7 +305,7 @@ function idpClientAuth(r) {
     // If PKCE is enabled we have to use the code_verifier
     if (r.variables.oidc_pkce_enable == 1) {
         r.variables.pkce_id = r.variables.arg_state;
-        return "code=" + r.variables.arg_code + "&code_verifier=" + r.variables.pkce_code_verifier;
+        return "code=" + r.variables.arg_code + "&code_verifier=" + r.variables[providerVar(r, "pkce_code_verifier")];
     } else {
         return "code=" + r.variables.arg_code + "&client_secret=" + r.variables.oidc_client_secret;
     }
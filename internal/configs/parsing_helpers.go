@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"strings"
 
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // There seems to be no composite interface in the kubernetes api package,
@@ -309,6 +311,62 @@ func ParseStickyServiceList(s string) (map[string]string, error) {
 	return services, nil
 }
 
+// ParseNamespaceList ensures that the string is a comma-separated list of namespaces.
+// The * wildcard matches all namespaces and can't be combined with namespaces.
+func ParseNamespaceList(s string) ([]string, error) {
+	var namespaces []string
+	for _, part := range strings.Split(s, ",") {
+		ns := strings.TrimSpace(part)
+		if ns == "*" {
+			if len(strings.Split(s, ",")) > 1 {
+				return nil, fmt.Errorf("Invalid namespace list %q: * can't be combined with namespaces", s)
+			}
+		} else if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+			return nil, fmt.Errorf("Invalid namespace %q: %s", ns, strings.Join(msgs, ", "))
+		}
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces, nil
+}
+
+// ParsePolicyList ensures that the string is a comma-separated list of Policy references.
+// Every reference is either the name of a Policy or its namespace and name separated by a slash.
+func ParsePolicyList(s string) ([]conf_v1.PolicyReference, error) {
+	var policies []conf_v1.PolicyReference
+	for _, part := range strings.Split(s, ",") {
+		policy, err := parsePolicyReference(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func parsePolicyReference(ref string) (conf_v1.PolicyReference, error) {
+	var policy conf_v1.PolicyReference
+
+	parts := strings.Split(ref, "/")
+	switch len(parts) {
+	case 1:
+		policy.Name = parts[0]
+	case 2:
+		policy.Namespace = parts[0]
+		policy.Name = parts[1]
+		if msgs := validation.IsDNS1123Label(policy.Namespace); len(msgs) > 0 {
+			return policy, fmt.Errorf("Invalid policy namespace %q: %s", policy.Namespace, strings.Join(msgs, ", "))
+		}
+	default:
+		return policy, fmt.Errorf("Invalid policy format: %s", ref)
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(policy.Name); len(msgs) > 0 {
+		return policy, fmt.Errorf("Invalid policy name %q: %s", policy.Name, strings.Join(msgs, ", "))
+	}
+
+	return policy, nil
+}
+
 func parseStickyService(service string) (serviceName string, stickyCookie string, err error) {
 	parts := strings.SplitN(service, " ", 2)
 
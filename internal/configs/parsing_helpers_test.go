@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -442,6 +443,78 @@ func TestParseSize(t *testing.T) {
 	}
 }
 
+func TestParsePolicyList(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []conf_v1.PolicyReference
+	}{
+		{
+			input: "access-control",
+			expected: []conf_v1.PolicyReference{
+				{Name: "access-control"},
+			},
+		},
+		{
+			input: "access-control, policies/rate-limit,jwt.v1",
+			expected: []conf_v1.PolicyReference{
+				{Name: "access-control"},
+				{Namespace: "policies", Name: "rate-limit"},
+				{Name: "jwt.v1"},
+			},
+		},
+	}
+	for _, test := range tests {
+		result, err := ParsePolicyList(test.input)
+		if err != nil {
+			t.Errorf("ParsePolicyList(%q) returned an error for valid input: %v", test.input, err)
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ParsePolicyList(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	invalidInput := []string{"", "access-control,", "a/b/c", "Access-Control", "policies.ns/rate-limit", "/rate-limit"}
+	for _, test := range invalidInput {
+		result, err := ParsePolicyList(test)
+		if err == nil {
+			t.Errorf("ParsePolicyList(%q) didn't return error. Returned: %v", test, result)
+		}
+	}
+}
+
+func TestParseNamespaceList(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "*",
+			expected: []string{"*"},
+		},
+		{
+			input:    "tea, coffee",
+			expected: []string{"tea", "coffee"},
+		},
+	}
+	for _, test := range tests {
+		result, err := ParseNamespaceList(test.input)
+		if err != nil {
+			t.Errorf("ParseNamespaceList(%q) returned an error for valid input: %v", test.input, err)
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ParseNamespaceList(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	invalidInput := []string{"", "tea,", "tea,*", "Tea", "tea.coffee", "tea/coffee"}
+	for _, test := range invalidInput {
+		result, err := ParseNamespaceList(test)
+		if err == nil {
+			t.Errorf("ParseNamespaceList(%q) didn't return error. Returned: %v", test, result)
+		}
+	}
+}
+
 func TestParseProxyBuffersSpec(t *testing.T) {
 	testsWithValidInput := []string{"1 1k", "10 24k", "2 2K", "6 3m", "128 3M"}
 	invalidInput := []string{"-1", "-6 2k", "", "blah", "16k", "10M", "2 4g", "3 4G"}
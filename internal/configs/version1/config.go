@@ -15,6 +15,9 @@ type IngressNginxConfig struct {
 	Keepalive         string
 	Ingress           Ingress
 	SpiffeClientCerts bool
+	Maps              []Map
+	LimitReqZones     []LimitReqZone
+	SplitClients      []SplitClient
 }
 
 // Ingress holds information about an Ingress resource.
@@ -92,6 +95,15 @@ type Server struct {
 
 	JWTAuth              *JWTAuth
 	JWTRedirectLocations []JWTRedirectLocation
+	JwksURIs             []JwksURI
+
+	Allow               []string
+	Deny                []string
+	LimitReqOptions     LimitReqOptions
+	LimitReqs           []LimitReq
+	IngressMTLS         *IngressMTLS
+	EgressMTLS          *EgressMTLS
+	PoliciesErrorReturn *Return
 
 	Ports               []int
 	SSLPorts            []int
@@ -115,6 +127,104 @@ type JWTAuth struct {
 	Realm                string
 	Token                string
 	RedirectLocationName string
+	JwksURI              *JwksURI
+	Require              []string
+}
+
+// JwksURI defines the location for fetching the JSON Web Key Set of JWT authentication from a remote URI.
+type JwksURI struct {
+	Location    string
+	URI         string
+	CacheZone   string
+	KeyCache    string
+	SNIEnabled  bool
+	SNIName     string
+	SSLVerify   bool
+	TrustedCert string
+	SSLName     string
+}
+
+// Map defines a map.
+type Map struct {
+	Source     string
+	Variable   string
+	Parameters []Parameter
+}
+
+// SplitClient defines a split_clients.
+type SplitClient struct {
+	Source        string
+	Variable      string
+	Distributions []Distribution
+}
+
+// Distribution maps weight to a value in a SplitClient.
+type Distribution struct {
+	Weight string
+	Value  string
+}
+
+// Parameter defines a Parameter in a Map.
+type Parameter struct {
+	Value  string
+	Result string
+}
+
+// LimitReqZone defines a rate limit shared memory zone.
+type LimitReqZone struct {
+	Key      string
+	ZoneName string
+	ZoneSize string
+	Rate     string
+}
+
+// LimitReq defines a rate limit.
+type LimitReq struct {
+	ZoneName string
+	Burst    int
+	NoDelay  bool
+	Delay    int
+}
+
+// LimitReqOptions defines rate limit options.
+type LimitReqOptions struct {
+	DryRun     bool
+	LogLevel   string
+	RejectCode int
+}
+
+// IngressMTLS defines TLS configuration for a server. This is a subset of TLS specifically for clients auth.
+type IngressMTLS struct {
+	ClientCert        string
+	ClientCRL         string
+	VerifyClient      string
+	VerifyDepth       int
+	ClientCertHeaders []Header
+}
+
+// EgressMTLS defines TLS configuration for a location.
+type EgressMTLS struct {
+	Certificate    string
+	CertificateKey string
+	VerifyServer   bool
+	VerifyDepth    int
+	Ciphers        string
+	Protocols      string
+	TrustedCert    string
+	SessionReuse   bool
+	ServerName     bool
+	SSLName        string
+}
+
+// Header defines a header.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Return defines a Return directive.
+type Return struct {
+	Code int
 }
 
 // Location describes an NGINX location.
@@ -137,6 +247,14 @@ type Location struct {
 	ProxySSLName         string
 	JWTAuth              *JWTAuth
 	ServiceName          string
+	CanaryVariable       string
+
+	Allow               []string
+	Deny                []string
+	LimitReqOptions     LimitReqOptions
+	LimitReqs           []LimitReq
+	EgressMTLS          *EgressMTLS
+	PoliciesErrorReturn *Return
 
 	MinionIngress *Ingress
 }
@@ -2,6 +2,7 @@ package version1
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"text/template"
 )
@@ -93,6 +94,175 @@ var ingCfg = IngressNginxConfig{
 	},
 }
 
+var ingCfgWithPolicies = IngressNginxConfig{
+	Servers: []Server{
+		{
+			Name:              "test.example.com",
+			ServerTokens:      "off",
+			StatusZone:        "test.example.com",
+			SSL:               true,
+			SSLCertificate:    "secret.pem",
+			SSLCertificateKey: "secret.pem",
+			SSLPorts:          []int{443},
+			Allow:             []string{"10.0.0.0/8"},
+			LimitReqOptions: LimitReqOptions{
+				LogLevel:   "error",
+				RejectCode: 503,
+			},
+			LimitReqs: []LimitReq{
+				{
+					ZoneName: "pol_rl_default_rate-limit_default_ing_cafe-ingress",
+					Burst:    10,
+				},
+			},
+			JWTAuth: &JWTAuth{
+				Realm: "closed site",
+				JwksURI: &JwksURI{
+					Location:  "/_pol_jwks_default_jwt_default_ing_cafe-ingress",
+					URI:       "https://idp.example.com/keys",
+					CacheZone: "pol_jwks_default_jwt_default_ing_cafe-ingress",
+					KeyCache:  "1h",
+				},
+				Require: []string{"$pol_jwt_default_jwt_default_ing_cafe_ingress_claim_0"},
+			},
+			JwksURIs: []JwksURI{
+				{
+					Location:    "/_pol_jwks_default_jwt_default_ing_cafe-ingress",
+					URI:         "https://idp.example.com/keys",
+					CacheZone:   "pol_jwks_default_jwt_default_ing_cafe-ingress",
+					KeyCache:    "1h",
+					SSLVerify:   true,
+					TrustedCert: "/etc/ssl/certs/ca-certificates.crt",
+					SSLName:     "idp.example.com",
+				},
+			},
+			IngressMTLS: &IngressMTLS{
+				ClientCert:   "/etc/nginx/secrets/default-ingress-mtls-secret-ca.crt",
+				VerifyClient: "on",
+				VerifyDepth:  1,
+				ClientCertHeaders: []Header{
+					{Name: "X-Client-Cert-Subject", Value: "$ssl_client_s_dn"},
+				},
+			},
+			Locations: []Location{
+				{
+					Path:                "/tea",
+					Upstream:            testUps,
+					ProxyConnectTimeout: "10s",
+					ProxyReadTimeout:    "10s",
+					ProxySendTimeout:    "10s",
+					ClientMaxBodySize:   "2m",
+					Deny:                []string{"10.0.0.1"},
+					EgressMTLS: &EgressMTLS{
+						Certificate:    "/etc/nginx/secrets/default-egress-mtls-secret",
+						CertificateKey: "/etc/nginx/secrets/default-egress-mtls-secret",
+						VerifyDepth:    1,
+						Ciphers:        "DEFAULT",
+						Protocols:      "TLSv1 TLSv1.1 TLSv1.2",
+						SessionReuse:   true,
+						SSLName:        "$proxy_host",
+					},
+					MinionIngress: &Ingress{
+						Name:      "tea-minion",
+						Namespace: "default",
+					},
+				},
+				{
+					Path:                "/coffee",
+					Upstream:            testUps,
+					ProxyConnectTimeout: "10s",
+					ProxyReadTimeout:    "10s",
+					ProxySendTimeout:    "10s",
+					ClientMaxBodySize:   "2m",
+					PoliciesErrorReturn: &Return{Code: 500},
+				},
+			},
+		},
+	},
+	Upstreams: []Upstream{testUps},
+	Ingress: Ingress{
+		Name:      "cafe-ingress",
+		Namespace: "default",
+	},
+	Maps: []Map{
+		{
+			Source:   "$jwt_claim_scope",
+			Variable: "$pol_jwt_default_jwt_default_ing_cafe_ingress_claim_0",
+			Parameters: []Parameter{
+				{Value: `"~^(admin)$"`, Result: "1"},
+				{Value: "default", Result: "0"},
+			},
+		},
+	},
+	LimitReqZones: []LimitReqZone{
+		{
+			Key:      "$binary_remote_addr",
+			ZoneName: "pol_rl_default_rate-limit_default_ing_cafe-ingress",
+			ZoneSize: "10M",
+			Rate:     "10r/s",
+		},
+	},
+}
+
+var testCanaryUps = Upstream{
+	Name:             "test-canary",
+	UpstreamZoneSize: "256k",
+	UpstreamServers: []UpstreamServer{
+		{
+			Address:     "127.0.0.1",
+			Port:        "8282",
+			FailTimeout: "1s",
+		},
+	},
+}
+
+var ingCfgWithCanary = IngressNginxConfig{
+	Servers: []Server{
+		{
+			Name:         "test.example.com",
+			ServerTokens: "off",
+			StatusZone:   "test.example.com",
+			Locations: []Location{
+				{
+					Path:                "/",
+					Upstream:            testUps,
+					CanaryVariable:      "$ing_default_cafe_ingress_canary_0_header",
+					ProxyConnectTimeout: "10s",
+					ProxyReadTimeout:    "10s",
+					ProxySendTimeout:    "10s",
+					ClientMaxBodySize:   "2m",
+				},
+			},
+		},
+	},
+	Upstreams: []Upstream{testUps, testCanaryUps},
+	Ingress: Ingress{
+		Name:      "cafe-ingress",
+		Namespace: "default",
+	},
+	SplitClients: []SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$ing_default_cafe_ingress_canary_0_weight",
+			Distributions: []Distribution{
+				{Weight: "20%", Value: "test-canary"},
+				{Weight: "*", Value: "test"},
+			},
+		},
+	},
+	Maps: []Map{
+		{
+			Source:   "$http_x_canary",
+			Variable: "$ing_default_cafe_ingress_canary_0_header",
+			Parameters: []Parameter{
+				{Value: `"always"`, Result: "test-canary"},
+				{Value: `"never"`, Result: "test"},
+				{Value: "default", Result: "$ing_default_cafe_ingress_canary_0_weight"},
+			},
+		},
+	},
+}
+
 var mainCfg = MainConfig{
 	ServerNamesHashMaxSize:  "512",
 	ServerTokens:            "off",
@@ -147,6 +317,130 @@ func TestIngressForNGINX(t *testing.T) {
 	}
 }
 
+func TestIngressWithPoliciesForNGINXPlus(t *testing.T) {
+	tmpl, err := template.New(nginxPlusIngressTmpl).Funcs(helperFunctions).ParseFiles(nginxPlusIngressTmpl)
+	if err != nil {
+		t.Fatalf("Failed to parse template file: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, ingCfgWithPolicies)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	expected := []string{
+		"map $jwt_claim_scope $pol_jwt_default_jwt_default_ing_cafe_ingress_claim_0 {",
+		"limit_req_zone $binary_remote_addr zone=pol_rl_default_rate-limit_default_ing_cafe-ingress:10M rate=10r/s;",
+		"proxy_cache_path /var/cache/nginx/pol_jwks_default_jwt_default_ing_cafe-ingress keys_zone=pol_jwks_default_jwt_default_ing_cafe-ingress:1m max_size=1m;",
+		"ssl_client_certificate /etc/nginx/secrets/default-ingress-mtls-secret-ca.crt;",
+		"allow 10.0.0.0/8;",
+		"limit_req zone=pol_rl_default_rate-limit_default_ing_cafe-ingress burst=10;",
+		"auth_jwt_key_request /_pol_jwks_default_jwt_default_ing_cafe-ingress;",
+		"auth_jwt_require $pol_jwt_default_jwt_default_ing_cafe_ingress_claim_0 error=403;",
+		"location = /_pol_jwks_default_jwt_default_ing_cafe-ingress {",
+		"proxy_ssl_verify on;",
+		"proxy_ssl_trusted_certificate /etc/ssl/certs/ca-certificates.crt;",
+		"proxy_ssl_name idp.example.com;",
+		"proxy_ssl_server_name off;",
+		"deny 10.0.0.1;",
+		"proxy_set_header X-Client-Cert-Subject $ssl_client_s_dn;",
+		"proxy_ssl_certificate /etc/nginx/secrets/default-egress-mtls-secret;",
+		"return 500;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("generated config doesn't contain %q", e)
+		}
+	}
+}
+
+func TestIngressWithPoliciesForNGINX(t *testing.T) {
+	tmpl, err := template.New(nginxIngressTmpl).Funcs(helperFunctions).ParseFiles(nginxIngressTmpl)
+	if err != nil {
+		t.Fatalf("Failed to parse template file: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, ingCfgWithPolicies)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	expected := []string{
+		"limit_req_zone $binary_remote_addr zone=pol_rl_default_rate-limit_default_ing_cafe-ingress:10M rate=10r/s;",
+		"ssl_client_certificate /etc/nginx/secrets/default-ingress-mtls-secret-ca.crt;",
+		"allow 10.0.0.0/8;",
+		"limit_req zone=pol_rl_default_rate-limit_default_ing_cafe-ingress burst=10;",
+		"deny 10.0.0.1;",
+		"proxy_ssl_certificate /etc/nginx/secrets/default-egress-mtls-secret;",
+		"return 500;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("generated config doesn't contain %q", e)
+		}
+	}
+}
+
+func TestIngressWithCanaryForNGINXPlus(t *testing.T) {
+	tmpl, err := template.New(nginxPlusIngressTmpl).Funcs(helperFunctions).ParseFiles(nginxPlusIngressTmpl)
+	if err != nil {
+		t.Fatalf("Failed to parse template file: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, ingCfgWithCanary)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	expected := []string{
+		"split_clients $request_id $ing_default_cafe_ingress_canary_0_weight {",
+		"20% test-canary;",
+		"map $http_x_canary $ing_default_cafe_ingress_canary_0_header {",
+		"proxy_pass http://$ing_default_cafe_ingress_canary_0_header;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("generated config doesn't contain %q", e)
+		}
+	}
+}
+
+func TestIngressWithCanaryForNGINX(t *testing.T) {
+	tmpl, err := template.New(nginxIngressTmpl).Funcs(helperFunctions).ParseFiles(nginxIngressTmpl)
+	if err != nil {
+		t.Fatalf("Failed to parse template file: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, ingCfgWithCanary)
+	t.Log(buf.String())
+	if err != nil {
+		t.Fatalf("Failed to write template %v", err)
+	}
+
+	expected := []string{
+		"split_clients $request_id $ing_default_cafe_ingress_canary_0_weight {",
+		"20% test-canary;",
+		"map $http_x_canary $ing_default_cafe_ingress_canary_0_header {",
+		"proxy_pass http://$ing_default_cafe_ingress_canary_0_header;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("generated config doesn't contain %q", e)
+		}
+	}
+}
+
 func TestMainForNGINXPlus(t *testing.T) {
 	tmpl, err := template.New(nginxPlusMainTmpl).ParseFiles(nginxPlusMainTmpl)
 	if err != nil {
@@ -56,6 +56,7 @@ type Server struct {
 	SetRealIPFrom             []string
 	RealIPRecursive           bool
 	Snippets                  []string
+	AddHeaders                []AddHeader
 	InternalRedirectLocations []InternalRedirectLocation
 	Locations                 []Location
 	ErrorPageLocations        []ErrorPageLocation
@@ -68,10 +69,12 @@ type Server struct {
 	LimitReqOptions           LimitReqOptions
 	LimitReqs                 []LimitReq
 	JWTAuth                   *JWTAuth
+	JwksURIs                  []JwksURI
 	IngressMTLS               *IngressMTLS
 	EgressMTLS                *EgressMTLS
-	OIDC                      *OIDC
+	OIDCProviders             []OIDC
 	WAF                       *WAF
+	SecurityHeaders           []Header
 	PoliciesErrorReturn       *Return
 	VSNamespace               string
 	VSName                    string
@@ -87,9 +90,11 @@ type SSL struct {
 
 // IngressMTLS defines TLS configuration for a server. This is a subset of TLS specifically for clients auth.
 type IngressMTLS struct {
-	ClientCert   string
-	VerifyClient string
-	VerifyDepth  int
+	ClientCert        string
+	ClientCRL         string
+	VerifyClient      string
+	VerifyDepth       int
+	ClientCertHeaders []Header
 }
 
 // EgressMTLS defines TLS configuration for a location.
@@ -106,14 +111,23 @@ type EgressMTLS struct {
 	SSLName        string
 }
 
+// OIDC defines an OIDC provider. The ID is unique among the OIDC providers of all servers
+// and isolates the key-value zones, variables and locations of the provider.
 type OIDC struct {
-	AuthEndpoint  string
-	ClientID      string
-	ClientSecret  string
-	JwksURI       string
-	Scope         string
-	TokenEndpoint string
-	RedirectURI   string
+	ID                    string
+	AuthEndpoint          string
+	ClientID              string
+	ClientSecret          string
+	JwksURI               string
+	Scope                 string
+	TokenEndpoint         string
+	RedirectURI           string
+	PKCE                  bool
+	EndSessionEndpoint    string
+	PostLogoutRedirectURI string
+	LogoutURI             string
+	CookieName            string
+	CookieFlags           string
 }
 
 // WAF defines WAF configuration.
@@ -160,8 +174,10 @@ type Location struct {
 	LimitReqs                []LimitReq
 	JWTAuth                  *JWTAuth
 	EgressMTLS               *EgressMTLS
-	OIDC                     bool
+	OIDC                     *OIDC
+	APIKey                   *APIKey
 	WAF                      *WAF
+	SecurityHeaders          []Header
 	PoliciesErrorReturn      *Return
 	ServiceName              string
 	IsVSR                    bool
@@ -171,9 +187,11 @@ type Location struct {
 
 // ReturnLocation defines a location for returning a fixed response.
 type ReturnLocation struct {
-	Name        string
-	DefaultType string
-	Return      Return
+	Name            string
+	DefaultType     string
+	Return          Return
+	SecurityHeaders []Header
+	AddHeaders      []AddHeader
 }
 
 // SplitClient defines a split_clients.
@@ -198,10 +216,12 @@ type ErrorPage struct {
 
 // ErrorPageLocation defines a named location for an error_page directive.
 type ErrorPageLocation struct {
-	Name        string
-	DefaultType string
-	Return      *Return
-	Headers     []Header
+	Name            string
+	DefaultType     string
+	Return          *Return
+	Headers         []Header
+	SecurityHeaders []Header
+	AddHeaders      []AddHeader
 }
 
 // Header defines a header to use with add_header directive.
@@ -324,7 +344,32 @@ func (rl LimitReqOptions) String() string {
 
 // JWTAuth holds JWT authentication configuration.
 type JWTAuth struct {
-	Secret string
-	Realm  string
-	Token  string
+	Secret  string
+	Realm   string
+	Token   string
+	JwksURI *JwksURI
+	Require []string
+}
+
+// APIKey holds API key authentication configuration.
+// ClientMap is the variable of the map that maps the hash of the API key of a request to the ID of the client.
+type APIKey struct {
+	Token      string
+	ClientMap  string
+	RejectCode int
+}
+
+// JwksURI defines an internal location that requests the JSON Web Key Set for JWT authentication from a remote endpoint.
+// The responses are cached in the cache zone. With SSLVerify, the certificate of the endpoint is verified
+// against the trusted certificate and the SSL name.
+type JwksURI struct {
+	Location    string
+	URI         string
+	CacheZone   string
+	KeyCache    string
+	SNIEnabled  bool
+	SNIName     string
+	SSLVerify   bool
+	TrustedCert string
+	SSLName     string
 }
@@ -1,6 +1,8 @@
 package version2
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 )
 
@@ -125,8 +127,14 @@ var virtualServerCfg = VirtualServerConfig{
 		SetRealIPFrom:   []string{"0.0.0.0/0"},
 		RealIPHeader:    "X-Real-IP",
 		RealIPRecursive: true,
-		Allow:           []string{"127.0.0.1"},
-		Deny:            []string{"127.0.0.1"},
+		AddHeaders: []AddHeader{
+			{
+				Header: Header{Name: "X-Frame-Options", Value: "DENY"},
+				Always: true,
+			},
+		},
+		Allow: []string{"127.0.0.1"},
+		Deny:  []string{"127.0.0.1"},
 		LimitReqs: []LimitReq{
 			{
 				ZoneName: "pol_rl_test_test_test",
@@ -144,8 +152,15 @@ var virtualServerCfg = VirtualServerConfig{
 		},
 		IngressMTLS: &IngressMTLS{
 			ClientCert:   "ingress-mtls-secret",
+			ClientCRL:    "ingress-mtls-secret_ca.crl",
 			VerifyClient: "on",
 			VerifyDepth:  2,
+			ClientCertHeaders: []Header{
+				{
+					Name:  "X-SSL-Client-Subject-DN",
+					Value: "$ssl_client_s_dn",
+				},
+			},
 		},
 		WAF: &WAF{
 			ApPolicy:            "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
@@ -381,6 +396,393 @@ func TestVirtualServerForNginxPlus(t *testing.T) {
 	t.Log(string(data))
 }
 
+func TestVirtualServerForNginxPlusWithJwksURI(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	jwksURI := JwksURI{
+		Location:    "/_pol_jwks_default_jwt-policy_default_cafe",
+		URI:         "https://idp.example.com/keys",
+		CacheZone:   "pol_jwks_default_jwt-policy_default_cafe",
+		KeyCache:    "1h",
+		SNIEnabled:  true,
+		SNIName:     "idp.example.com",
+		SSLVerify:   true,
+		TrustedCert: "/etc/ssl/certs/ca-certificates.crt",
+		SSLName:     "idp.example.com",
+	}
+
+	cfg := virtualServerCfg
+	cfg.Server.JWTAuth = &JWTAuth{
+		Realm:   "My Api",
+		JwksURI: &jwksURI,
+	}
+	cfg.Server.JwksURIs = []JwksURI{jwksURI}
+
+	data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	expected := []string{
+		"proxy_cache_path /var/cache/nginx/pol_jwks_default_jwt-policy_default_cafe keys_zone=pol_jwks_default_jwt-policy_default_cafe:1m max_size=1m;",
+		"auth_jwt_key_request /_pol_jwks_default_jwt-policy_default_cafe;",
+		"location = /_pol_jwks_default_jwt-policy_default_cafe {",
+		"proxy_cache_valid 200 1h;",
+		"proxy_ssl_verify on;",
+		"proxy_ssl_trusted_certificate /etc/ssl/certs/ca-certificates.crt;",
+		"proxy_ssl_name idp.example.com;",
+		"proxy_ssl_server_name on;",
+		"proxy_pass https://idp.example.com/keys;",
+	}
+
+	for _, e := range expected {
+		if !bytes.Contains(data, []byte(e)) {
+			t.Errorf("The generated config doesn't include %q", e)
+		}
+	}
+
+	if bytes.Contains(data, []byte("auth_jwt_key_file")) {
+		t.Errorf("The generated config includes auth_jwt_key_file for a JWKS URI")
+	}
+}
+
+func TestVirtualServerForNginxPlusWithJWTClaimRequirements(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	cfg := virtualServerCfg
+	cfg.Maps = []Map{
+		{
+			Source:   "$jwt_claim_aud",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_0",
+			Parameters: []Parameter{
+				{
+					Value:  `"~^(my-client)$"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+	}
+	cfg.Server.JWTAuth = &JWTAuth{
+		Realm:   "My Api",
+		Secret:  "jwk-secret",
+		Require: []string{"$pol_jwt_default_jwt_policy_default_cafe_claim_0", "$jwt_claim_sub"},
+	}
+
+	data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	expected := []string{
+		"map $jwt_claim_aud $pol_jwt_default_jwt_policy_default_cafe_claim_0 {",
+		`"~^(my-client)$" 1;`,
+		"auth_jwt_require $pol_jwt_default_jwt_policy_default_cafe_claim_0 $jwt_claim_sub error=403;",
+	}
+
+	for _, e := range expected {
+		if !bytes.Contains(data, []byte(e)) {
+			t.Errorf("The generated config doesn't include %q", e)
+		}
+	}
+}
+
+func TestVirtualServerForNginxPlusWithAPIKey(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	cfg := virtualServerCfg
+	cfg.Maps = []Map{
+		{
+			Source:   "${http_x_api_key}",
+			Variable: "$apikey_auth_token_default_api_key_policy_default_cafe_0",
+			Parameters: []Parameter{
+				{
+					Value:  `""`,
+					Result: "${arg_api_key}",
+				},
+				{
+					Value:  "default",
+					Result: "${http_x_api_key}",
+				},
+			},
+		},
+		{
+			Source:   "$apikey_auth_hash",
+			Variable: "$apikey_auth_client_default_api_key_policy_default_cafe",
+			Parameters: []Parameter{
+				{
+					Value:  `"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"`,
+					Result: `"client1"`,
+				},
+				{
+					Value:  "default",
+					Result: `""`,
+				},
+			},
+		},
+	}
+	cfg.Server.Locations = []Location{
+		{
+			Path:      "/",
+			ProxyPass: "http://test-upstream",
+			APIKey: &APIKey{
+				Token:      "$apikey_auth_token_default_api_key_policy_default_cafe_0",
+				ClientMap:  "$apikey_auth_client_default_api_key_policy_default_cafe",
+				RejectCode: 403,
+			},
+		},
+	}
+
+	data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	expected := []string{
+		"map ${http_x_api_key} $apikey_auth_token_default_api_key_policy_default_cafe_0 {",
+		`"" ${arg_api_key};`,
+		"default ${http_x_api_key};",
+		"map $apikey_auth_hash $apikey_auth_client_default_api_key_policy_default_cafe {",
+		`"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8" "client1";`,
+		`set $apikey_auth_token "$apikey_auth_token_default_api_key_policy_default_cafe_0";`,
+		"return 401;",
+		"set $apikey_client_id $apikey_auth_client_default_api_key_policy_default_cafe;",
+		"return 403;",
+	}
+
+	for _, e := range expected {
+		if !bytes.Contains(data, []byte(e)) {
+			t.Errorf("The generated config doesn't include %q", e)
+		}
+	}
+}
+
+func TestVirtualServerWithSecurityHeaders(t *testing.T) {
+	templates := []struct {
+		virtualServerTmpl   string
+		transportServerTmpl string
+	}{
+		{nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl},
+		{nginxVirtualServerTmpl, nginxTransportServerTmpl},
+	}
+
+	headers := []Header{
+		{Name: "Strict-Transport-Security", Value: "$security_headers_hsts_default_security_headers_default_cafe"},
+		{Name: "X-Frame-Options", Value: "DENY"},
+	}
+
+	cfg := virtualServerCfg
+	cfg.Server.AddHeaders = nil
+	cfg.Server.SecurityHeaders = headers
+	cfg.Server.Locations = []Location{
+		{
+			Path:              "/redirect",
+			InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			SecurityHeaders:   headers,
+		},
+	}
+	cfg.Server.ErrorPageLocations = []ErrorPageLocation{
+		{
+			Name:            "@error_page_0_0",
+			DefaultType:     "text/plain",
+			Return:          &Return{Text: "Bad Gateway"},
+			SecurityHeaders: headers,
+		},
+	}
+	cfg.Server.ReturnLocations = []ReturnLocation{
+		{
+			Name:            "@return_0",
+			DefaultType:     "text/plain",
+			Return:          Return{Text: "Hello"},
+			SecurityHeaders: headers,
+		},
+	}
+
+	for _, tmpl := range templates {
+		executor, err := NewTemplateExecutor(tmpl.virtualServerTmpl, tmpl.transportServerTmpl)
+		if err != nil {
+			t.Fatalf("Failed to create template executor: %v", err)
+		}
+
+		data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Fatalf("Failed to execute template: %v", err)
+		}
+
+		// the server, the location, the error page location and the return location
+		expectedCount := 4
+		for _, h := range headers {
+			e := fmt.Sprintf(`add_header %s "%s" always;`, h.Name, h.Value)
+			if count := bytes.Count(data, []byte(e)); count != expectedCount {
+				t.Errorf("The generated config %s includes %q %d times but expected %d times", tmpl.virtualServerTmpl, e, count, expectedCount)
+			}
+
+			e = fmt.Sprintf("proxy_hide_header %s;", h.Name)
+			if !bytes.Contains(data, []byte(e)) {
+				t.Errorf("The generated config %s doesn't include %q", tmpl.virtualServerTmpl, e)
+			}
+		}
+	}
+}
+
+func TestVirtualServerWithAddHeadersForResponsesGeneratedByNGINX(t *testing.T) {
+	templates := []struct {
+		virtualServerTmpl   string
+		transportServerTmpl string
+	}{
+		{nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl},
+		{nginxVirtualServerTmpl, nginxTransportServerTmpl},
+	}
+
+	addHeaders := []AddHeader{
+		{Header: Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}, Always: true},
+	}
+
+	cfg := virtualServerCfg
+	cfg.Server.AddHeaders = nil
+	cfg.Server.Locations = []Location{
+		{
+			Path:              "/redirect",
+			InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			AddHeaders:        addHeaders,
+		},
+	}
+	cfg.Server.ErrorPageLocations = []ErrorPageLocation{
+		{
+			Name:        "@error_page_0_0",
+			DefaultType: "text/plain",
+			Return:      &Return{Text: "Bad Gateway"},
+			AddHeaders:  addHeaders,
+		},
+	}
+	cfg.Server.ReturnLocations = []ReturnLocation{
+		{
+			Name:        "@return_0",
+			DefaultType: "text/plain",
+			Return:      Return{Text: "Hello"},
+			AddHeaders:  addHeaders,
+		},
+	}
+
+	for _, tmpl := range templates {
+		executor, err := NewTemplateExecutor(tmpl.virtualServerTmpl, tmpl.transportServerTmpl)
+		if err != nil {
+			t.Fatalf("Failed to create template executor: %v", err)
+		}
+
+		data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Fatalf("Failed to execute template: %v", err)
+		}
+
+		// the location, the error page location and the return location
+		expectedCount := 3
+		e := `add_header X-Frame-Options "SAMEORIGIN" always;`
+		if count := bytes.Count(data, []byte(e)); count != expectedCount {
+			t.Errorf("The generated config %s includes %q %d times but expected %d times", tmpl.virtualServerTmpl, e, count, expectedCount)
+		}
+	}
+}
+
+func TestVirtualServerForNginxPlusWithOIDCProviders(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	admin := OIDC{
+		ID:                    "default_admin_oidc_default_cafe",
+		AuthEndpoint:          "https://idp.example.com/auth",
+		TokenEndpoint:         "https://idp.example.com/token",
+		JwksURI:               "https://idp.example.com/certs",
+		ClientID:              "admin",
+		ClientSecret:          "admin-secret",
+		Scope:                 "openid",
+		RedirectURI:           "/admin/_codexch",
+		PKCE:                  true,
+		EndSessionEndpoint:    "https://idp.example.com/logout",
+		PostLogoutRedirectURI: "/",
+		LogoutURI:             "/admin/logout",
+		CookieName:            "admin_session",
+		CookieFlags:           "Path=/admin; SameSite=Strict; HttpOnly;",
+	}
+	app := OIDC{
+		ID:                    "default_app_oidc_default_cafe",
+		AuthEndpoint:          "https://accounts.example.com/auth",
+		TokenEndpoint:         "https://accounts.example.com/token",
+		JwksURI:               "https://accounts.example.com/certs",
+		ClientID:              "app",
+		ClientSecret:          "app-secret",
+		Scope:                 "openid",
+		RedirectURI:           "/_codexch",
+		PostLogoutRedirectURI: "/_logout",
+		LogoutURI:             "/logout",
+		CookieName:            "auth_token_default_app_oidc",
+		CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+	}
+
+	cfg := virtualServerCfg
+	cfg.Server.OIDCProviders = []OIDC{admin, app}
+	cfg.Server.Locations = []Location{
+		{
+			Path:      "/admin",
+			ProxyPass: "http://test-upstream",
+			OIDC:      &admin,
+		},
+		{
+			Path:      "/app",
+			ProxyPass: "http://test-upstream",
+			OIDC:      &app,
+		},
+	}
+
+	data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	expected := []string{
+		"keyval_zone zone=oidc_id_tokens_default_admin_oidc_default_cafe:1M timeout=1h sync;",
+		"keyval $cookie_admin_session $session_jwt_default_admin_oidc_default_cafe zone=oidc_id_tokens_default_admin_oidc_default_cafe;",
+		"keyval $pkce_id $pkce_code_verifier_default_admin_oidc_default_cafe zone=oidc_pkce_default_admin_oidc_default_cafe;",
+		"keyval $cookie_auth_token_default_app_oidc $session_jwt_default_app_oidc_default_cafe zone=oidc_id_tokens_default_app_oidc_default_cafe;",
+		"include oidc/oidc.conf;",
+		"location @do_oidc_flow_default_admin_oidc_default_cafe {",
+		"location = /admin/_codexch {",
+		"location = /admin/logout {",
+		`set $oidc_cookie_flags "Path=/admin; SameSite=Strict; HttpOnly;$oidc_cookie_secure";`,
+		"set $oidc_pkce_enable 1;",
+		`set $oidc_end_session_endpoint "https://idp.example.com/logout";`,
+		"auth_jwt_key_request /_jwks_uri_default_admin_oidc_default_cafe;",
+		"auth_jwt \"\" token=$session_jwt_default_admin_oidc_default_cafe;",
+		"error_page 401 = @do_oidc_flow_default_app_oidc_default_cafe;",
+		"location = /_codexch {",
+		"location = /logout {",
+	}
+
+	for _, e := range expected {
+		if !bytes.Contains(data, []byte(e)) {
+			t.Errorf("The generated config doesn't include %q", e)
+		}
+	}
+
+	if bytes.Contains(data, []byte("keyval_zone zone=oidc_pkce_default_app_oidc_default_cafe")) {
+		t.Errorf("The generated config includes a PKCE key-value zone for a provider without PKCE")
+	}
+}
+
 func TestVirtualServerForNginx(t *testing.T) {
 	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
 	if err != nil {
@@ -2,6 +2,10 @@ package configs
 
 import (
 	"fmt"
+	"hash/fnv"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,6 +29,8 @@ const (
 	specContext            = "spec"
 	routeContext           = "route"
 	subRouteContext        = "subroute"
+	// systemTrustedCertPath is the CA bundle of the system, used to verify remote endpoints when no trusted CA is configured.
+	systemTrustedCertPath = "/etc/ssl/certs/ca-certificates.crt"
 )
 
 var incompatibleLBMethodsForSlowStart = map[string]bool{
@@ -63,6 +69,17 @@ type VirtualServerEx struct {
 	SecretRefs          map[string]*secrets.SecretReference
 	ApPolRefs           map[string]*unstructured.Unstructured
 	LogConfRefs         map[string]*unstructured.Unstructured
+	OIDCDiscoveries     map[string]*OIDCDiscovery
+}
+
+// OIDCDiscovery holds the endpoints of an OpenID Provider discovered from the metadata of its issuer.
+// If the discovery has failed, Error holds the error and the endpoints are empty.
+type OIDCDiscovery struct {
+	AuthEndpoint       string
+	TokenEndpoint      string
+	JWKSURI            string
+	EndSessionEndpoint string
+	Error              error
 }
 
 func (vsx *VirtualServerEx) String() string {
@@ -196,13 +213,39 @@ type virtualServerConfigurator struct {
 	isTLSPassthrough     bool
 	enableSnippets       bool
 	warnings             Warnings
+	policyWarnings       PolicyWarnings
 	spiffeCerts          bool
 	oidcPolCfg           *oidcPolicyCfg
 }
 
+// oidcPolicyCfg holds the OIDC providers of a VirtualServer and its VirtualServerRoutes.
+// Every referenced OIDC policy gets its own provider.
 type oidcPolicyCfg struct {
-	oidc *version2.OIDC
-	key  string
+	providers []*version2.OIDC
+	keys      []string
+}
+
+func (c *oidcPolicyCfg) get(polKey string) *version2.OIDC {
+	for i, key := range c.keys {
+		if key == polKey {
+			return c.providers[i]
+		}
+	}
+	return nil
+}
+
+func (c *oidcPolicyCfg) add(polKey string, oidc *version2.OIDC) {
+	c.keys = append(c.keys, polKey)
+	c.providers = append(c.providers, oidc)
+}
+
+// generateServerProviders returns the OIDC providers for the server.
+func (c *oidcPolicyCfg) generateServerProviders() []version2.OIDC {
+	var result []version2.OIDC
+	for _, p := range c.providers {
+		result = append(result, *p)
+	}
+	return result
 }
 
 func (vsc *virtualServerConfigurator) addWarningf(obj runtime.Object, msgFmt string, args ...interface{}) {
@@ -215,8 +258,15 @@ func (vsc *virtualServerConfigurator) addWarnings(obj runtime.Object, msgs []str
 	}
 }
 
+// addPolicyWarnings adds the warnings of the reference to the Policy both to the warnings of the object and to its Policy warnings.
+func (vsc *virtualServerConfigurator) addPolicyWarnings(obj runtime.Object, policyKey string, msgs []string) {
+	vsc.addWarnings(obj, msgs)
+	vsc.policyWarnings.AddWarnings(obj, policyKey, msgs)
+}
+
 func (vsc *virtualServerConfigurator) clearWarnings() {
 	vsc.warnings = make(map[runtime.Object][]string)
+	vsc.policyWarnings = newPolicyWarnings()
 }
 
 // newVirtualServerConfigurator creates a new VirtualServerConfigurator
@@ -235,6 +285,7 @@ func newVirtualServerConfigurator(
 		isTLSPassthrough:     staticParams.TLSPassthrough,
 		enableSnippets:       staticParams.EnableSnippets,
 		warnings:             make(map[runtime.Object][]string),
+		policyWarnings:       newPolicyWarnings(),
 		spiffeCerts:          staticParams.NginxServiceMesh,
 		oidcPolCfg:           &oidcPolicyCfg{},
 	}
@@ -271,9 +322,10 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 	tlsRedirectConfig := generateTLSRedirectConfig(vsEx.VirtualServer.Spec.TLS)
 
 	policyOpts := policyOptions{
-		tls:         sslConfig != nil,
-		secretRefs:  vsEx.SecretRefs,
-		apResources: apResources,
+		tls:             sslConfig != nil,
+		secretRefs:      vsEx.SecretRefs,
+		apResources:     apResources,
+		oidcDiscoveries: vsEx.OIDCDiscoveries,
 	}
 
 	ownerDetails := policyOwnerDetails{
@@ -296,6 +348,9 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 
 	limitReqZones = append(limitReqZones, policiesCfg.LimitReqZones...)
 
+	var jwksURIs []version2.JwksURI
+	jwksURIs = appendJwksURI(jwksURIs, policiesCfg.JWTAuth)
+
 	// generate upstreams for VirtualServer
 	for _, u := range vsEx.VirtualServer.Spec.Upstreams {
 		upstreamName := virtualServerUpstreamNamer.GetNameForUpstream(u.Name)
@@ -352,6 +407,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 	var returnLocations []version2.ReturnLocation
 	var splitClients []version2.SplitClient
 	var maps []version2.Map
+	maps = append(maps, policiesCfg.Maps...)
 	var errorPageLocations []version2.ErrorPageLocation
 	vsrErrorPagesFromVs := make(map[string][]conf_v1.ErrorPage)
 	vsrErrorPagesRouteIndex := make(map[string]int)
@@ -366,6 +422,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 	for _, r := range vsEx.VirtualServer.Spec.Routes {
 		errorPageIndex := len(errorPageLocations)
 		errorPageLocations = append(errorPageLocations, generateErrorPageLocations(errorPageIndex, r.ErrorPages)...)
+		returnLocationIndex := len(returnLocations)
+		locationIndex := len(locations)
 
 		// ignore routes that reference VirtualServerRoute
 		if r.Route != "" {
@@ -383,6 +441,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 			if len(r.ErrorPages) > 0 {
 				vsrErrorPagesFromVs[name] = r.ErrorPages
 				vsrErrorPagesRouteIndex[name] = errorPageIndex
+				addHeadersToInternalLocations(vsEx.VirtualServer.Spec.Headers, nil, errorPageLocations[errorPageIndex:], nil)
 			}
 
 			// store route policies for the referenced VirtualServerRoute in case they don't define their own
@@ -393,6 +452,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 			continue
 		}
 
+		r = applyHeadersToRoute(r, vsEx.VirtualServer.Spec.Headers)
+
 		vsLocSnippets := r.LocationSnippets
 		ownerDetails := policyOwnerDetails{
 			owner:          vsEx.VirtualServer,
@@ -401,10 +462,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 			vsName:         vsEx.VirtualServer.Name,
 		}
 		routePoliciesCfg := vsc.generatePolicies(ownerDetails, r.Policies, vsEx.Policies, routeContext, policyOpts)
-		if policiesCfg.OIDC {
+		if routePoliciesCfg.OIDC == nil {
 			routePoliciesCfg.OIDC = policiesCfg.OIDC
 		}
 		limitReqZones = append(limitReqZones, routePoliciesCfg.LimitReqZones...)
+		jwksURIs = appendJwksURI(jwksURIs, routePoliciesCfg.JWTAuth)
+		maps = append(maps, routePoliciesCfg.Maps...)
 
 		if len(r.Matches) > 0 {
 			cfg := generateMatchesConfig(
@@ -455,15 +518,33 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 				returnLocations = append(returnLocations, *returnLoc)
 			}
 		}
+
+		addSecurityHeadersToInternalLocations(
+			routePoliciesCfg.SecurityHeaders,
+			errorPageLocations[errorPageIndex:],
+			returnLocations[returnLocationIndex:],
+		)
+		addHeadersToInternalLocations(
+			vsEx.VirtualServer.Spec.Headers,
+			locations[locationIndex:],
+			errorPageLocations[errorPageIndex:],
+			returnLocations[returnLocationIndex:],
+		)
 	}
 
 	// generate config for subroutes of each VirtualServerRoute
 	for _, vsr := range vsEx.VirtualServerRoutes {
 		isVSR := true
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(vsEx.VirtualServer, vsr)
+		vsrHeaders := mergeHeaders(vsEx.VirtualServer.Spec.Headers, vsr.Spec.Headers)
 		for _, r := range vsr.Spec.Subroutes {
+			r = applyHeadersToRoute(r, vsrHeaders)
+
 			errorPageIndex := len(errorPageLocations)
 			errorPageLocations = append(errorPageLocations, generateErrorPageLocations(errorPageIndex, r.ErrorPages)...)
+			errorPageLocationIndex := errorPageIndex
+			returnLocationIndex := len(returnLocations)
+			locationIndex := len(locations)
 			errorPages := r.ErrorPages
 			vsrNamespaceName := fmt.Sprintf("%v/%v", vsr.Namespace, vsr.Name)
 			// use the VirtualServer error pages if the route does not define any
@@ -504,10 +585,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 				context = subRouteContext
 			}
 			routePoliciesCfg := vsc.generatePolicies(ownerDetails, policyRefs, vsEx.Policies, context, policyOpts)
-			if policiesCfg.OIDC {
+			if routePoliciesCfg.OIDC == nil {
 				routePoliciesCfg.OIDC = policiesCfg.OIDC
 			}
 			limitReqZones = append(limitReqZones, routePoliciesCfg.LimitReqZones...)
+			jwksURIs = appendJwksURI(jwksURIs, routePoliciesCfg.JWTAuth)
+			maps = append(maps, routePoliciesCfg.Maps...)
 			if len(r.Matches) > 0 {
 				cfg := generateMatchesConfig(
 					r,
@@ -557,9 +640,28 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 					returnLocations = append(returnLocations, *returnLoc)
 				}
 			}
+
+			// the error page locations of the VirtualServer route, which are used by the subroutes without their own error
+			// pages, are shared by all subroutes, so they get the security headers of the server
+			addSecurityHeadersToInternalLocations(
+				routePoliciesCfg.SecurityHeaders,
+				errorPageLocations[errorPageLocationIndex:],
+				returnLocations[returnLocationIndex:],
+			)
+			addHeadersToInternalLocations(
+				vsrHeaders,
+				locations[locationIndex:],
+				errorPageLocations[errorPageLocationIndex:],
+				returnLocations[returnLocationIndex:],
+			)
 		}
 	}
 
+	addServerAPIKeyToLocations(policiesCfg.APIKey, locations)
+	addServerSecurityHeadersToLocations(policiesCfg.SecurityHeaders, locations)
+	addSecurityHeadersToInternalLocations(policiesCfg.SecurityHeaders, errorPageLocations, returnLocations)
+	removeSecurityHeadersFromAddHeaders(locations, errorPageLocations, returnLocations)
+
 	httpSnippets := generateSnippets(vsc.enableSnippets, vsEx.VirtualServer.Spec.HTTPSnippets, []string{})
 	serverSnippets := generateSnippets(
 		vsc.enableSnippets,
@@ -570,20 +672,23 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 	vsCfg := version2.VirtualServerConfig{
 		Upstreams:     upstreams,
 		SplitClients:  splitClients,
-		Maps:          maps,
+		Maps:          removeDuplicateMaps(maps),
 		StatusMatches: statusMatches,
 		LimitReqZones: removeDuplicateLimitReqZones(limitReqZones),
 		HTTPSnippets:  httpSnippets,
 		Server: version2.Server{
-			ServerName:                vsEx.VirtualServer.Spec.Host,
-			StatusZone:                vsEx.VirtualServer.Spec.Host,
-			ProxyProtocol:             vsc.cfgParams.ProxyProtocol,
-			SSL:                       sslConfig,
-			ServerTokens:              vsc.cfgParams.ServerTokens,
-			SetRealIPFrom:             vsc.cfgParams.SetRealIPFrom,
-			RealIPHeader:              vsc.cfgParams.RealIPHeader,
-			RealIPRecursive:           vsc.cfgParams.RealIPRecursive,
-			Snippets:                  serverSnippets,
+			ServerName:      vsEx.VirtualServer.Spec.Host,
+			StatusZone:      vsEx.VirtualServer.Spec.Host,
+			ProxyProtocol:   vsc.cfgParams.ProxyProtocol,
+			SSL:             sslConfig,
+			ServerTokens:    vsc.cfgParams.ServerTokens,
+			SetRealIPFrom:   vsc.cfgParams.SetRealIPFrom,
+			RealIPHeader:    vsc.cfgParams.RealIPHeader,
+			RealIPRecursive: vsc.cfgParams.RealIPRecursive,
+			Snippets:        serverSnippets,
+			// the add_header directives of the server are inherited only by the locations without their own add_header
+			// directives, like the OIDC locations, so the headers are not added twice to the responses of the routes
+			AddHeaders:                filterAddHeaders(generateResponseAddHeaders(vsEx.VirtualServer.Spec.Headers), policiesCfg.SecurityHeaders),
 			InternalRedirectLocations: internalRedirectLocations,
 			Locations:                 locations,
 			ReturnLocations:           returnLocations,
@@ -596,10 +701,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(vsEx *VirtualS
 			LimitReqOptions:           policiesCfg.LimitReqOptions,
 			LimitReqs:                 policiesCfg.LimitReqs,
 			JWTAuth:                   policiesCfg.JWTAuth,
+			JwksURIs:                  jwksURIs,
 			IngressMTLS:               policiesCfg.IngressMTLS,
 			EgressMTLS:                policiesCfg.EgressMTLS,
-			OIDC:                      vsc.oidcPolCfg.oidc,
+			OIDCProviders:             vsc.oidcPolCfg.generateServerProviders(),
 			WAF:                       policiesCfg.WAF,
+			SecurityHeaders:           policiesCfg.SecurityHeaders,
 			PoliciesErrorReturn:       policiesCfg.ErrorReturn,
 			VSNamespace:               vsEx.VirtualServer.Namespace,
 			VSName:                    vsEx.VirtualServer.Name,
@@ -617,10 +724,13 @@ type policiesCfg struct {
 	LimitReqZones   []version2.LimitReqZone
 	LimitReqs       []version2.LimitReq
 	JWTAuth         *version2.JWTAuth
+	Maps            []version2.Map
 	IngressMTLS     *version2.IngressMTLS
 	EgressMTLS      *version2.EgressMTLS
-	OIDC            bool
+	OIDC            *version2.OIDC
+	APIKey          *version2.APIKey
 	WAF             *version2.WAF
+	SecurityHeaders []version2.Header
 	ErrorReturn     *version2.Return
 }
 
@@ -636,9 +746,10 @@ type policyOwnerDetails struct {
 }
 
 type policyOptions struct {
-	tls         bool
-	secretRefs  map[string]*secrets.SecretReference
-	apResources map[string]string
+	tls             bool
+	secretRefs      map[string]*secrets.SecretReference
+	apResources     map[string]string
+	oidcDiscoveries map[string]*OIDCDiscovery
 }
 
 type validationResults struct {
@@ -675,10 +786,15 @@ func (p *policiesCfg) addRateLimitConfig(
 	vsName string,
 ) *validationResults {
 	res := newValidationResults()
+	isFirst := len(p.LimitReqs) == 0
 	rlZoneName := fmt.Sprintf("pol_rl_%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName)
-	p.LimitReqs = append(p.LimitReqs, generateLimitReq(rlZoneName, rateLimit))
-	p.LimitReqZones = append(p.LimitReqZones, generateLimitReqZone(rlZoneName, rateLimit))
-	if len(p.LimitReqs) == 1 {
+	if len(rateLimit.Tiers) > 0 {
+		p.addRateLimitTiers(rlZoneName, rateLimit)
+	} else {
+		p.LimitReqs = append(p.LimitReqs, generateLimitReq(rlZoneName, rateLimit))
+		p.LimitReqZones = append(p.LimitReqZones, generateLimitReqZone(rlZoneName, rateLimit))
+	}
+	if isFirst {
 		p.LimitReqOptions = generateLimitReqOptions(rateLimit)
 	} else {
 		curOptions := generateLimitReqOptions(rateLimit)
@@ -695,10 +811,79 @@ func (p *policiesCfg) addRateLimitConfig(
 	return res
 }
 
+// addRateLimitTiers adds a rate limit for every tier of the policy. The map of every tier sets the key of the rate limit
+// only for the requests of the tier, so that other requests are not accounted in the zone of the tier.
+func (p *policiesCfg) addRateLimitTiers(zoneName string, rateLimit *conf_v1.RateLimit) {
+	for i, t := range rateLimit.Tiers {
+		tierZoneName := fmt.Sprintf("%v_%v", zoneName, t.Name)
+		variable := "$" + toVariableName(tierZoneName)
+
+		p.Maps = append(p.Maps, version2.Map{
+			Source:     rateLimit.TierSelector,
+			Variable:   variable,
+			Parameters: generateRateLimitTierMapParameters(rateLimit.Key, rateLimit.Tiers, i),
+		})
+		p.LimitReqZones = append(p.LimitReqZones, version2.LimitReqZone{
+			Key:      variable,
+			ZoneName: tierZoneName,
+			ZoneSize: rateLimit.ZoneSize,
+			Rate:     t.Rate,
+		})
+
+		limitReq := generateLimitReq(tierZoneName, rateLimit)
+		if t.Burst != nil {
+			limitReq.Burst = *t.Burst
+		}
+		p.LimitReqs = append(p.LimitReqs, limitReq)
+	}
+}
+
+func generateRateLimitTierMapParameters(key string, tiers []conf_v1.RateLimitTier, index int) []version2.Parameter {
+	tier := tiers[index]
+	quotedKey := fmt.Sprintf(`"%s"`, key)
+
+	var params []version2.Parameter
+
+	for _, v := range tier.Values {
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf(`"%s"`, v),
+			Result: quotedKey,
+		})
+	}
+
+	if !tier.Default {
+		return append(params, version2.Parameter{
+			Value:  "default",
+			Result: `""`,
+		})
+	}
+
+	// the default tier must not account the requests of the other tiers
+	for i, t := range tiers {
+		if i == index {
+			continue
+		}
+		for _, v := range t.Values {
+			params = append(params, version2.Parameter{
+				Value:  fmt.Sprintf(`"%s"`, v),
+				Result: `""`,
+			})
+		}
+	}
+
+	return append(params, version2.Parameter{
+		Value:  "default",
+		Result: quotedKey,
+	})
+}
+
 func (p *policiesCfg) addJWTAuthConfig(
 	jwtAuth *conf_v1.JWTAuth,
 	polKey string,
 	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
 	secretRefs map[string]*secrets.SecretReference,
 ) *validationResults {
 	res := newValidationResults()
@@ -707,6 +892,58 @@ func (p *policiesCfg) addJWTAuthConfig(
 		return res
 	}
 
+	if jwtAuth.JwksURI != "" {
+		jwksZoneName := fmt.Sprintf("pol_jwks_%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName)
+		jwksURI := &version2.JwksURI{
+			Location:   "/_" + jwksZoneName,
+			URI:        jwtAuth.JwksURI,
+			CacheZone:  jwksZoneName,
+			KeyCache:   jwtAuth.KeyCache,
+			SNIEnabled: jwtAuth.SNIEnabled,
+			SNIName:    jwtAuth.SNIName,
+		}
+
+		// the policy validation ensures the URI can be parsed
+		u, _ := url.Parse(jwtAuth.JwksURI)
+		if u != nil && u.Scheme == "https" {
+			jwksURI.SSLVerify = true
+			jwksURI.TrustedCert = systemTrustedCertPath
+			jwksURI.SSLName = u.Hostname()
+			if jwtAuth.SNIName != "" {
+				jwksURI.SSLName = jwtAuth.SNIName
+			}
+		}
+
+		if jwtAuth.TrustedCertSecret != "" {
+			trustedCertSecret := fmt.Sprintf("%v/%v", polNamespace, jwtAuth.TrustedCertSecret)
+
+			secretRef := secretRefs[trustedCertSecret]
+			var secretType api_v1.SecretType
+			if secretRef.Secret != nil {
+				secretType = secretRef.Secret.Type
+			}
+			if secretType != "" && secretType != secrets.SecretTypeCA {
+				res.addWarningf("JWT policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, trustedCertSecret, secretType, secrets.SecretTypeCA)
+				res.isError = true
+				return res
+			} else if secretRef.Error != nil {
+				res.addWarningf("JWT policy %s references an invalid secret %s: %v", polKey, trustedCertSecret, secretRef.Error)
+				res.isError = true
+				return res
+			}
+
+			jwksURI.TrustedCert = secretRef.Path
+		}
+
+		p.JWTAuth = &version2.JWTAuth{
+			Realm:   jwtAuth.Realm,
+			Token:   jwtAuth.Token,
+			JwksURI: jwksURI,
+		}
+		p.addJWTClaimRequirements(jwtAuth.Claims, polNamespace, polName, vsNamespace, vsName)
+		return res
+	}
+
 	jwtSecretKey := fmt.Sprintf("%v/%v", polNamespace, jwtAuth.Secret)
 	secretRef := secretRefs[jwtSecretKey]
 	var secretType api_v1.SecretType
@@ -728,9 +965,86 @@ func (p *policiesCfg) addJWTAuthConfig(
 		Realm:  jwtAuth.Realm,
 		Token:  jwtAuth.Token,
 	}
+	p.addJWTClaimRequirements(jwtAuth.Claims, polNamespace, polName, vsNamespace, vsName)
 	return res
 }
 
+// addJWTClaimRequirements generates a map for every claim requirement of the JWT policy. The variable of the map is not empty
+// and not "0" only if the claim of the JWT meets the requirement, so that the variables can be used in auth_jwt_require.
+func (p *policiesCfg) addJWTClaimRequirements(
+	claims []conf_v1.JWTClaimRequirement,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+) {
+	if len(claims) == 0 {
+		return
+	}
+
+	safeName := toVariableName(fmt.Sprintf("%s_%s_%s_%s", polNamespace, polName, vsNamespace, vsName))
+
+	for i, c := range claims {
+		variable := fmt.Sprintf("$pol_jwt_%s_claim_%d", safeName, i)
+
+		p.Maps = append(p.Maps, version2.Map{
+			Source:     fmt.Sprintf("$jwt_claim_%s", c.Name),
+			Variable:   variable,
+			Parameters: generateJWTClaimMapParameters(c),
+		})
+		p.JWTAuth.Require = append(p.JWTAuth.Require, variable)
+	}
+}
+
+// toVariableName converts the names of Kubernetes resources into a string that can be used in the names of NGINX variables.
+// Because both "-" and "." are replaced with "_", different names can be converted into the same string.
+// To keep the strings unique, every string ends with a hash of the names.
+func toVariableName(s string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return fmt.Sprintf("%s_%08x", strings.NewReplacer("-", "_", ".", "_").Replace(s), h.Sum32())
+}
+
+func generateJWTClaimMapParameters(claim conf_v1.JWTClaimRequirement) []version2.Parameter {
+	if claim.Operator == conf_v1.JWTClaimOperatorExists {
+		return []version2.Parameter{
+			{
+				Value:  `""`,
+				Result: "0",
+			},
+			{
+				Value:  "default",
+				Result: "1",
+			},
+		}
+	}
+
+	var quotedValues []string
+	for _, v := range claim.Values {
+		quotedValues = append(quotedValues, regexp.QuoteMeta(v))
+	}
+	values := strings.Join(quotedValues, "|")
+
+	var regex string
+	if claim.Operator == conf_v1.JWTClaimOperatorContains {
+		// the elements of an array claim are separated by commas
+		regex = fmt.Sprintf("(^|,)(%s)(,|$)", values)
+	} else {
+		regex = fmt.Sprintf("^(%s)$", values)
+	}
+
+	return []version2.Parameter{
+		{
+			Value:  fmt.Sprintf(`"~%s"`, regex),
+			Result: "1",
+		},
+		{
+			Value:  "default",
+			Result: "0",
+		},
+	}
+}
+
 func (p *policiesCfg) addIngressMTLSConfig(
 	ingressMTLS *conf_v1.IngressMTLS,
 	polKey string,
@@ -780,14 +1094,44 @@ func (p *policiesCfg) addIngressMTLSConfig(
 		verifyClient = ingressMTLS.VerifyClient
 	}
 
+	var clientCRL string
+	if _, exists := secretRef.Secret.Data[secrets.CRLKey]; exists {
+		clientCRL = generateCRLFileName(secretRef.Path)
+	}
+
 	p.IngressMTLS = &version2.IngressMTLS{
-		ClientCert:   secretRef.Path,
-		VerifyClient: verifyClient,
-		VerifyDepth:  verifyDepth,
+		ClientCert:        secretRef.Path,
+		ClientCRL:         clientCRL,
+		VerifyClient:      verifyClient,
+		VerifyDepth:       verifyDepth,
+		ClientCertHeaders: generateClientCertHeaders(ingressMTLS.ClientCertHeaders),
 	}
 	return res
 }
 
+// generateClientCertHeaders generates the request headers that pass the details of the client certificate to the upstreams.
+func generateClientCertHeaders(headers *conf_v1.ClientCertHeaders) []version2.Header {
+	if headers == nil {
+		return nil
+	}
+
+	fields := []version2.Header{
+		{Name: headers.SubjectDN, Value: "$ssl_client_s_dn"},
+		{Name: headers.Fingerprint, Value: "$ssl_client_fingerprint"},
+		{Name: headers.Serial, Value: "$ssl_client_serial"},
+		{Name: headers.Cert, Value: "$ssl_client_escaped_cert"},
+	}
+
+	var result []version2.Header
+	for _, h := range fields {
+		if h.Name != "" {
+			result = append(result, h)
+		}
+	}
+
+	return result
+}
+
 func (p *policiesCfg) addEgressMTLSConfig(
 	egressMTLS *conf_v1.EgressMTLS,
 	polKey string,
@@ -868,11 +1212,15 @@ func (p *policiesCfg) addOIDCConfig(
 	oidc *conf_v1.OIDC,
 	polKey string,
 	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
 	secretRefs map[string]*secrets.SecretReference,
+	oidcDiscoveries map[string]*OIDCDiscovery,
 	oidcPolCfg *oidcPolicyCfg,
 ) *validationResults {
 	res := newValidationResults()
-	if p.OIDC {
+	if p.OIDC != nil {
 		res.addWarningf(
 			"Multiple oidc policies in the same context is not valid. OIDC policy %s will be ignored",
 			polKey,
@@ -880,62 +1228,372 @@ func (p *policiesCfg) addOIDCConfig(
 		return res
 	}
 
-	if oidcPolCfg.oidc != nil {
-		if oidcPolCfg.key != polKey {
-			res.addWarningf(
-				"Only one oidc policy is allowed in a VirtualServer and its VirtualServerRoutes. Can't use %s. Use %s",
-				polKey,
-				oidcPolCfg.key,
-			)
-			res.isError = true
-			return res
-		}
-	} else {
-		secretKey := fmt.Sprintf("%v/%v", polNamespace, oidc.ClientSecret)
-		secretRef := secretRefs[secretKey]
+	if provider := oidcPolCfg.get(polKey); provider != nil {
+		p.OIDC = provider
+		return res
+	}
 
-		var secretType api_v1.SecretType
-		if secretRef.Secret != nil {
-			secretType = secretRef.Secret.Type
-		}
-		if secretType != "" && secretType != secrets.SecretTypeOIDC {
-			res.addWarningf("OIDC policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, secretKey, secretType, secrets.SecretTypeOIDC)
-			res.isError = true
-			return res
-		} else if secretRef.Error != nil {
-			res.addWarningf("OIDC policy %s references an invalid secret %s: %v", polKey, secretKey, secretRef.Error)
+	secretKey := fmt.Sprintf("%v/%v", polNamespace, oidc.ClientSecret)
+	secretRef := secretRefs[secretKey]
+
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeOIDC {
+		res.addWarningf("OIDC policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, secretKey, secretType, secrets.SecretTypeOIDC)
+		res.isError = true
+		return res
+	} else if secretRef.Error != nil {
+		res.addWarningf("OIDC policy %s references an invalid secret %s: %v", polKey, secretKey, secretRef.Error)
+		res.isError = true
+		return res
+	}
+
+	clientSecret := secretRef.Secret.Data[ClientSecretKey]
+
+	oidc, err := applyOIDCDiscovery(oidc, oidcDiscoveries)
+	if err != nil {
+		res.addWarningf("OIDC policy %s can't be used: %v", polKey, err)
+		res.isError = true
+		return res
+	}
+
+	id := toVariableName(fmt.Sprintf("%s_%s_%s_%s", polNamespace, polName, vsNamespace, vsName))
+
+	// the first provider keeps the well-known defaults, so that a VirtualServer with a single provider
+	// doesn't depend on the generated ID. The defaults of other providers include the ID to keep them unique.
+	defaultSuffix := ""
+	if len(oidcPolCfg.providers) > 0 {
+		defaultSuffix = "_" + id
+	}
+
+	provider := &version2.OIDC{
+		ID:                    id,
+		AuthEndpoint:          oidc.AuthEndpoint,
+		TokenEndpoint:         oidc.TokenEndpoint,
+		JwksURI:               oidc.JWKSURI,
+		ClientID:              oidc.ClientID,
+		ClientSecret:          string(clientSecret),
+		Scope:                 generateString(oidc.Scope, "openid"),
+		RedirectURI:           generateString(oidc.RedirectURI, "/_codexch"+defaultSuffix),
+		PKCE:                  oidc.PKCE,
+		EndSessionEndpoint:    oidc.EndSessionEndpoint,
+		PostLogoutRedirectURI: generateString(oidc.PostLogoutRedirectURI, "/_logout"),
+		LogoutURI:             generateString(oidc.LogoutURI, "/logout"+defaultSuffix),
+		CookieName:            "auth_token" + defaultSuffix,
+		CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+	}
+
+	if oidc.SessionCookie != nil {
+		provider.CookieName = generateString(oidc.SessionCookie.Name, provider.CookieName)
+		provider.CookieFlags = generateOIDCCookieFlags(oidc.SessionCookie)
+	}
+
+	for i, other := range oidcPolCfg.providers {
+		if conflict := findOIDCProviderConflict(provider, other); conflict != "" {
+			res.addWarningf("OIDC policy %s uses the same %s as OIDC policy %s", polKey, conflict, oidcPolCfg.keys[i])
 			res.isError = true
 			return res
 		}
+	}
+
+	oidcPolCfg.add(polKey, provider)
+	p.OIDC = provider
+
+	return res
+}
 
-		clientSecret := secretRef.Secret.Data[ClientSecretKey]
+// applyOIDCDiscovery returns the OIDC policy with the endpoints that are not specified in the policy
+// taken from the metadata discovered from the issuer of the policy.
+func applyOIDCDiscovery(oidcPolicy *conf_v1.OIDC, discoveries map[string]*OIDCDiscovery) (*conf_v1.OIDC, error) {
+	if oidcPolicy.Issuer == "" {
+		return oidcPolicy, nil
+	}
 
-		redirectURI := oidc.RedirectURI
-		if redirectURI == "" {
-			redirectURI = "/_codexch"
+	// the end session endpoint is optional, so the discovery is required only for the other endpoints
+	endpointsSpecified := oidcPolicy.AuthEndpoint != "" && oidcPolicy.TokenEndpoint != "" && oidcPolicy.JWKSURI != ""
+	discovery, exists := discoveries[oidcPolicy.Issuer]
+	if !exists {
+		if endpointsSpecified {
+			return oidcPolicy, nil
 		}
-		scope := oidc.Scope
-		if scope == "" {
-			scope = "openid"
+		return nil, fmt.Errorf("the metadata of the issuer %s is not discovered yet", oidcPolicy.Issuer)
+	}
+	if discovery.Error != nil {
+		if endpointsSpecified {
+			return oidcPolicy, nil
 		}
+		return nil, fmt.Errorf("the discovery of the metadata of the issuer %s failed: %w", oidcPolicy.Issuer, discovery.Error)
+	}
+
+	result := oidcPolicy.DeepCopy()
+	result.AuthEndpoint = generateString(result.AuthEndpoint, discovery.AuthEndpoint)
+	result.TokenEndpoint = generateString(result.TokenEndpoint, discovery.TokenEndpoint)
+	result.JWKSURI = generateString(result.JWKSURI, discovery.JWKSURI)
+	result.EndSessionEndpoint = generateString(result.EndSessionEndpoint, discovery.EndSessionEndpoint)
+
+	return result, nil
+}
 
-		oidcPolCfg.oidc = &version2.OIDC{
-			AuthEndpoint:  oidc.AuthEndpoint,
-			TokenEndpoint: oidc.TokenEndpoint,
-			JwksURI:       oidc.JWKSURI,
-			ClientID:      oidc.ClientID,
-			ClientSecret:  string(clientSecret),
-			Scope:         scope,
-			RedirectURI:   redirectURI,
+func generateOIDCCookieFlags(cookie *conf_v1.OIDCSessionCookie) string {
+	flags := fmt.Sprintf("Path=%s;", generateString(cookie.Path, "/"))
+	if cookie.Domain != "" {
+		flags += fmt.Sprintf(" Domain=%s;", cookie.Domain)
+	}
+	flags += fmt.Sprintf(" SameSite=%s; HttpOnly;", generateString(cookie.SameSite, "Lax"))
+
+	return flags
+}
+
+// findOIDCProviderConflict returns the name of the setting that prevents two OIDC providers
+// from being used in the same server or an empty string if there is no such setting.
+func findOIDCProviderConflict(provider *version2.OIDC, other *version2.OIDC) string {
+	locations := map[string]bool{
+		other.RedirectURI: true,
+		other.LogoutURI:   true,
+	}
+
+	switch {
+	case locations[provider.RedirectURI]:
+		return fmt.Sprintf("redirect URI %s", provider.RedirectURI)
+	case locations[provider.LogoutURI]:
+		return fmt.Sprintf("logout URI %s", provider.LogoutURI)
+	case provider.CookieName == other.CookieName:
+		return fmt.Sprintf("session cookie %s", provider.CookieName)
+	}
+
+	return ""
+}
+
+func (p *policiesCfg) addAPIKeyConfig(
+	apiKey *conf_v1.APIKey,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+	secretRefs map[string]*secrets.SecretReference,
+) *validationResults {
+	res := newValidationResults()
+	if p.APIKey != nil {
+		res.addWarningf("Multiple apiKey policies in the same context is not valid. API key policy %s will be ignored", polKey)
+		return res
+	}
+
+	secretKey := fmt.Sprintf("%v/%v", polNamespace, apiKey.ClientSecret)
+	secretRef := secretRefs[secretKey]
+
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeAPIKey {
+		res.addWarningf("API key policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, secretKey, secretType, secrets.SecretTypeAPIKey)
+		res.isError = true
+		return res
+	} else if secretRef.Error != nil {
+		res.addWarningf("API key policy %s references an invalid secret %s: %v", polKey, secretKey, secretRef.Error)
+		res.isError = true
+		return res
+	}
+
+	safeName := toVariableName(fmt.Sprintf("%s_%s_%s_%s", polNamespace, polName, vsNamespace, vsName))
+	clientMap := fmt.Sprintf("$apikey_auth_client_%s", safeName)
+
+	token, tokenMaps := generateAPIKeyToken(apiKey.SuppliedIn, safeName)
+
+	p.Maps = append(p.Maps, tokenMaps...)
+	p.Maps = append(p.Maps, version2.Map{
+		Source:     "$apikey_auth_hash",
+		Variable:   clientMap,
+		Parameters: generateAPIKeyClientMapParameters(secretRef.Secret.Data),
+	})
+
+	p.APIKey = &version2.APIKey{
+		Token:      token,
+		ClientMap:  clientMap,
+		RejectCode: generateIntFromPointer(apiKey.RejectCode, 403),
+	}
+
+	return res
+}
+
+// generateAPIKeyToken generates the value of the API key of a request, which is taken from the first non-empty header
+// or query parameter, with the headers checked before the query parameters. For several sources, the value is selected
+// by a chain of maps: the map of every source falls back to the map of the next source if the source is empty.
+func generateAPIKeyToken(suppliedIn *conf_v1.SuppliedIn, safeName string) (string, []version2.Map) {
+	var sources []string
+
+	for _, h := range suppliedIn.Header {
+		sources = append(sources, fmt.Sprintf("${http_%s}", strings.ReplaceAll(strings.ToLower(h), "-", "_")))
+	}
+	for _, q := range suppliedIn.Query {
+		sources = append(sources, fmt.Sprintf("${arg_%s}", q))
+	}
+
+	if len(sources) == 1 {
+		return sources[0], nil
+	}
+
+	var maps []version2.Map
+
+	for i := 0; i < len(sources)-1; i++ {
+		next := sources[i+1]
+		if i+1 < len(sources)-1 {
+			next = fmt.Sprintf("$apikey_auth_token_%s_%d", safeName, i+1)
 		}
-		oidcPolCfg.key = polKey
+
+		maps = append(maps, version2.Map{
+			Source:   sources[i],
+			Variable: fmt.Sprintf("$apikey_auth_token_%s_%d", safeName, i),
+			Parameters: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: next,
+				},
+				{
+					Value:  "default",
+					Result: sources[i],
+				},
+			},
+		})
+	}
+
+	return maps[0].Variable, maps
+}
+
+// generateAPIKeyClientMapParameters generates the parameters of the map that maps the hashes of the API keys to the client IDs.
+// The requests with an unknown key get an empty client ID.
+func generateAPIKeyClientMapParameters(clients map[string][]byte) []version2.Parameter {
+	clientIDs := make([]string, 0, len(clients))
+	for clientID := range clients {
+		clientIDs = append(clientIDs, clientID)
+	}
+	sort.Strings(clientIDs)
+
+	var params []version2.Parameter
+
+	for _, clientID := range clientIDs {
+		params = append(params, version2.Parameter{
+			Value:  fmt.Sprintf(`"%s"`, secrets.NormalizeAPIKeyHash(clients[clientID])),
+			Result: fmt.Sprintf(`"%s"`, clientID),
+		})
+	}
+
+	params = append(params, version2.Parameter{
+		Value:  "default",
+		Result: `""`,
+	})
+
+	return params
+}
+
+// The defaults of the SecurityHeaders policy.
+const (
+	defaultHSTSMaxAge            = 31536000
+	defaultFrameOptions          = "DENY"
+	defaultContentTypeOptions    = "nosniff"
+	defaultReferrerPolicy        = "strict-origin-when-cross-origin"
+	defaultContentSecurityPolicy = "default-src 'self'"
+	defaultPermissionsPolicy     = "camera=(), geolocation=(), microphone=()"
+)
+
+func (p *policiesCfg) addSecurityHeadersConfig(
+	securityHeaders *conf_v1.SecurityHeaders,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+) *validationResults {
+	res := newValidationResults()
+	if p.SecurityHeaders != nil {
+		res.addWarningf("Multiple securityHeaders policies in the same context is not valid. SecurityHeaders policy %s will be ignored", polKey)
+		return res
 	}
 
-	p.OIDC = true
+	// not nil, so that the policy overrides the policy of the server even if it omits all headers
+	headers := []version2.Header{}
+
+	if hsts := securityHeaders.HSTS; hsts == nil || generateBool(hsts.Enable, true) {
+		variable := fmt.Sprintf("$security_headers_hsts_%s", toVariableName(fmt.Sprintf("%s_%s_%s_%s", polNamespace, polName, vsNamespace, vsName)))
+		p.Maps = append(p.Maps, generateHSTSMap(hsts, variable))
+		headers = append(headers, version2.Header{Name: "Strict-Transport-Security", Value: variable})
+	}
+
+	headers = appendSecurityHeader(headers, "X-Frame-Options", securityHeaders.FrameOptions, defaultFrameOptions)
+	headers = appendSecurityHeader(headers, "X-Content-Type-Options", securityHeaders.ContentTypeOptions, defaultContentTypeOptions)
+	headers = appendSecurityHeader(headers, "Referrer-Policy", securityHeaders.ReferrerPolicy, defaultReferrerPolicy)
+	headers = appendSecurityHeader(headers, "Content-Security-Policy", securityHeaders.ContentSecurityPolicy, defaultContentSecurityPolicy)
+	headers = appendSecurityHeader(headers, "Permissions-Policy", securityHeaders.PermissionsPolicy, defaultPermissionsPolicy)
+
+	p.SecurityHeaders = headers
 
 	return res
 }
 
+// appendSecurityHeader appends the header with the default value, unless the value is overridden.
+// The header is omitted if it is overridden with an empty value.
+func appendSecurityHeader(headers []version2.Header, name string, value *string, defaultValue string) []version2.Header {
+	v := defaultValue
+	if value != nil {
+		v = *value
+	}
+
+	if v == "" {
+		return headers
+	}
+
+	return append(headers, version2.Header{Name: name, Value: v})
+}
+
+// generateHSTSMap generates the map that sets the value of the Strict-Transport-Security header only for HTTPS requests,
+// because browsers ignore the header received over HTTP. An empty header is not added to a response.
+func generateHSTSMap(hsts *conf_v1.HSTS, variable string) version2.Map {
+	maxAge := defaultHSTSMaxAge
+	includeSubdomains := true
+	preload := true
+	behindProxy := false
+
+	if hsts != nil {
+		maxAge = generateIntFromPointer(hsts.MaxAge, defaultHSTSMaxAge)
+		includeSubdomains = generateBool(hsts.IncludeSubdomains, true)
+		preload = generateBool(hsts.Preload, true)
+		behindProxy = hsts.BehindProxy
+	}
+
+	value := fmt.Sprintf("max-age=%d", maxAge)
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if preload {
+		value += "; preload"
+	}
+
+	source := "$https"
+	httpsValue := "on"
+	if behindProxy {
+		source = "$http_x_forwarded_proto"
+		httpsValue = "https"
+	}
+
+	return version2.Map{
+		Source:   source,
+		Variable: variable,
+		Parameters: []version2.Parameter{
+			{
+				Value:  httpsValue,
+				Result: fmt.Sprintf(`"%s"`, value),
+			},
+			{
+				Value:  "default",
+				Result: `""`,
+			},
+		},
+	}
+}
+
 func (p *policiesCfg) addWAFConfig(
 	waf *conf_v1.WAF,
 	polKey string,
@@ -1023,7 +1681,15 @@ func (vsc *virtualServerConfigurator) generatePolicies(
 					ownerDetails.vsName,
 				)
 			case pol.Spec.JWTAuth != nil:
-				res = config.addJWTAuthConfig(pol.Spec.JWTAuth, key, polNamespace, policyOpts.secretRefs)
+				res = config.addJWTAuthConfig(
+					pol.Spec.JWTAuth,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+					policyOpts.secretRefs,
+				)
 			case pol.Spec.IngressMTLS != nil:
 				res = config.addIngressMTLSConfig(
 					pol.Spec.IngressMTLS,
@@ -1036,20 +1702,49 @@ func (vsc *virtualServerConfigurator) generatePolicies(
 			case pol.Spec.EgressMTLS != nil:
 				res = config.addEgressMTLSConfig(pol.Spec.EgressMTLS, key, polNamespace, policyOpts.secretRefs)
 			case pol.Spec.OIDC != nil:
-				res = config.addOIDCConfig(pol.Spec.OIDC, key, polNamespace, policyOpts.secretRefs, vsc.oidcPolCfg)
+				res = config.addOIDCConfig(
+					pol.Spec.OIDC,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+					policyOpts.secretRefs,
+					policyOpts.oidcDiscoveries,
+					vsc.oidcPolCfg,
+				)
+			case pol.Spec.APIKey != nil:
+				res = config.addAPIKeyConfig(
+					pol.Spec.APIKey,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+					policyOpts.secretRefs,
+				)
 			case pol.Spec.WAF != nil:
 				res = config.addWAFConfig(pol.Spec.WAF, key, polNamespace, policyOpts.apResources)
+			case pol.Spec.SecurityHeaders != nil:
+				res = config.addSecurityHeadersConfig(
+					pol.Spec.SecurityHeaders,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+				)
 			default:
 				res = newValidationResults()
 			}
-			vsc.addWarnings(ownerDetails.owner, res.warnings)
+			vsc.addPolicyWarnings(ownerDetails.owner, key, res.warnings)
 			if res.isError {
 				return policiesCfg{
 					ErrorReturn: &version2.Return{Code: 500},
 				}
 			}
 		} else {
-			vsc.addWarningf(ownerDetails.owner, "Policy %s is missing or invalid", key)
+			vsc.addPolicyWarnings(ownerDetails.owner, key, []string{fmt.Sprintf("Policy %s is missing or invalid", key)})
 			return policiesCfg{
 				ErrorReturn: &version2.Return{Code: 500},
 			}
@@ -1096,6 +1791,38 @@ func generateLimitReqOptions(rateLimitPol *conf_v1.RateLimit) version2.LimitReqO
 	}
 }
 
+// appendJwksURI appends the JWKS URI of the JWT authentication configuration, unless it has already been appended,
+// because the same JWT policy can be referenced in multiple contexts of a VirtualServer.
+func appendJwksURI(jwksURIs []version2.JwksURI, jwtAuth *version2.JWTAuth) []version2.JwksURI {
+	if jwtAuth == nil || jwtAuth.JwksURI == nil {
+		return jwksURIs
+	}
+
+	for _, j := range jwksURIs {
+		if j.Location == jwtAuth.JwksURI.Location {
+			return jwksURIs
+		}
+	}
+
+	return append(jwksURIs, *jwtAuth.JwksURI)
+}
+
+// removeDuplicateMaps removes the maps with the same variable, because the same JWT policy can be referenced
+// in multiple contexts of a VirtualServer, which generates the same maps for its claim requirements.
+func removeDuplicateMaps(maps []version2.Map) []version2.Map {
+	encountered := make(map[string]bool)
+	var result []version2.Map
+
+	for _, m := range maps {
+		if !encountered[m.Variable] {
+			encountered[m.Variable] = true
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
 func removeDuplicateLimitReqZones(rlz []version2.LimitReqZone) []version2.LimitReqZone {
 	encountered := make(map[string]bool)
 	result := []version2.LimitReqZone{}
@@ -1118,7 +1845,9 @@ func addPoliciesCfgToLocation(cfg policiesCfg, location *version2.Location) {
 	location.JWTAuth = cfg.JWTAuth
 	location.EgressMTLS = cfg.EgressMTLS
 	location.OIDC = cfg.OIDC
+	location.APIKey = cfg.APIKey
 	location.WAF = cfg.WAF
+	location.SecurityHeaders = cfg.SecurityHeaders
 	location.PoliciesErrorReturn = cfg.ErrorReturn
 }
 
@@ -1128,6 +1857,142 @@ func addPoliciesCfgToLocations(cfg policiesCfg, locations []version2.Location) {
 	}
 }
 
+// addServerAPIKeyToLocations adds the API key authentication of the server to the locations without their own.
+// Unlike auth_jwt, the directives of API key authentication are not inherited by the locations, and they can't be
+// generated in the server, because they would be applied before the API key authentication of a location.
+func addServerAPIKeyToLocations(apiKey *version2.APIKey, locations []version2.Location) {
+	if apiKey == nil {
+		return
+	}
+
+	for i := range locations {
+		if locations[i].APIKey == nil {
+			locations[i].APIKey = apiKey
+		}
+	}
+}
+
+// addServerSecurityHeadersToLocations adds the security headers of the server to the locations without their own.
+// The add_header directives of the server are not inherited by the locations with their own add_header directives.
+func addServerSecurityHeadersToLocations(headers []version2.Header, locations []version2.Location) {
+	if headers == nil {
+		return
+	}
+
+	for i := range locations {
+		if locations[i].SecurityHeaders == nil {
+			locations[i].SecurityHeaders = headers
+		}
+	}
+}
+
+// addSecurityHeadersToInternalLocations adds the security headers to the error page and return locations without their own,
+// so that the responses generated by NGINX get the same headers as the responses of the upstreams.
+func addSecurityHeadersToInternalLocations(
+	headers []version2.Header,
+	errorPageLocations []version2.ErrorPageLocation,
+	returnLocations []version2.ReturnLocation,
+) {
+	if headers == nil {
+		return
+	}
+
+	for i := range errorPageLocations {
+		if errorPageLocations[i].SecurityHeaders == nil {
+			errorPageLocations[i].SecurityHeaders = headers
+		}
+	}
+
+	for i := range returnLocations {
+		if returnLocations[i].SecurityHeaders == nil {
+			returnLocations[i].SecurityHeaders = headers
+		}
+	}
+}
+
+// removeSecurityHeadersFromAddHeaders removes the response headers that have the same name as the security headers
+// of the locations, so that the headers of a SecurityHeaders policy take precedence and are not added twice.
+func removeSecurityHeadersFromAddHeaders(
+	locations []version2.Location,
+	errorPageLocations []version2.ErrorPageLocation,
+	returnLocations []version2.ReturnLocation,
+) {
+	for i := range locations {
+		locations[i].AddHeaders = filterAddHeaders(locations[i].AddHeaders, locations[i].SecurityHeaders)
+	}
+
+	for i := range errorPageLocations {
+		errorPageLocations[i].AddHeaders = filterAddHeaders(errorPageLocations[i].AddHeaders, errorPageLocations[i].SecurityHeaders)
+	}
+
+	for i := range returnLocations {
+		returnLocations[i].AddHeaders = filterAddHeaders(returnLocations[i].AddHeaders, returnLocations[i].SecurityHeaders)
+	}
+}
+
+// filterAddHeaders returns the response headers without the headers that have the same name (case-insensitive)
+// as any of the security headers. The original slice is not modified.
+func filterAddHeaders(addHeaders []version2.AddHeader, securityHeaders []version2.Header) []version2.AddHeader {
+	if len(securityHeaders) == 0 {
+		return addHeaders
+	}
+
+	names := make(map[string]bool)
+	for _, h := range securityHeaders {
+		names[strings.ToLower(h.Name)] = true
+	}
+
+	var result []version2.AddHeader
+	for _, h := range addHeaders {
+		if !names[strings.ToLower(h.Name)] {
+			result = append(result, h)
+		}
+	}
+
+	return result
+}
+
+// addHeadersToInternalLocations adds the response headers of a VirtualServer or a VirtualServerRoute to the locations
+// that don't pass requests to an upstream, like the locations of the redirect actions, and to the error page and return
+// locations, so that the responses generated by NGINX get the headers of the routes. The headers of an error page
+// override the response headers with the same name.
+func addHeadersToInternalLocations(
+	headers *conf_v1.Headers,
+	locations []version2.Location,
+	errorPageLocations []version2.ErrorPageLocation,
+	returnLocations []version2.ReturnLocation,
+) {
+	addHeaders := generateResponseAddHeaders(headers)
+	if addHeaders == nil {
+		return
+	}
+
+	for i := range locations {
+		if locations[i].ProxyPass == "" {
+			locations[i].AddHeaders = addHeaders
+		}
+	}
+
+	for i := range errorPageLocations {
+		overridden := make(map[string]bool)
+		for _, h := range errorPageLocations[i].Headers {
+			overridden[strings.ToLower(h.Name)] = true
+		}
+
+		var errorPageAddHeaders []version2.AddHeader
+		for _, h := range addHeaders {
+			if !overridden[strings.ToLower(h.Name)] {
+				errorPageAddHeaders = append(errorPageAddHeaders, h)
+			}
+		}
+		errorPageLocations[i].AddHeaders = errorPageAddHeaders
+	}
+
+	for i := range returnLocations {
+		returnLocations[i].AddHeaders = addHeaders
+	}
+}
+
 func getUpstreamResourceLabels(owner runtime.Object) version2.UpstreamLabels {
 	var resourceType, resourceName, resourceNamespace string
 
@@ -1330,7 +2195,15 @@ func upstreamHasKeepalive(upstream conf_v1.Upstream, cfgParams *ConfigParams) bo
 }
 
 func generateRewrites(path string, proxy *conf_v1.ActionProxy, internal bool, originalPath string) []string {
-	if proxy == nil || proxy.RewritePath == "" {
+	if proxy == nil {
+		return nil
+	}
+
+	if len(proxy.Rewrites) > 0 {
+		return generateRewriteRules(proxy.Rewrites, internal)
+	}
+
+	if proxy.RewritePath == "" {
 		return nil
 	}
 
@@ -1362,6 +2235,34 @@ func generateRewrites(path string, proxy *conf_v1.ActionProxy, internal bool, or
 	return rewrites
 }
 
+// generateRewriteRules generates the rewrites for the rewrite rules of an ActionProxy. The rules are applied in order
+// and the first matching rule stops the processing of the rest of the rules.
+func generateRewriteRules(rules []conf_v1.RewriteRule, internal bool) []string {
+	var rewrites []string
+
+	if internal {
+		// For internal locations (splits locations) only, recover the original request_uri.
+		rewrites = append(rewrites, "^ $request_uri")
+	}
+
+	for _, r := range rules {
+		replacement := r.Replacement
+		if r.DropQueryString {
+			// a question mark at the end of the replacement prevents NGINX from appending the original arguments
+			replacement += "?"
+		}
+		rewrites = append(rewrites, fmt.Sprintf(`"%v" "%v" break`, r.Match, replacement))
+	}
+
+	if internal {
+		// The URI of an internal location is changed into the original request_uri. If no rule matches, the processing
+		// must stop anyway, otherwise NGINX searches for the location again and loops back into the internal location.
+		rewrites = append(rewrites, `"^(.*)$" "$1" break`)
+	}
+
+	return rewrites
+}
+
 func generateProxyPassRewrite(path string, proxy *conf_v1.ActionProxy, internal bool) string {
 	if proxy == nil || internal {
 		return ""
@@ -1377,7 +2278,7 @@ func generateProxyPassRewrite(path string, proxy *conf_v1.ActionProxy, internal
 func generateProxyPass(tlsEnabled bool, upstreamName string, internal bool, proxy *conf_v1.ActionProxy) string {
 	proxyPass := fmt.Sprintf("%v://%v", generateProxyPassProtocol(tlsEnabled), upstreamName)
 
-	if internal && (proxy == nil || proxy.RewritePath == "") {
+	if internal && (proxy == nil || proxy.RewritePath == "" && len(proxy.Rewrites) == 0) {
 		return fmt.Sprintf("%v$request_uri", proxyPass)
 	}
 
@@ -1557,6 +2458,132 @@ func generateProxyAddHeaders(proxy *conf_v1.ActionProxy) []version2.AddHeader {
 	return addHeaders
 }
 
+// mergeHeaders merges the headers of a VirtualServer with the headers of a VirtualServerRoute.
+// The headers of the VirtualServerRoute override the headers of the VirtualServer with the same name.
+func mergeHeaders(parent *conf_v1.Headers, child *conf_v1.Headers) *conf_v1.Headers {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+
+	return &conf_v1.Headers{
+		Set:  mergeSetHeaders(parent.Set, child.Set),
+		Add:  mergeAddHeaders(parent.Add, child.Add),
+		Hide: mergeHideHeaders(parent.Hide, child.Hide),
+	}
+}
+
+// mergeSetHeaders returns the parent headers that are not overridden by the child headers followed by the child headers.
+// The names of the headers are case-insensitive.
+func mergeSetHeaders(parent []conf_v1.Header, child []conf_v1.Header) []conf_v1.Header {
+	overridden := make(map[string]bool)
+	for _, h := range child {
+		overridden[strings.ToLower(h.Name)] = true
+	}
+
+	var headers []conf_v1.Header
+	for _, h := range parent {
+		if !overridden[strings.ToLower(h.Name)] {
+			headers = append(headers, h)
+		}
+	}
+
+	return append(headers, child...)
+}
+
+// mergeAddHeaders merges the response headers like mergeSetHeaders.
+func mergeAddHeaders(parent []conf_v1.AddHeader, child []conf_v1.AddHeader) []conf_v1.AddHeader {
+	overridden := make(map[string]bool)
+	for _, h := range child {
+		overridden[strings.ToLower(h.Name)] = true
+	}
+
+	var headers []conf_v1.AddHeader
+	for _, h := range parent {
+		if !overridden[strings.ToLower(h.Name)] {
+			headers = append(headers, h)
+		}
+	}
+
+	return append(headers, child...)
+}
+
+// mergeHideHeaders returns the union of the hidden headers.
+func mergeHideHeaders(parent []string, child []string) []string {
+	seen := make(map[string]bool)
+
+	var headers []string
+	for _, h := range append(append([]string{}, parent...), child...) {
+		if seen[strings.ToLower(h)] {
+			continue
+		}
+		seen[strings.ToLower(h)] = true
+		headers = append(headers, h)
+	}
+
+	return headers
+}
+
+// applyHeadersToAction returns the action with the headers merged into its proxy. A pass action becomes a proxy action.
+// The headers of the proxy override the headers with the same name. The original action is not modified.
+func applyHeadersToAction(action *conf_v1.Action, headers *conf_v1.Headers) *conf_v1.Action {
+	if action == nil || headers == nil || action.Redirect != nil || action.Return != nil {
+		return action
+	}
+
+	proxy := &conf_v1.ActionProxy{Upstream: action.Pass}
+	if action.Proxy != nil {
+		proxy = action.Proxy.DeepCopy()
+	}
+
+	if proxy.RequestHeaders == nil {
+		proxy.RequestHeaders = &conf_v1.ProxyRequestHeaders{}
+	}
+	proxy.RequestHeaders.Set = mergeSetHeaders(headers.Set, proxy.RequestHeaders.Set)
+
+	if proxy.ResponseHeaders == nil {
+		proxy.ResponseHeaders = &conf_v1.ProxyResponseHeaders{}
+	}
+	proxy.ResponseHeaders.Add = mergeAddHeaders(headers.Add, proxy.ResponseHeaders.Add)
+	proxy.ResponseHeaders.Hide = mergeHideHeaders(headers.Hide, proxy.ResponseHeaders.Hide)
+
+	return &conf_v1.Action{Proxy: proxy}
+}
+
+// applyHeadersToRoute returns the route with the headers merged into all of its actions, including the actions
+// of the splits and matches. The original route is not modified.
+func applyHeadersToRoute(route conf_v1.Route, headers *conf_v1.Headers) conf_v1.Route {
+	if headers == nil {
+		return route
+	}
+
+	r := route.DeepCopy()
+
+	r.Action = applyHeadersToAction(r.Action, headers)
+	for i := range r.Splits {
+		r.Splits[i].Action = applyHeadersToAction(r.Splits[i].Action, headers)
+	}
+	for i := range r.Matches {
+		r.Matches[i].Action = applyHeadersToAction(r.Matches[i].Action, headers)
+		for j := range r.Matches[i].Splits {
+			r.Matches[i].Splits[j].Action = applyHeadersToAction(r.Matches[i].Splits[j].Action, headers)
+		}
+	}
+
+	return *r
+}
+
+// generateResponseAddHeaders generates the response headers of the headers of a VirtualServer or a VirtualServerRoute.
+func generateResponseAddHeaders(headers *conf_v1.Headers) []version2.AddHeader {
+	if headers == nil {
+		return nil
+	}
+
+	return generateProxyAddHeaders(&conf_v1.ActionProxy{ResponseHeaders: &conf_v1.ProxyResponseHeaders{Add: headers.Add}})
+}
+
 func generateLocationForProxying(path string, upstreamName string, upstream conf_v1.Upstream,
 	cfgParams *ConfigParams, errorPages []conf_v1.ErrorPage, internal bool, errPageIndex int,
 	proxySSLName string, proxy *conf_v1.ActionProxy, originalPath string, locationSnippets []string, isVSR bool, vsrName string, vsrNamespace string) version2.Location {
@@ -20,6 +20,10 @@ func createPointerFromBool(b bool) *bool {
 	return &b
 }
 
+func createPointerFromString(s string) *string {
+	return &s
+}
+
 func TestVirtualServerExString(t *testing.T) {
 	tests := []struct {
 		input    *VirtualServerEx
@@ -762,7 +766,7 @@ func TestGenerateVirtualServerConfigWithSpiffeCerts(t *testing.T) {
 	}
 }
 
-func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
+func TestGenerateVirtualServerConfigWithHeaders(t *testing.T) {
 	virtualServerEx := VirtualServerEx{
 		VirtualServer: &conf_v1.VirtualServer{
 			ObjectMeta: meta_v1.ObjectMeta{
@@ -773,32 +777,26 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 				Host: "cafe.example.com",
 				Upstreams: []conf_v1.Upstream{
 					{
-						Name:    "tea-v1",
-						Service: "tea-svc-v1",
+						Name:    "tea",
+						Service: "tea-svc",
 						Port:    80,
 					},
-					{
-						Name:    "tea-v2",
-						Service: "tea-svc-v2",
-						Port:    80,
+				},
+				Headers: &conf_v1.Headers{
+					Set: []conf_v1.Header{
+						{Name: "X-Server", Value: "cafe"},
+						{Name: "X-Route", Value: "vs"},
+					},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
 					},
+					Hide: []string{"X-Powered-By"},
 				},
 				Routes: []conf_v1.Route{
 					{
 						Path: "/tea",
-						Splits: []conf_v1.Split{
-							{
-								Weight: 90,
-								Action: &conf_v1.Action{
-									Pass: "tea-v1",
-								},
-							},
-							{
-								Weight: 10,
-								Action: &conf_v1.Action{
-									Pass: "tea-v2",
-								},
-							},
+						Action: &conf_v1.Action{
+							Pass: "tea",
 						},
 					},
 					{
@@ -808,20 +806,6 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 				},
 			},
 		},
-		Endpoints: map[string][]string{
-			"default/tea-svc-v1:80": {
-				"10.0.0.20:80",
-			},
-			"default/tea-svc-v2:80": {
-				"10.0.0.21:80",
-			},
-			"default/coffee-svc-v1:80": {
-				"10.0.0.30:80",
-			},
-			"default/coffee-svc-v2:80": {
-				"10.0.0.31:80",
-			},
-		},
 		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
 			{
 				ObjectMeta: meta_v1.ObjectMeta{
@@ -832,30 +816,32 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 					Host: "cafe.example.com",
 					Upstreams: []conf_v1.Upstream{
 						{
-							Name:    "coffee-v1",
-							Service: "coffee-svc-v1",
+							Name:    "coffee",
+							Service: "coffee-svc",
 							Port:    80,
 						},
-						{
-							Name:    "coffee-v2",
-							Service: "coffee-svc-v2",
-							Port:    80,
+					},
+					Headers: &conf_v1.Headers{
+						Set: []conf_v1.Header{
+							{Name: "x-route", Value: "vsr"},
+						},
+						Add: []conf_v1.AddHeader{
+							{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}, Always: true},
 						},
 					},
 					Subroutes: []conf_v1.Route{
 						{
 							Path: "/coffee",
-							Splits: []conf_v1.Split{
-								{
-									Weight: 40,
-									Action: &conf_v1.Action{
-										Pass: "coffee-v1",
+							Action: &conf_v1.Action{
+								Proxy: &conf_v1.ActionProxy{
+									Upstream: "coffee",
+									RequestHeaders: &conf_v1.ProxyRequestHeaders{
+										Set: []conf_v1.Header{
+											{Name: "X-Server", Value: "coffee"},
+										},
 									},
-								},
-								{
-									Weight: 60,
-									Action: &conf_v1.Action{
-										Pass: "coffee-v2",
+									ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+										Hide: []string{"x-powered-by", "X-Internal"},
 									},
 								},
 							},
@@ -868,187 +854,67 @@ func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
 
 	baseCfgParams := ConfigParams{}
 
-	expected := version2.VirtualServerConfig{
-		Upstreams: []version2.Upstream{
-			{
-				Name: "vs_default_cafe_tea-v1",
-				UpstreamLabels: version2.UpstreamLabels{
-					Service:           "tea-svc-v1",
-					ResourceType:      "virtualserver",
-					ResourceName:      "cafe",
-					ResourceNamespace: "default",
-				},
-				Servers: []version2.UpstreamServer{
-					{
-						Address: "10.0.0.20:80",
-					},
-				},
-			},
-			{
-				Name: "vs_default_cafe_tea-v2",
-				UpstreamLabels: version2.UpstreamLabels{
-					Service:           "tea-svc-v2",
-					ResourceType:      "virtualserver",
-					ResourceName:      "cafe",
-					ResourceNamespace: "default",
-				},
-				Servers: []version2.UpstreamServer{
-					{
-						Address: "10.0.0.21:80",
-					},
-				},
-			},
-			{
-				Name: "vs_default_cafe_vsr_default_coffee_coffee-v1",
-				UpstreamLabels: version2.UpstreamLabels{
-					Service:           "coffee-svc-v1",
-					ResourceType:      "virtualserverroute",
-					ResourceName:      "coffee",
-					ResourceNamespace: "default",
-				},
-				Servers: []version2.UpstreamServer{
-					{
-						Address: "10.0.0.30:80",
-					},
-				},
-			},
-			{
-				Name: "vs_default_cafe_vsr_default_coffee_coffee-v2",
-				UpstreamLabels: version2.UpstreamLabels{
-					Service:           "coffee-svc-v2",
-					ResourceType:      "virtualserverroute",
-					ResourceName:      "coffee",
-					ResourceNamespace: "default",
-				},
-				Servers: []version2.UpstreamServer{
-					{
-						Address: "10.0.0.31:80",
-					},
-				},
-			},
-		},
-		SplitClients: []version2.SplitClient{
-			{
-				Source:   "$request_id",
-				Variable: "$vs_default_cafe_splits_0",
-				Distributions: []version2.Distribution{
-					{
-						Weight: "90%",
-						Value:  "/internal_location_splits_0_split_0",
-					},
-					{
-						Weight: "10%",
-						Value:  "/internal_location_splits_0_split_1",
-					},
-				},
+	expectedServerAddHeaders := []version2.AddHeader{
+		{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+	}
+	expectedLocations := map[string]version2.Location{
+		"/tea": {
+			ProxySetHeaders: []version2.Header{
+				{Name: "X-Server", Value: "cafe"},
+				{Name: "X-Route", Value: "vs"},
+				{Name: "Host", Value: "$host"},
 			},
-			{
-				Source:   "$request_id",
-				Variable: "$vs_default_cafe_splits_1",
-				Distributions: []version2.Distribution{
-					{
-						Weight: "40%",
-						Value:  "/internal_location_splits_1_split_0",
-					},
-					{
-						Weight: "60%",
-						Value:  "/internal_location_splits_1_split_1",
-					},
-				},
+			AddHeaders: []version2.AddHeader{
+				{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
 			},
+			ProxyHideHeaders: []string{"X-Powered-By"},
 		},
-		HTTPSnippets:  []string{},
-		LimitReqZones: []version2.LimitReqZone{},
-		Server: version2.Server{
-			ServerName:  "cafe.example.com",
-			StatusZone:  "cafe.example.com",
-			VSNamespace: "default",
-			VSName:      "cafe",
-			InternalRedirectLocations: []version2.InternalRedirectLocation{
-				{
-					Path:        "/tea",
-					Destination: "$vs_default_cafe_splits_0",
-				},
-				{
-					Path:        "/coffee",
-					Destination: "$vs_default_cafe_splits_1",
-				},
+		"/coffee": {
+			ProxySetHeaders: []version2.Header{
+				{Name: "x-route", Value: "vsr"},
+				{Name: "X-Server", Value: "coffee"},
+				{Name: "Host", Value: "$host"},
 			},
-			Locations: []version2.Location{
-				{
-					Path:                     "/internal_location_splits_0_split_0",
-					ProxyPass:                "http://vs_default_cafe_tea-v1$request_uri",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "0s",
-					ProxyNextUpstreamTries:   0,
-					Internal:                 true,
-					ProxySSLName:             "tea-svc-v1.default.svc",
-					ProxyPassRequestHeaders:  true,
-					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
-					ServiceName:              "tea-svc-v1",
-				},
-				{
-					Path:                     "/internal_location_splits_0_split_1",
-					ProxyPass:                "http://vs_default_cafe_tea-v2$request_uri",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "0s",
-					ProxyNextUpstreamTries:   0,
-					Internal:                 true,
-					ProxySSLName:             "tea-svc-v2.default.svc",
-					ProxyPassRequestHeaders:  true,
-					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
-					ServiceName:              "tea-svc-v2",
-				},
-				{
-					Path:                     "/internal_location_splits_1_split_0",
-					ProxyPass:                "http://vs_default_cafe_vsr_default_coffee_coffee-v1$request_uri",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "0s",
-					ProxyNextUpstreamTries:   0,
-					Internal:                 true,
-					ProxySSLName:             "coffee-svc-v1.default.svc",
-					ProxyPassRequestHeaders:  true,
-					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
-					ServiceName:              "coffee-svc-v1",
-					IsVSR:                    true,
-					VSRName:                  "coffee",
-					VSRNamespace:             "default",
-				},
-				{
-					Path:                     "/internal_location_splits_1_split_1",
-					ProxyPass:                "http://vs_default_cafe_vsr_default_coffee_coffee-v2$request_uri",
-					ProxyNextUpstream:        "error timeout",
-					ProxyNextUpstreamTimeout: "0s",
-					ProxyNextUpstreamTries:   0,
-					Internal:                 true,
-					ProxySSLName:             "coffee-svc-v2.default.svc",
-					ProxyPassRequestHeaders:  true,
-					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
-					ServiceName:              "coffee-svc-v2",
-					IsVSR:                    true,
-					VSRName:                  "coffee",
-					VSRNamespace:             "default",
-				},
+			AddHeaders: []version2.AddHeader{
+				{Header: version2.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}, Always: true},
 			},
+			ProxyHideHeaders: []string{"X-Powered-By", "X-Internal"},
 		},
 	}
 
-	isPlus := false
-	isResolverConfigured := false
-	isWildcardEnabled := false
-	vsc := newVirtualServerConfigurator(&baseCfgParams, isPlus, isResolverConfigured, &StaticConfigParams{}, isWildcardEnabled)
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false)
 
 	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil)
-	if diff := cmp.Diff(expected, result); diff != "" {
-		t.Errorf("GenerateVirtualServerConfig() mismatch (-want +got):\n%s", diff)
-	}
 
+	if diff := cmp.Diff(expectedServerAddHeaders, result.Server.AddHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected server response headers (-want +got):\n%s", diff)
+	}
+	if len(result.Server.Locations) != len(expectedLocations) {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d locations but expected %d", len(result.Server.Locations), len(expectedLocations))
+	}
+	for _, loc := range result.Server.Locations {
+		expected := expectedLocations[loc.Path]
+		if diff := cmp.Diff(expected.ProxySetHeaders, loc.ProxySetHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected request headers for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+		if diff := cmp.Diff(expected.AddHeaders, loc.AddHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected response headers for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+		if diff := cmp.Diff(expected.ProxyHideHeaders, loc.ProxyHideHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected hidden headers for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+	}
 	if len(warnings) != 0 {
-		t.Errorf("GenerateVirtualServerConfig returned warnings: %v", vsc.warnings)
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+
+	if virtualServerEx.VirtualServerRoutes[0].Spec.Subroutes[0].Action.Proxy.RequestHeaders.Set[0].Name != "X-Server" ||
+		len(virtualServerEx.VirtualServerRoutes[0].Spec.Subroutes[0].Action.Proxy.RequestHeaders.Set) != 1 {
+		t.Errorf("GenerateVirtualServerConfig() modified the headers of the VirtualServerRoute")
 	}
 }
 
-func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
+func TestGenerateVirtualServerConfigWithSecurityHeadersPolicies(t *testing.T) {
 	virtualServerEx := VirtualServerEx{
 		VirtualServer: &conf_v1.VirtualServer{
 			ObjectMeta: meta_v1.ObjectMeta{
@@ -1057,22 +923,839 @@ func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
 			},
 			Spec: conf_v1.VirtualServerSpec{
 				Host: "cafe.example.com",
-				Upstreams: []conf_v1.Upstream{
+				Policies: []conf_v1.PolicyReference{
 					{
-						Name:    "tea-v1",
-						Service: "tea-svc-v1",
-						Port:    80,
+						Name: "server-headers",
 					},
+				},
+				Upstreams: []conf_v1.Upstream{
 					{
-						Name:    "tea-v2",
-						Service: "tea-svc-v2",
+						Name:    "tea",
+						Service: "tea-svc",
 						Port:    80,
 					},
 				},
 				Routes: []conf_v1.Route{
 					{
 						Path: "/tea",
-						Matches: []conf_v1.Match{
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+						ErrorPages: []conf_v1.ErrorPage{
+							{
+								Codes: []int{502},
+								Return: &conf_v1.ErrorPageReturn{
+									ActionReturn: conf_v1.ActionReturn{
+										Body: "Bad Gateway",
+									},
+								},
+							},
+						},
+					},
+					{
+						Path: "/coffee",
+						Policies: []conf_v1.PolicyReference{
+							{
+								Name: "route-headers",
+							},
+						},
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{
+								Body: "coffee",
+							},
+						},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"default/server-headers": {
+				Spec: conf_v1.PolicySpec{
+					SecurityHeaders: &conf_v1.SecurityHeaders{
+						HSTS: &conf_v1.HSTS{
+							Enable: createPointerFromBool(false),
+						},
+						ContentSecurityPolicy: createPointerFromString(""),
+						PermissionsPolicy:     createPointerFromString(""),
+						ReferrerPolicy:        createPointerFromString(""),
+					},
+				},
+			},
+			"default/route-headers": {
+				Spec: conf_v1.PolicySpec{
+					SecurityHeaders: &conf_v1.SecurityHeaders{
+						HSTS: &conf_v1.HSTS{
+							Enable: createPointerFromBool(false),
+						},
+						FrameOptions:          createPointerFromString("SAMEORIGIN"),
+						ContentTypeOptions:    createPointerFromString(""),
+						ContentSecurityPolicy: createPointerFromString(""),
+						PermissionsPolicy:     createPointerFromString(""),
+						ReferrerPolicy:        createPointerFromString(""),
+					},
+				},
+			},
+		},
+	}
+
+	serverHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "DENY"},
+		{Name: "X-Content-Type-Options", Value: "nosniff"},
+	}
+	routeHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil)
+
+	if diff := cmp.Diff(serverHeaders, result.Server.SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected server security headers (-want +got):\n%s", diff)
+	}
+	if len(result.Server.Locations) != 2 {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d locations but expected 2", len(result.Server.Locations))
+	}
+	if diff := cmp.Diff(serverHeaders, result.Server.Locations[0].SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for location /tea (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(routeHeaders, result.Server.Locations[1].SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for location /coffee (-want +got):\n%s", diff)
+	}
+	if len(result.Server.ErrorPageLocations) != 1 {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d error page locations but expected 1", len(result.Server.ErrorPageLocations))
+	}
+	if diff := cmp.Diff(serverHeaders, result.Server.ErrorPageLocations[0].SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for the error page location (-want +got):\n%s", diff)
+	}
+	if len(result.Server.ReturnLocations) != 1 {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d return locations but expected 1", len(result.Server.ReturnLocations))
+	}
+	if diff := cmp.Diff(routeHeaders, result.Server.ReturnLocations[0].SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for the return location (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+}
+
+func TestGenerateVirtualServerConfigWithSecurityHeadersPolicyAndAddHeaders(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Policies: []conf_v1.PolicyReference{
+					{
+						Name: "security-headers",
+					},
+				},
+				Headers: &conf_v1.Headers{
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "x-frame-options", Value: "SAMEORIGIN"}},
+						{Header: conf_v1.Header{Name: "X-Cafe", Value: "open"}},
+					},
+				},
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+					},
+					{
+						Path: "/coffee",
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{
+								Body: "coffee",
+							},
+						},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"default/security-headers": {
+				Spec: conf_v1.PolicySpec{
+					SecurityHeaders: &conf_v1.SecurityHeaders{
+						HSTS: &conf_v1.HSTS{
+							Enable: createPointerFromBool(false),
+						},
+						ContentTypeOptions:    createPointerFromString(""),
+						ContentSecurityPolicy: createPointerFromString(""),
+						PermissionsPolicy:     createPointerFromString(""),
+						ReferrerPolicy:        createPointerFromString(""),
+					},
+				},
+			},
+		},
+	}
+
+	securityHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "DENY"},
+	}
+	addHeaders := []version2.AddHeader{
+		{Header: version2.Header{Name: "X-Cafe", Value: "open"}},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil)
+
+	if diff := cmp.Diff(securityHeaders, result.Server.SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected server security headers (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(addHeaders, result.Server.AddHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected server add headers (-want +got):\n%s", diff)
+	}
+	for _, l := range result.Server.Locations {
+		if diff := cmp.Diff(securityHeaders, l.SecurityHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for location %s (-want +got):\n%s", l.Path, diff)
+		}
+		if diff := cmp.Diff(addHeaders, l.AddHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected add headers for location %s (-want +got):\n%s", l.Path, diff)
+		}
+	}
+	if len(result.Server.ReturnLocations) != 1 {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d return locations but expected 1", len(result.Server.ReturnLocations))
+	}
+	if diff := cmp.Diff(securityHeaders, result.Server.ReturnLocations[0].SecurityHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected security headers for the return location (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(addHeaders, result.Server.ReturnLocations[0].AddHeaders); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected add headers for the return location (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+}
+
+func TestGenerateVirtualServerConfigWithHeadersForResponsesGeneratedByNGINX(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Headers: &conf_v1.Headers{
+					Set: []conf_v1.Header{
+						{Name: "X-Server", Value: "cafe"},
+					},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{
+								Body: "tea",
+							},
+						},
+					},
+					{
+						Path: "/juice",
+						Action: &conf_v1.Action{
+							Redirect: &conf_v1.ActionRedirect{
+								URL: "http://juice.example.com",
+							},
+						},
+					},
+					{
+						Path:  "/coffee",
+						Route: "default/coffee",
+					},
+				},
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1.Upstream{
+						{
+							Name:    "coffee",
+							Service: "coffee-svc",
+							Port:    80,
+						},
+					},
+					Headers: &conf_v1.Headers{
+						Add: []conf_v1.AddHeader{
+							{Header: conf_v1.Header{Name: "x-frame-options", Value: "SAMEORIGIN"}, Always: true},
+							{Header: conf_v1.Header{Name: "X-Coffee", Value: "espresso"}},
+						},
+					},
+					Subroutes: []conf_v1.Route{
+						{
+							Path: "/coffee/mocha",
+							Action: &conf_v1.Action{
+								Redirect: &conf_v1.ActionRedirect{
+									URL: "http://mocha.example.com",
+								},
+							},
+						},
+						{
+							Path: "/coffee/latte",
+							Action: &conf_v1.Action{
+								Return: &conf_v1.ActionReturn{
+									Body: "latte",
+								},
+							},
+						},
+						{
+							Path: "/coffee",
+							Action: &conf_v1.Action{
+								Pass: "coffee",
+							},
+							ErrorPages: []conf_v1.ErrorPage{
+								{
+									Codes: []int{502},
+									Return: &conf_v1.ErrorPageReturn{
+										ActionReturn: conf_v1.ActionReturn{
+											Body: "Try again later",
+										},
+										Headers: []conf_v1.Header{
+											{Name: "x-coffee", Value: "none"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	baseCfgParams := ConfigParams{}
+
+	vsAddHeaders := []version2.AddHeader{
+		{Header: version2.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+	}
+	vsrAddHeaders := []version2.AddHeader{
+		{Header: version2.Header{Name: "x-frame-options", Value: "SAMEORIGIN"}, Always: true},
+		{Header: version2.Header{Name: "X-Coffee", Value: "espresso"}},
+	}
+
+	expectedLocationAddHeaders := map[string][]version2.AddHeader{
+		"/tea":          vsAddHeaders,
+		"/juice":        vsAddHeaders,
+		"/coffee/mocha": vsrAddHeaders,
+		"/coffee/latte": vsrAddHeaders,
+		"/coffee":       vsrAddHeaders,
+	}
+	expectedReturnLocations := []version2.ReturnLocation{
+		{
+			Name:        "@return_0",
+			DefaultType: "text/plain",
+			Return: version2.Return{
+				Text: "tea",
+			},
+			AddHeaders: vsAddHeaders,
+		},
+		{
+			Name:        "@return_1",
+			DefaultType: "text/plain",
+			Return: version2.Return{
+				Text: "latte",
+			},
+			AddHeaders: vsrAddHeaders,
+		},
+	}
+	expectedErrorPageLocations := []version2.ErrorPageLocation{
+		{
+			Name:        "@error_page_0_0",
+			DefaultType: "text/html",
+			Return: &version2.Return{
+				Code: 0,
+				Text: "Try again later",
+			},
+			Headers: []version2.Header{
+				{Name: "x-coffee", Value: "none"},
+			},
+			AddHeaders: []version2.AddHeader{
+				{Header: version2.Header{Name: "x-frame-options", Value: "SAMEORIGIN"}, Always: true},
+			},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&baseCfgParams, false, false, &StaticConfigParams{}, false)
+
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil)
+
+	if len(result.Server.Locations) != len(expectedLocationAddHeaders) {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d locations but expected %d", len(result.Server.Locations), len(expectedLocationAddHeaders))
+	}
+	for _, loc := range result.Server.Locations {
+		if diff := cmp.Diff(expectedLocationAddHeaders[loc.Path], loc.AddHeaders); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() returned unexpected response headers for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+	}
+	if diff := cmp.Diff(expectedReturnLocations, result.Server.ReturnLocations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected return locations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedErrorPageLocations, result.Server.ErrorPageLocations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected error page locations (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned warnings: %v", warnings)
+	}
+}
+
+func TestApplyHeadersToAction(t *testing.T) {
+	headers := &conf_v1.Headers{
+		Set:  []conf_v1.Header{{Name: "X-Server", Value: "cafe"}},
+		Add:  []conf_v1.AddHeader{{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}}},
+		Hide: []string{"X-Powered-By"},
+	}
+	pass := false
+
+	tests := []struct {
+		action   *conf_v1.Action
+		expected *conf_v1.Action
+		msg      string
+	}{
+		{
+			action: &conf_v1.Action{Pass: "tea"},
+			expected: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "tea",
+					RequestHeaders: &conf_v1.ProxyRequestHeaders{
+						Set: []conf_v1.Header{{Name: "X-Server", Value: "cafe"}},
+					},
+					ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+						Add:  []conf_v1.AddHeader{{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}}},
+						Hide: []string{"X-Powered-By"},
+					},
+				},
+			},
+			msg: "pass action",
+		},
+		{
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream:    "tea",
+					RewritePath: "/",
+					RequestHeaders: &conf_v1.ProxyRequestHeaders{
+						Pass: &pass,
+						Set:  []conf_v1.Header{{Name: "x-server", Value: "tea"}},
+					},
+					ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+						Add:  []conf_v1.AddHeader{{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}, Always: true}},
+						Pass: []string{"Server"},
+					},
+				},
+			},
+			expected: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream:    "tea",
+					RewritePath: "/",
+					RequestHeaders: &conf_v1.ProxyRequestHeaders{
+						Pass: &pass,
+						Set:  []conf_v1.Header{{Name: "x-server", Value: "tea"}},
+					},
+					ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+						Add:  []conf_v1.AddHeader{{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}, Always: true}},
+						Hide: []string{"X-Powered-By"},
+						Pass: []string{"Server"},
+					},
+				},
+			},
+			msg: "proxy action overrides the headers",
+		},
+		{
+			action:   &conf_v1.Action{Return: &conf_v1.ActionReturn{Body: "hello"}},
+			expected: &conf_v1.Action{Return: &conf_v1.ActionReturn{Body: "hello"}},
+			msg:      "return action",
+		},
+		{
+			action:   &conf_v1.Action{Redirect: &conf_v1.ActionRedirect{URL: "http://example.com"}},
+			expected: &conf_v1.Action{Redirect: &conf_v1.ActionRedirect{URL: "http://example.com"}},
+			msg:      "redirect action",
+		},
+	}
+
+	for _, test := range tests {
+		result := applyHeadersToAction(test.action, headers)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("applyHeadersToAction() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestMergeHeaders(t *testing.T) {
+	parent := &conf_v1.Headers{
+		Set: []conf_v1.Header{
+			{Name: "X-A", Value: "parent"},
+			{Name: "X-B", Value: "parent"},
+		},
+		Add: []conf_v1.AddHeader{
+			{Header: conf_v1.Header{Name: "X-C", Value: "parent"}},
+		},
+		Hide: []string{"X-D"},
+	}
+	child := &conf_v1.Headers{
+		Set: []conf_v1.Header{
+			{Name: "x-b", Value: "child"},
+		},
+		Add: []conf_v1.AddHeader{
+			{Header: conf_v1.Header{Name: "X-E", Value: "child"}},
+		},
+		Hide: []string{"x-d", "X-F"},
+	}
+	expected := &conf_v1.Headers{
+		Set: []conf_v1.Header{
+			{Name: "X-A", Value: "parent"},
+			{Name: "x-b", Value: "child"},
+		},
+		Add: []conf_v1.AddHeader{
+			{Header: conf_v1.Header{Name: "X-C", Value: "parent"}},
+			{Header: conf_v1.Header{Name: "X-E", Value: "child"}},
+		},
+		Hide: []string{"X-D", "X-F"},
+	}
+
+	result := mergeHeaders(parent, child)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("mergeHeaders() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	if mergeHeaders(nil, child) != child {
+		t.Errorf("mergeHeaders() didn't return the child headers for nil parent headers")
+	}
+	if mergeHeaders(parent, nil) != parent {
+		t.Errorf("mergeHeaders() didn't return the parent headers for nil child headers")
+	}
+}
+
+func TestGenerateVirtualServerConfigForVirtualServerWithSplits(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea-v1",
+						Service: "tea-svc-v1",
+						Port:    80,
+					},
+					{
+						Name:    "tea-v2",
+						Service: "tea-svc-v2",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Splits: []conf_v1.Split{
+							{
+								Weight: 90,
+								Action: &conf_v1.Action{
+									Pass: "tea-v1",
+								},
+							},
+							{
+								Weight: 10,
+								Action: &conf_v1.Action{
+									Pass: "tea-v2",
+								},
+							},
+						},
+					},
+					{
+						Path:  "/coffee",
+						Route: "default/coffee",
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc-v1:80": {
+				"10.0.0.20:80",
+			},
+			"default/tea-svc-v2:80": {
+				"10.0.0.21:80",
+			},
+			"default/coffee-svc-v1:80": {
+				"10.0.0.30:80",
+			},
+			"default/coffee-svc-v2:80": {
+				"10.0.0.31:80",
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1.Upstream{
+						{
+							Name:    "coffee-v1",
+							Service: "coffee-svc-v1",
+							Port:    80,
+						},
+						{
+							Name:    "coffee-v2",
+							Service: "coffee-svc-v2",
+							Port:    80,
+						},
+					},
+					Subroutes: []conf_v1.Route{
+						{
+							Path: "/coffee",
+							Splits: []conf_v1.Split{
+								{
+									Weight: 40,
+									Action: &conf_v1.Action{
+										Pass: "coffee-v1",
+									},
+								},
+								{
+									Weight: 60,
+									Action: &conf_v1.Action{
+										Pass: "coffee-v2",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	baseCfgParams := ConfigParams{}
+
+	expected := version2.VirtualServerConfig{
+		Upstreams: []version2.Upstream{
+			{
+				Name: "vs_default_cafe_tea-v1",
+				UpstreamLabels: version2.UpstreamLabels{
+					Service:           "tea-svc-v1",
+					ResourceType:      "virtualserver",
+					ResourceName:      "cafe",
+					ResourceNamespace: "default",
+				},
+				Servers: []version2.UpstreamServer{
+					{
+						Address: "10.0.0.20:80",
+					},
+				},
+			},
+			{
+				Name: "vs_default_cafe_tea-v2",
+				UpstreamLabels: version2.UpstreamLabels{
+					Service:           "tea-svc-v2",
+					ResourceType:      "virtualserver",
+					ResourceName:      "cafe",
+					ResourceNamespace: "default",
+				},
+				Servers: []version2.UpstreamServer{
+					{
+						Address: "10.0.0.21:80",
+					},
+				},
+			},
+			{
+				Name: "vs_default_cafe_vsr_default_coffee_coffee-v1",
+				UpstreamLabels: version2.UpstreamLabels{
+					Service:           "coffee-svc-v1",
+					ResourceType:      "virtualserverroute",
+					ResourceName:      "coffee",
+					ResourceNamespace: "default",
+				},
+				Servers: []version2.UpstreamServer{
+					{
+						Address: "10.0.0.30:80",
+					},
+				},
+			},
+			{
+				Name: "vs_default_cafe_vsr_default_coffee_coffee-v2",
+				UpstreamLabels: version2.UpstreamLabels{
+					Service:           "coffee-svc-v2",
+					ResourceType:      "virtualserverroute",
+					ResourceName:      "coffee",
+					ResourceNamespace: "default",
+				},
+				Servers: []version2.UpstreamServer{
+					{
+						Address: "10.0.0.31:80",
+					},
+				},
+			},
+		},
+		SplitClients: []version2.SplitClient{
+			{
+				Source:   "$request_id",
+				Variable: "$vs_default_cafe_splits_0",
+				Distributions: []version2.Distribution{
+					{
+						Weight: "90%",
+						Value:  "/internal_location_splits_0_split_0",
+					},
+					{
+						Weight: "10%",
+						Value:  "/internal_location_splits_0_split_1",
+					},
+				},
+			},
+			{
+				Source:   "$request_id",
+				Variable: "$vs_default_cafe_splits_1",
+				Distributions: []version2.Distribution{
+					{
+						Weight: "40%",
+						Value:  "/internal_location_splits_1_split_0",
+					},
+					{
+						Weight: "60%",
+						Value:  "/internal_location_splits_1_split_1",
+					},
+				},
+			},
+		},
+		HTTPSnippets:  []string{},
+		LimitReqZones: []version2.LimitReqZone{},
+		Server: version2.Server{
+			ServerName:  "cafe.example.com",
+			StatusZone:  "cafe.example.com",
+			VSNamespace: "default",
+			VSName:      "cafe",
+			InternalRedirectLocations: []version2.InternalRedirectLocation{
+				{
+					Path:        "/tea",
+					Destination: "$vs_default_cafe_splits_0",
+				},
+				{
+					Path:        "/coffee",
+					Destination: "$vs_default_cafe_splits_1",
+				},
+			},
+			Locations: []version2.Location{
+				{
+					Path:                     "/internal_location_splits_0_split_0",
+					ProxyPass:                "http://vs_default_cafe_tea-v1$request_uri",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "0s",
+					ProxyNextUpstreamTries:   0,
+					Internal:                 true,
+					ProxySSLName:             "tea-svc-v1.default.svc",
+					ProxyPassRequestHeaders:  true,
+					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+					ServiceName:              "tea-svc-v1",
+				},
+				{
+					Path:                     "/internal_location_splits_0_split_1",
+					ProxyPass:                "http://vs_default_cafe_tea-v2$request_uri",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "0s",
+					ProxyNextUpstreamTries:   0,
+					Internal:                 true,
+					ProxySSLName:             "tea-svc-v2.default.svc",
+					ProxyPassRequestHeaders:  true,
+					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+					ServiceName:              "tea-svc-v2",
+				},
+				{
+					Path:                     "/internal_location_splits_1_split_0",
+					ProxyPass:                "http://vs_default_cafe_vsr_default_coffee_coffee-v1$request_uri",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "0s",
+					ProxyNextUpstreamTries:   0,
+					Internal:                 true,
+					ProxySSLName:             "coffee-svc-v1.default.svc",
+					ProxyPassRequestHeaders:  true,
+					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+					ServiceName:              "coffee-svc-v1",
+					IsVSR:                    true,
+					VSRName:                  "coffee",
+					VSRNamespace:             "default",
+				},
+				{
+					Path:                     "/internal_location_splits_1_split_1",
+					ProxyPass:                "http://vs_default_cafe_vsr_default_coffee_coffee-v2$request_uri",
+					ProxyNextUpstream:        "error timeout",
+					ProxyNextUpstreamTimeout: "0s",
+					ProxyNextUpstreamTries:   0,
+					Internal:                 true,
+					ProxySSLName:             "coffee-svc-v2.default.svc",
+					ProxyPassRequestHeaders:  true,
+					ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+					ServiceName:              "coffee-svc-v2",
+					IsVSR:                    true,
+					VSRName:                  "coffee",
+					VSRNamespace:             "default",
+				},
+			},
+		},
+	}
+
+	isPlus := false
+	isResolverConfigured := false
+	isWildcardEnabled := false
+	vsc := newVirtualServerConfigurator(&baseCfgParams, isPlus, isResolverConfigured, &StaticConfigParams{}, isWildcardEnabled)
+
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig returned warnings: %v", vsc.warnings)
+	}
+}
+
+func TestGenerateVirtualServerConfigForVirtualServerWithMatches(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea-v1",
+						Service: "tea-svc-v1",
+						Port:    80,
+					},
+					{
+						Name:    "tea-v2",
+						Service: "tea-svc-v2",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Matches: []conf_v1.Match{
 							{
 								Conditions: []conf_v1.Condition{
 									{
@@ -1857,6 +2540,16 @@ func TestGeneratePolicies(t *testing.T) {
 				},
 				Path: ingressMTLSCertPath,
 			},
+			"default/ingress-mtls-crl-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeCA,
+					Data: map[string][]byte{
+						"ca.crt": nil,
+						"ca.crl": nil,
+					},
+				},
+				Path: "/etc/nginx/secrets/default-ingress-mtls-crl-secret",
+			},
 			"default/egress-mtls-secret": {
 				Secret: &api_v1.Secret{
 					Type: api_v1.SecretTypeTLS,
@@ -1883,6 +2576,15 @@ func TestGeneratePolicies(t *testing.T) {
 					},
 				},
 			},
+			"default/api-key-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeAPIKey,
+					Data: map[string][]byte{
+						"client2": []byte("2BB80D537B1DA3E38BD30361AA855686BDE0EACD7162FEF6A25FE97BF527A25B\n"),
+						"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+					},
+				},
+			},
 		},
 		apResources: map[string]string{
 			"default/logconf":         "/etc/nginx/waf/nac-logconfs/default-logconf",
@@ -1955,120 +2657,324 @@ func TestGeneratePolicies(t *testing.T) {
 							Allow: []string{"127.0.0.1"},
 						},
 					},
-				},
-				"default/allow-policy-2": {
+				},
+				"default/allow-policy-2": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"127.0.0.2"},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Allow: []string{"127.0.0.1", "127.0.0.2"},
+			},
+			msg: "merging",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "rateLimit-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/rateLimit-policy": {
+					Spec: conf_v1.PolicySpec{
+						RateLimit: &conf_v1.RateLimit{
+							Key:      "test",
+							ZoneSize: "10M",
+							Rate:     "10r/s",
+							LogLevel: "notice",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				LimitReqZones: []version2.LimitReqZone{
+					{
+						Key:      "test",
+						ZoneSize: "10M",
+						Rate:     "10r/s",
+						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
+					},
+				},
+				LimitReqOptions: version2.LimitReqOptions{
+					LogLevel:   "notice",
+					RejectCode: 503,
+				},
+				LimitReqs: []version2.LimitReq{
+					{
+						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
+					},
+				},
+			},
+			msg: "rate limit reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "rateLimit-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "rateLimit-policy2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/rateLimit-policy": {
+					Spec: conf_v1.PolicySpec{
+						RateLimit: &conf_v1.RateLimit{
+							Key:      "test",
+							ZoneSize: "10M",
+							Rate:     "10r/s",
+						},
+					},
+				},
+				"default/rateLimit-policy2": {
+					Spec: conf_v1.PolicySpec{
+						RateLimit: &conf_v1.RateLimit{
+							Key:      "test2",
+							ZoneSize: "20M",
+							Rate:     "20r/s",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				LimitReqZones: []version2.LimitReqZone{
+					{
+						Key:      "test",
+						ZoneSize: "10M",
+						Rate:     "10r/s",
+						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
+					},
+					{
+						Key:      "test2",
+						ZoneSize: "20M",
+						Rate:     "20r/s",
+						ZoneName: "pol_rl_default_rateLimit-policy2_default_test",
+					},
+				},
+				LimitReqOptions: version2.LimitReqOptions{
+					LogLevel:   "error",
+					RejectCode: 503,
+				},
+				LimitReqs: []version2.LimitReq{
+					{
+						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
+					},
+					{
+						ZoneName: "pol_rl_default_rateLimit-policy2_default_test",
+					},
+				},
+			},
+			msg: "multi rate limit reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "tiered-rate-limit-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/tiered-rate-limit-policy": {
+					Spec: conf_v1.PolicySpec{
+						RateLimit: &conf_v1.RateLimit{
+							Key:          "${jwt_claim_sub}",
+							ZoneSize:     "10M",
+							Burst:        intPointer(5),
+							TierSelector: "${jwt_claim_plan}",
+							Tiers: []conf_v1.RateLimitTier{
+								{
+									Name:   "premium",
+									Values: []string{"premium"},
+									Rate:   "100r/s",
+									Burst:  intPointer(50),
+								},
+								{
+									Name:    "free",
+									Default: true,
+									Rate:    "10r/s",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Maps: []version2.Map{
+					{
+						Source:   "${jwt_claim_plan}",
+						Variable: "$pol_rl_default_tiered_rate_limit_policy_default_test_premium_f0d93b38",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"premium"`,
+								Result: `"${jwt_claim_sub}"`,
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+					{
+						Source:   "${jwt_claim_plan}",
+						Variable: "$pol_rl_default_tiered_rate_limit_policy_default_test_free_0f70e52d",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"premium"`,
+								Result: `""`,
+							},
+							{
+								Value:  "default",
+								Result: `"${jwt_claim_sub}"`,
+							},
+						},
+					},
+				},
+				LimitReqZones: []version2.LimitReqZone{
+					{
+						Key:      "$pol_rl_default_tiered_rate_limit_policy_default_test_premium_f0d93b38",
+						ZoneSize: "10M",
+						Rate:     "100r/s",
+						ZoneName: "pol_rl_default_tiered-rate-limit-policy_default_test_premium",
+					},
+					{
+						Key:      "$pol_rl_default_tiered_rate_limit_policy_default_test_free_0f70e52d",
+						ZoneSize: "10M",
+						Rate:     "10r/s",
+						ZoneName: "pol_rl_default_tiered-rate-limit-policy_default_test_free",
+					},
+				},
+				LimitReqOptions: version2.LimitReqOptions{
+					LogLevel:   "error",
+					RejectCode: 503,
+				},
+				LimitReqs: []version2.LimitReq{
+					{
+						ZoneName: "pol_rl_default_tiered-rate-limit-policy_default_test_premium",
+						Burst:    50,
+					},
+					{
+						ZoneName: "pol_rl_default_tiered-rate-limit-policy_default_test_free",
+						Burst:    5,
+					},
+				},
+			},
+			msg: "tiered rate limit reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "jwt-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/jwt-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy",
+						Namespace: "default",
+					},
 					Spec: conf_v1.PolicySpec{
-						AccessControl: &conf_v1.AccessControl{
-							Allow: []string{"127.0.0.2"},
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:  "My Test API",
+							Secret: "jwt-secret",
 						},
 					},
 				},
 			},
 			expected: policiesCfg{
-				Allow: []string{"127.0.0.1", "127.0.0.2"},
+				JWTAuth: &version2.JWTAuth{
+					Secret: "/etc/nginx/secrets/default-jwt-secret",
+					Realm:  "My Test API",
+				},
 			},
-			msg: "merging",
+			msg: "jwt reference",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "rateLimit-policy",
+					Name:      "jwt-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/rateLimit-policy": {
+				"default/jwt-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy",
+						Namespace: "default",
+					},
 					Spec: conf_v1.PolicySpec{
-						RateLimit: &conf_v1.RateLimit{
-							Key:      "test",
-							ZoneSize: "10M",
-							Rate:     "10r/s",
-							LogLevel: "notice",
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:      "My Test API",
+							JwksURI:    "https://idp.example.com/keys",
+							KeyCache:   "1h",
+							SNIEnabled: true,
+							SNIName:    "idp.example.com",
 						},
 					},
 				},
 			},
 			expected: policiesCfg{
-				LimitReqZones: []version2.LimitReqZone{
-					{
-						Key:      "test",
-						ZoneSize: "10M",
-						Rate:     "10r/s",
-						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
-					},
-				},
-				LimitReqOptions: version2.LimitReqOptions{
-					LogLevel:   "notice",
-					RejectCode: 503,
-				},
-				LimitReqs: []version2.LimitReq{
-					{
-						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
+				JWTAuth: &version2.JWTAuth{
+					Realm: "My Test API",
+					JwksURI: &version2.JwksURI{
+						Location:    "/_pol_jwks_default_jwt-policy_default_test",
+						URI:         "https://idp.example.com/keys",
+						CacheZone:   "pol_jwks_default_jwt-policy_default_test",
+						KeyCache:    "1h",
+						SNIEnabled:  true,
+						SNIName:     "idp.example.com",
+						SSLVerify:   true,
+						TrustedCert: "/etc/ssl/certs/ca-certificates.crt",
+						SSLName:     "idp.example.com",
 					},
 				},
 			},
-			msg: "rate limit reference",
+			msg: "jwt reference with jwksURI",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "rateLimit-policy",
-					Namespace: "default",
-				},
-				{
-					Name:      "rateLimit-policy2",
+					Name:      "jwt-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/rateLimit-policy": {
-					Spec: conf_v1.PolicySpec{
-						RateLimit: &conf_v1.RateLimit{
-							Key:      "test",
-							ZoneSize: "10M",
-							Rate:     "10r/s",
-						},
+				"default/jwt-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy",
+						Namespace: "default",
 					},
-				},
-				"default/rateLimit-policy2": {
 					Spec: conf_v1.PolicySpec{
-						RateLimit: &conf_v1.RateLimit{
-							Key:      "test2",
-							ZoneSize: "20M",
-							Rate:     "20r/s",
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:             "My Test API",
+							JwksURI:           "https://idp.example.com:8443/keys",
+							KeyCache:          "1h",
+							TrustedCertSecret: "egress-trusted-ca-secret",
 						},
 					},
 				},
 			},
 			expected: policiesCfg{
-				LimitReqZones: []version2.LimitReqZone{
-					{
-						Key:      "test",
-						ZoneSize: "10M",
-						Rate:     "10r/s",
-						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
-					},
-					{
-						Key:      "test2",
-						ZoneSize: "20M",
-						Rate:     "20r/s",
-						ZoneName: "pol_rl_default_rateLimit-policy2_default_test",
-					},
-				},
-				LimitReqOptions: version2.LimitReqOptions{
-					LogLevel:   "error",
-					RejectCode: 503,
-				},
-				LimitReqs: []version2.LimitReq{
-					{
-						ZoneName: "pol_rl_default_rateLimit-policy_default_test",
-					},
-					{
-						ZoneName: "pol_rl_default_rateLimit-policy2_default_test",
+				JWTAuth: &version2.JWTAuth{
+					Realm: "My Test API",
+					JwksURI: &version2.JwksURI{
+						Location:    "/_pol_jwks_default_jwt-policy_default_test",
+						URI:         "https://idp.example.com:8443/keys",
+						CacheZone:   "pol_jwks_default_jwt-policy_default_test",
+						KeyCache:    "1h",
+						SSLVerify:   true,
+						TrustedCert: "/etc/nginx/secrets/default-egress-trusted-ca-secret",
+						SSLName:     "idp.example.com",
 					},
 				},
 			},
-			msg: "multi rate limit reference",
+			msg: "jwt reference with jwksURI and trusted CA secret",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
@@ -2087,6 +2993,18 @@ func TestGeneratePolicies(t *testing.T) {
 						JWTAuth: &conf_v1.JWTAuth{
 							Realm:  "My Test API",
 							Secret: "jwt-secret",
+							Claims: []conf_v1.JWTClaimRequirement{
+								{
+									Name:     "aud",
+									Operator: "Equals",
+									Values:   []string{"my-client"},
+								},
+								{
+									Name:     "groups",
+									Operator: "Contains",
+									Values:   []string{"admin", "ops.team"},
+								},
+							},
 						},
 					},
 				},
@@ -2095,9 +3013,43 @@ func TestGeneratePolicies(t *testing.T) {
 				JWTAuth: &version2.JWTAuth{
 					Secret: "/etc/nginx/secrets/default-jwt-secret",
 					Realm:  "My Test API",
+					Require: []string{
+						"$pol_jwt_default_jwt_policy_default_test_7c992fe4_claim_0",
+						"$pol_jwt_default_jwt_policy_default_test_7c992fe4_claim_1",
+					},
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$jwt_claim_aud",
+						Variable: "$pol_jwt_default_jwt_policy_default_test_7c992fe4_claim_0",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"~^(my-client)$"`,
+								Result: "1",
+							},
+							{
+								Value:  "default",
+								Result: "0",
+							},
+						},
+					},
+					{
+						Source:   "$jwt_claim_groups",
+						Variable: "$pol_jwt_default_jwt_policy_default_test_7c992fe4_claim_1",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"~(^|,)(admin|ops\.team)(,|$)"`,
+								Result: "1",
+							},
+							{
+								Value:  "default",
+								Result: "0",
+							},
+						},
+					},
 				},
 			},
-			msg: "jwt reference",
+			msg: "jwt reference with claim requirements",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
@@ -2130,6 +3082,51 @@ func TestGeneratePolicies(t *testing.T) {
 			},
 			msg: "ingressMTLS reference",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "ingress-mtls-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/ingress-mtls-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "ingress-mtls-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						IngressMTLS: &conf_v1.IngressMTLS{
+							ClientCertSecret: "ingress-mtls-crl-secret",
+							ClientCertHeaders: &conf_v1.ClientCertHeaders{
+								SubjectDN: "X-SSL-Client-Subject-DN",
+								Cert:      "X-SSL-Client-Cert",
+							},
+						},
+					},
+				},
+			},
+			context: "spec",
+			expected: policiesCfg{
+				IngressMTLS: &version2.IngressMTLS{
+					ClientCert:   "/etc/nginx/secrets/default-ingress-mtls-crl-secret",
+					ClientCRL:    "/etc/nginx/secrets/default-ingress-mtls-crl-secret_ca.crl",
+					VerifyClient: "on",
+					VerifyDepth:  1,
+					ClientCertHeaders: []version2.Header{
+						{
+							Name:  "X-SSL-Client-Subject-DN",
+							Value: "$ssl_client_s_dn",
+						},
+						{
+							Name:  "X-SSL-Client-Cert",
+							Value: "$ssl_client_escaped_cert",
+						},
+					},
+				},
+			},
+			msg: "ingressMTLS reference with CRL and client cert headers",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -2193,9 +3190,211 @@ func TestGeneratePolicies(t *testing.T) {
 				},
 			},
 			expected: policiesCfg{
-				OIDC: true,
+				OIDC: &version2.OIDC{
+					ID:                    "default_oidc_policy_default_test_b7b44172",
+					AuthEndpoint:          "http://example.com/auth",
+					TokenEndpoint:         "http://example.com/token",
+					JwksURI:               "http://example.com/jwks",
+					ClientID:              "client-id",
+					ClientSecret:          "super_secret_123",
+					Scope:                 "scope",
+					RedirectURI:           "/redirect",
+					PostLogoutRedirectURI: "/_logout",
+					LogoutURI:             "/logout",
+					CookieName:            "auth_token",
+					CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+				},
+			},
+			msg: "oidc reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "api-key-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/api-key-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "api-key-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						APIKey: &conf_v1.APIKey{
+							SuppliedIn: &conf_v1.SuppliedIn{
+								Header: []string{"X-API-Key"},
+								Query:  []string{"api_key"},
+							},
+							ClientSecret: "api-key-secret",
+							RejectCode:   intPointer(401),
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Maps: []version2.Map{
+					{
+						Source:   "${http_x_api_key}",
+						Variable: "$apikey_auth_token_default_api_key_policy_default_test_d681496b_0",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `""`,
+								Result: "${arg_api_key}",
+							},
+							{
+								Value:  "default",
+								Result: "${http_x_api_key}",
+							},
+						},
+					},
+					{
+						Source:   "$apikey_auth_hash",
+						Variable: "$apikey_auth_client_default_api_key_policy_default_test_d681496b",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"`,
+								Result: `"client1"`,
+							},
+							{
+								Value:  `"2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"`,
+								Result: `"client2"`,
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+				},
+				APIKey: &version2.APIKey{
+					Token:      "$apikey_auth_token_default_api_key_policy_default_test_d681496b_0",
+					ClientMap:  "$apikey_auth_client_default_api_key_policy_default_test_d681496b",
+					RejectCode: 401,
+				},
+			},
+			msg: "apiKey reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "security-headers-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/security-headers-policy": {
+					Spec: conf_v1.PolicySpec{
+						SecurityHeaders: &conf_v1.SecurityHeaders{},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Maps: []version2.Map{
+					{
+						Source:   "$https",
+						Variable: "$security_headers_hsts_default_security_headers_policy_default_test_98beb41e",
+						Parameters: []version2.Parameter{
+							{
+								Value:  "on",
+								Result: `"max-age=31536000; includeSubDomains; preload"`,
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+				},
+				SecurityHeaders: []version2.Header{
+					{Name: "Strict-Transport-Security", Value: "$security_headers_hsts_default_security_headers_policy_default_test_98beb41e"},
+					{Name: "X-Frame-Options", Value: "DENY"},
+					{Name: "X-Content-Type-Options", Value: "nosniff"},
+					{Name: "Referrer-Policy", Value: "strict-origin-when-cross-origin"},
+					{Name: "Content-Security-Policy", Value: "default-src 'self'"},
+					{Name: "Permissions-Policy", Value: "camera=(), geolocation=(), microphone=()"},
+				},
+			},
+			msg: "securityHeaders reference with defaults",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "security-headers-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/security-headers-policy": {
+					Spec: conf_v1.PolicySpec{
+						SecurityHeaders: &conf_v1.SecurityHeaders{
+							HSTS: &conf_v1.HSTS{
+								MaxAge:            intPointer(86400),
+								IncludeSubdomains: createPointerFromBool(false),
+								Preload:           createPointerFromBool(false),
+								BehindProxy:       true,
+							},
+							FrameOptions:          createPointerFromString("SAMEORIGIN"),
+							ContentTypeOptions:    createPointerFromString(""),
+							ContentSecurityPolicy: createPointerFromString("default-src 'self'; img-src *"),
+							PermissionsPolicy:     createPointerFromString(""),
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				Maps: []version2.Map{
+					{
+						Source:   "$http_x_forwarded_proto",
+						Variable: "$security_headers_hsts_default_security_headers_policy_default_test_98beb41e",
+						Parameters: []version2.Parameter{
+							{
+								Value:  "https",
+								Result: `"max-age=86400"`,
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+				},
+				SecurityHeaders: []version2.Header{
+					{Name: "Strict-Transport-Security", Value: "$security_headers_hsts_default_security_headers_policy_default_test_98beb41e"},
+					{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+					{Name: "Referrer-Policy", Value: "strict-origin-when-cross-origin"},
+					{Name: "Content-Security-Policy", Value: "default-src 'self'; img-src *"},
+				},
+			},
+			msg: "securityHeaders reference with overrides",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "security-headers-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/security-headers-policy": {
+					Spec: conf_v1.PolicySpec{
+						SecurityHeaders: &conf_v1.SecurityHeaders{
+							HSTS: &conf_v1.HSTS{
+								Enable: createPointerFromBool(false),
+							},
+							FrameOptions:          createPointerFromString(""),
+							ContentTypeOptions:    createPointerFromString(""),
+							ReferrerPolicy:        createPointerFromString(""),
+							ContentSecurityPolicy: createPointerFromString(""),
+							PermissionsPolicy:     createPointerFromString(""),
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				SecurityHeaders: []version2.Header{},
 			},
-			msg: "oidc reference",
+			msg: "securityHeaders reference with all headers omitted",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
@@ -2944,6 +4143,52 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			expectedOidc: &oidcPolicyCfg{},
 			msg:          "egress mtls referencing wrong secret type",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "jwt-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/jwt-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:             "My Test API",
+							JwksURI:           "https://idp.example.com/keys",
+							KeyCache:          "1h",
+							TrustedCertSecret: "jwks-trusted-secret",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/jwks-trusted-secret": {
+						Secret: &api_v1.Secret{
+							Type: api_v1.SecretTypeTLS,
+						},
+					},
+				},
+			},
+			context: "route",
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`JWT policy default/jwt-policy references a secret default/jwks-trusted-secret of a wrong type 'kubernetes.io/tls', must be 'nginx.org/ca'`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "jwt with jwksURI referencing wrong trusted CA secret type",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -3146,47 +4391,304 @@ func TestGeneratePoliciesFails(t *testing.T) {
 						},
 					},
 				},
-				"default/oidc-policy-2": {
+				"default/oidc-policy-2": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "oidc-policy-2",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						OIDC: &conf_v1.OIDC{
+							ClientID:      "foo",
+							ClientSecret:  "oidc-secret",
+							AuthEndpoint:  "https://bar.com/auth",
+							TokenEndpoint: "https://bar.com/token",
+							JWKSURI:       "https://bar.com/certs",
+							RedirectURI:   "/_codexch",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/oidc-secret": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeOIDC,
+							Data: map[string][]byte{
+								"client-secret": []byte("super_secret_123"),
+							},
+						},
+					},
+				},
+			},
+			context: "route",
+			oidcPolCfg: &oidcPolicyCfg{
+				providers: []*version2.OIDC{
+					{
+						ID:          "default_oidc_policy_1_default_test_b6a575fc",
+						RedirectURI: "/_codexch",
+						LogoutURI:   "/logout",
+						CookieName:  "auth_token",
+					},
+				},
+				keys: []string{"default/oidc-policy-1"},
+			},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`OIDC policy default/oidc-policy-2 uses the same redirect URI /_codexch as OIDC policy default/oidc-policy-1`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{
+				providers: []*version2.OIDC{
+					{
+						ID:          "default_oidc_policy_1_default_test_b6a575fc",
+						RedirectURI: "/_codexch",
+						LogoutURI:   "/logout",
+						CookieName:  "auth_token",
+					},
+				},
+				keys: []string{"default/oidc-policy-1"},
+			},
+			msg: "multiple oidc policies with the same redirect URI",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "oidc-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "oidc-policy2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/oidc-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "oidc-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						OIDC: &conf_v1.OIDC{
+							ClientSecret:  "oidc-secret",
+							AuthEndpoint:  "https://foo.com/auth",
+							TokenEndpoint: "https://foo.com/token",
+							JWKSURI:       "https://foo.com/certs",
+							ClientID:      "foo",
+						},
+					},
+				},
+				"default/oidc-policy2": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "oidc-policy2",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						OIDC: &conf_v1.OIDC{
+							ClientSecret:  "oidc-secret",
+							AuthEndpoint:  "https://bar.com/auth",
+							TokenEndpoint: "https://bar.com/token",
+							JWKSURI:       "https://bar.com/certs",
+							ClientID:      "bar",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/oidc-secret": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeOIDC,
+							Data: map[string][]byte{
+								"client-secret": []byte("super_secret_123"),
+							},
+						},
+					},
+				},
+			},
+			context: "route",
+			expected: policiesCfg{
+				OIDC: &version2.OIDC{
+					ID:                    "default_oidc_policy_default_test_b7b44172",
+					AuthEndpoint:          "https://foo.com/auth",
+					TokenEndpoint:         "https://foo.com/token",
+					JwksURI:               "https://foo.com/certs",
+					ClientID:              "foo",
+					ClientSecret:          "super_secret_123",
+					RedirectURI:           "/_codexch",
+					Scope:                 "openid",
+					PostLogoutRedirectURI: "/_logout",
+					LogoutURI:             "/logout",
+					CookieName:            "auth_token",
+					CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`Multiple oidc policies in the same context is not valid. OIDC policy default/oidc-policy2 will be ignored`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{
+				providers: []*version2.OIDC{
+					{
+						ID:                    "default_oidc_policy_default_test_b7b44172",
+						AuthEndpoint:          "https://foo.com/auth",
+						TokenEndpoint:         "https://foo.com/token",
+						JwksURI:               "https://foo.com/certs",
+						ClientID:              "foo",
+						ClientSecret:          "super_secret_123",
+						RedirectURI:           "/_codexch",
+						Scope:                 "openid",
+						PostLogoutRedirectURI: "/_logout",
+						LogoutURI:             "/logout",
+						CookieName:            "auth_token",
+						CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+					},
+				},
+				keys: []string{"default/oidc-policy"},
+			},
+			msg: "multi oidc",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "waf-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "waf-policy2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/waf-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "waf-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						WAF: &conf_v1.WAF{
+							Enable:   true,
+							ApPolicy: "default/dataguard-alarm",
+						},
+					},
+				},
+				"default/waf-policy2": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "waf-policy2",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						WAF: &conf_v1.WAF{
+							Enable:   true,
+							ApPolicy: "default/dataguard-alarm",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				apResources: map[string]string{
+					"default/logconf":         "/etc/nginx/waf/nac-logconfs/default-logconf",
+					"default/dataguard-alarm": "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
+				},
+			},
+			context: "route",
+			expected: policiesCfg{
+				WAF: &version2.WAF{
+					Enable:   "on",
+					ApPolicy: "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`Multiple WAF policies in the same context is not valid. WAF policy default/waf-policy2 will be ignored`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "multi waf",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "api-key-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/api-key-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "api-key-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						APIKey: &conf_v1.APIKey{
+							SuppliedIn: &conf_v1.SuppliedIn{
+								Header: []string{"X-API-Key"},
+							},
+							ClientSecret: "api-key-secret",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/api-key-secret": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeAPIKey,
+						},
+						Error: errors.New("secret is invalid"),
+					},
+				},
+			},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`API key policy default/api-key-policy references an invalid secret default/api-key-secret: secret is invalid`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "apiKey referencing an invalid secret",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "api-key-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/api-key-policy": {
 					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "oidc-policy-2",
+						Name:      "api-key-policy",
 						Namespace: "default",
 					},
 					Spec: conf_v1.PolicySpec{
-						OIDC: &conf_v1.OIDC{
-							ClientID:      "foo",
-							ClientSecret:  "oidc-secret",
-							AuthEndpoint:  "https://bar.com/auth",
-							TokenEndpoint: "https://bar.com/token",
-							JWKSURI:       "https://bar.com/certs",
+						APIKey: &conf_v1.APIKey{
+							SuppliedIn: &conf_v1.SuppliedIn{
+								Header: []string{"X-API-Key"},
+							},
+							ClientSecret: "api-key-secret",
 						},
 					},
 				},
 			},
 			policyOpts: policyOptions{
 				secretRefs: map[string]*secrets.SecretReference{
-					"default/oidc-secret": {
+					"default/api-key-secret": {
 						Secret: &api_v1.Secret{
 							Type: secrets.SecretTypeOIDC,
-							Data: map[string][]byte{
-								"client-secret": []byte("super_secret_123"),
-							},
 						},
 					},
 				},
 			},
-			context: "route",
-			oidcPolCfg: &oidcPolicyCfg{
-				oidc: &version2.OIDC{
-					AuthEndpoint:  "https://foo.com/auth",
-					TokenEndpoint: "https://foo.com/token",
-					JwksURI:       "https://foo.com/certs",
-					ClientID:      "foo",
-					ClientSecret:  "super_secret_123",
-					RedirectURI:   "/_codexch",
-					Scope:         "openid",
-				},
-				key: "default/oidc-policy-1",
-			},
 			expected: policiesCfg{
 				ErrorReturn: &version2.Return{
 					Code: 500,
@@ -3194,158 +4696,148 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			},
 			expectedWarnings: Warnings{
 				nil: {
-					`Only one oidc policy is allowed in a VirtualServer and its VirtualServerRoutes. Can't use default/oidc-policy-2. Use default/oidc-policy-1`,
-				},
-			},
-			expectedOidc: &oidcPolicyCfg{
-				oidc: &version2.OIDC{
-					AuthEndpoint:  "https://foo.com/auth",
-					TokenEndpoint: "https://foo.com/token",
-					JwksURI:       "https://foo.com/certs",
-					ClientID:      "foo",
-					ClientSecret:  "super_secret_123",
-					RedirectURI:   "/_codexch",
-					Scope:         "openid",
+					`API key policy default/api-key-policy references a secret default/api-key-secret of a wrong type 'nginx.org/oidc', must be 'nginx.org/apikey'`,
 				},
-				key: "default/oidc-policy-1",
 			},
-			msg: "multiple oidc policies",
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "apiKey referencing wrong secret type",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "oidc-policy",
+					Name:      "api-key-policy",
 					Namespace: "default",
 				},
 				{
-					Name:      "oidc-policy2",
+					Name:      "api-key-policy2",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/oidc-policy": {
+				"default/api-key-policy": {
 					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "oidc-policy",
+						Name:      "api-key-policy",
 						Namespace: "default",
 					},
 					Spec: conf_v1.PolicySpec{
-						OIDC: &conf_v1.OIDC{
-							ClientSecret:  "oidc-secret",
-							AuthEndpoint:  "https://foo.com/auth",
-							TokenEndpoint: "https://foo.com/token",
-							JWKSURI:       "https://foo.com/certs",
-							ClientID:      "foo",
+						APIKey: &conf_v1.APIKey{
+							SuppliedIn: &conf_v1.SuppliedIn{
+								Header: []string{"X-API-Key"},
+							},
+							ClientSecret: "api-key-secret",
 						},
 					},
 				},
-				"default/oidc-policy2": {
+				"default/api-key-policy2": {
 					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "oidc-policy2",
+						Name:      "api-key-policy2",
 						Namespace: "default",
 					},
 					Spec: conf_v1.PolicySpec{
-						OIDC: &conf_v1.OIDC{
-							ClientSecret:  "oidc-secret",
-							AuthEndpoint:  "https://bar.com/auth",
-							TokenEndpoint: "https://bar.com/token",
-							JWKSURI:       "https://bar.com/certs",
-							ClientID:      "bar",
+						APIKey: &conf_v1.APIKey{
+							SuppliedIn: &conf_v1.SuppliedIn{
+								Header: []string{"X-API-Key"},
+							},
+							ClientSecret: "api-key-secret2",
 						},
 					},
 				},
 			},
 			policyOpts: policyOptions{
 				secretRefs: map[string]*secrets.SecretReference{
-					"default/oidc-secret": {
+					"default/api-key-secret": {
 						Secret: &api_v1.Secret{
-							Type: secrets.SecretTypeOIDC,
+							Type: secrets.SecretTypeAPIKey,
 							Data: map[string][]byte{
-								"client-secret": []byte("super_secret_123"),
+								"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+							},
+						},
+					},
+					"default/api-key-secret2": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeAPIKey,
+							Data: map[string][]byte{
+								"client2": []byte("2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"),
 							},
 						},
 					},
 				},
 			},
-			context: "route",
 			expected: policiesCfg{
-				OIDC: true,
+				Maps: []version2.Map{
+					{
+						Source:   "$apikey_auth_hash",
+						Variable: "$apikey_auth_client_default_api_key_policy_default_test_d681496b",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"`,
+								Result: `"client1"`,
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+				},
+				APIKey: &version2.APIKey{
+					Token:      "${http_x_api_key}",
+					ClientMap:  "$apikey_auth_client_default_api_key_policy_default_test_d681496b",
+					RejectCode: 403,
+				},
 			},
 			expectedWarnings: Warnings{
 				nil: {
-					`Multiple oidc policies in the same context is not valid. OIDC policy default/oidc-policy2 will be ignored`,
-				},
-			},
-			expectedOidc: &oidcPolicyCfg{
-				&version2.OIDC{
-					AuthEndpoint:  "https://foo.com/auth",
-					TokenEndpoint: "https://foo.com/token",
-					JwksURI:       "https://foo.com/certs",
-					ClientID:      "foo",
-					ClientSecret:  "super_secret_123",
-					RedirectURI:   "/_codexch",
-					Scope:         "openid",
+					`Multiple apiKey policies in the same context is not valid. API key policy default/api-key-policy2 will be ignored`,
 				},
-				"default/oidc-policy",
 			},
-			msg: "multi oidc",
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "multi apiKey",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "waf-policy",
+					Name:      "security-headers-policy",
 					Namespace: "default",
 				},
 				{
-					Name:      "waf-policy2",
+					Name:      "security-headers-policy2",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/waf-policy": {
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "waf-policy",
-						Namespace: "default",
-					},
+				"default/security-headers-policy": {
 					Spec: conf_v1.PolicySpec{
-						WAF: &conf_v1.WAF{
-							Enable:   true,
-							ApPolicy: "default/dataguard-alarm",
+						SecurityHeaders: &conf_v1.SecurityHeaders{
+							HSTS: &conf_v1.HSTS{
+								Enable: createPointerFromBool(false),
+							},
+							ContentSecurityPolicy: createPointerFromString(""),
+							PermissionsPolicy:     createPointerFromString(""),
 						},
 					},
 				},
-				"default/waf-policy2": {
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "waf-policy2",
-						Namespace: "default",
-					},
+				"default/security-headers-policy2": {
 					Spec: conf_v1.PolicySpec{
-						WAF: &conf_v1.WAF{
-							Enable:   true,
-							ApPolicy: "default/dataguard-alarm",
-						},
+						SecurityHeaders: &conf_v1.SecurityHeaders{},
 					},
 				},
 			},
-			policyOpts: policyOptions{
-				apResources: map[string]string{
-					"default/logconf":         "/etc/nginx/waf/nac-logconfs/default-logconf",
-					"default/dataguard-alarm": "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
-				},
-			},
-			context: "route",
+			policyOpts: policyOptions{},
 			expected: policiesCfg{
-				WAF: &version2.WAF{
-					Enable:   "on",
-					ApPolicy: "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
+				SecurityHeaders: []version2.Header{
+					{Name: "X-Frame-Options", Value: "DENY"},
+					{Name: "X-Content-Type-Options", Value: "nosniff"},
+					{Name: "Referrer-Policy", Value: "strict-origin-when-cross-origin"},
 				},
 			},
 			expectedWarnings: Warnings{
 				nil: {
-					`Multiple WAF policies in the same context is not valid. WAF policy default/waf-policy2 will be ignored`,
+					`Multiple securityHeaders policies in the same context is not valid. SecurityHeaders policy default/security-headers-policy2 will be ignored`,
 				},
 			},
 			expectedOidc: &oidcPolicyCfg{},
-			msg:          "multi waf",
+			msg:          "multi securityHeaders",
 		},
 	}
 
@@ -3368,15 +4860,385 @@ func TestGeneratePoliciesFails(t *testing.T) {
 				test.msg,
 			)
 		}
-		if diff := cmp.Diff(test.expectedOidc.oidc, vsc.oidcPolCfg.oidc); diff != "" {
+		if diff := cmp.Diff(test.expectedOidc.providers, vsc.oidcPolCfg.providers); diff != "" {
 			t.Errorf("generatePolicies() '%v' mismatch (-want +got):\n%s", test.msg, diff)
 		}
-		if diff := cmp.Diff(test.expectedOidc.key, vsc.oidcPolCfg.key); diff != "" {
+		if diff := cmp.Diff(test.expectedOidc.keys, vsc.oidcPolCfg.keys); diff != "" {
 			t.Errorf("generatePolicies() '%v' mismatch (-want +got):\n%s", test.msg, diff)
 		}
 	}
 }
 
+func TestGeneratePoliciesPolicyWarnings(t *testing.T) {
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	ownerDetails := policyOwnerDetails{
+		owner:          vs,
+		ownerNamespace: "default",
+		vsNamespace:    "default",
+		vsName:         "cafe",
+	}
+
+	policyRefs := []conf_v1.PolicyReference{
+		{
+			Name: "headers",
+		},
+		{
+			Name: "headers-2",
+		},
+		{
+			Name: "headers-3",
+		},
+	}
+	policies := map[string]*conf_v1.Policy{
+		"default/headers": {
+			Spec: conf_v1.PolicySpec{
+				SecurityHeaders: &conf_v1.SecurityHeaders{
+					FrameOptions: createPointerFromString("DENY"),
+				},
+			},
+		},
+		"default/headers-2": {
+			Spec: conf_v1.PolicySpec{
+				SecurityHeaders: &conf_v1.SecurityHeaders{
+					FrameOptions: createPointerFromString("SAMEORIGIN"),
+				},
+			},
+		},
+	}
+
+	// the warnings are attributed to the keys of the Policies rather than found by the keys in the messages,
+	// so the warnings of default/headers-2 and default/headers-3 are not the warnings of default/headers
+	expected := PolicyWarnings{
+		vs: {
+			"default/headers-2": {"Multiple securityHeaders policies in the same context is not valid. SecurityHeaders policy default/headers-2 will be ignored"},
+			"default/headers-3": {"Policy default/headers-3 is missing or invalid"},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+	vsc.generatePolicies(ownerDetails, policyRefs, policies, specContext, policyOptions{})
+
+	if diff := cmp.Diff(expected, vsc.policyWarnings); diff != "" {
+		t.Errorf("generatePolicies() returned unexpected policy warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGeneratePoliciesWithMultipleOIDCProviders(t *testing.T) {
+	ownerDetails := policyOwnerDetails{
+		owner:          nil, // nil is OK for the unit test
+		ownerNamespace: "default",
+		vsNamespace:    "default",
+		vsName:         "cafe",
+	}
+	policyOpts := policyOptions{
+		secretRefs: map[string]*secrets.SecretReference{
+			"default/oidc-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeOIDC,
+					Data: map[string][]byte{
+						"client-secret": []byte("super_secret_123"),
+					},
+				},
+			},
+		},
+	}
+	policies := map[string]*conf_v1.Policy{
+		"default/admin-oidc": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "admin-oidc",
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				OIDC: &conf_v1.OIDC{
+					AuthEndpoint:          "https://idp.example.com/auth",
+					TokenEndpoint:         "https://idp.example.com/token",
+					JWKSURI:               "https://idp.example.com/certs",
+					ClientID:              "admin",
+					ClientSecret:          "oidc-secret",
+					RedirectURI:           "/admin/_codexch",
+					PKCE:                  true,
+					EndSessionEndpoint:    "https://idp.example.com/logout",
+					PostLogoutRedirectURI: "https://example.com/",
+					LogoutURI:             "/admin/logout",
+					SessionCookie: &conf_v1.OIDCSessionCookie{
+						Name:     "admin_session",
+						Path:     "/admin",
+						Domain:   "example.com",
+						SameSite: "Strict",
+					},
+				},
+			},
+		},
+		"default/app-oidc": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "app-oidc",
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				OIDC: &conf_v1.OIDC{
+					AuthEndpoint:  "https://accounts.example.com/auth",
+					TokenEndpoint: "https://accounts.example.com/token",
+					JWKSURI:       "https://accounts.example.com/certs",
+					ClientID:      "app",
+					ClientSecret:  "oidc-secret",
+				},
+			},
+		},
+	}
+
+	expectedAdmin := &version2.OIDC{
+		ID:                    "default_admin_oidc_default_cafe_1d7bcf4e",
+		AuthEndpoint:          "https://idp.example.com/auth",
+		TokenEndpoint:         "https://idp.example.com/token",
+		JwksURI:               "https://idp.example.com/certs",
+		ClientID:              "admin",
+		ClientSecret:          "super_secret_123",
+		Scope:                 "openid",
+		RedirectURI:           "/admin/_codexch",
+		PKCE:                  true,
+		EndSessionEndpoint:    "https://idp.example.com/logout",
+		PostLogoutRedirectURI: "https://example.com/",
+		LogoutURI:             "/admin/logout",
+		CookieName:            "admin_session",
+		CookieFlags:           "Path=/admin; Domain=example.com; SameSite=Strict; HttpOnly;",
+	}
+	expectedApp := &version2.OIDC{
+		ID:                    "default_app_oidc_default_cafe_7e14995a",
+		AuthEndpoint:          "https://accounts.example.com/auth",
+		TokenEndpoint:         "https://accounts.example.com/token",
+		JwksURI:               "https://accounts.example.com/certs",
+		ClientID:              "app",
+		ClientSecret:          "super_secret_123",
+		Scope:                 "openid",
+		RedirectURI:           "/_codexch_default_app_oidc_default_cafe_7e14995a",
+		PostLogoutRedirectURI: "/_logout",
+		LogoutURI:             "/logout_default_app_oidc_default_cafe_7e14995a",
+		CookieName:            "auth_token_default_app_oidc_default_cafe_7e14995a",
+		CookieFlags:           "Path=/; SameSite=Lax; HttpOnly;",
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, true, false, &StaticConfigParams{}, false)
+
+	adminRefs := []conf_v1.PolicyReference{{Name: "admin-oidc"}}
+	appRefs := []conf_v1.PolicyReference{{Name: "app-oidc"}}
+
+	adminCfg := vsc.generatePolicies(ownerDetails, adminRefs, policies, routeContext, policyOpts)
+	appCfg := vsc.generatePolicies(ownerDetails, appRefs, policies, routeContext, policyOpts)
+	// another route with the same policy shares the provider
+	anotherAdminCfg := vsc.generatePolicies(ownerDetails, adminRefs, policies, routeContext, policyOpts)
+
+	if diff := cmp.Diff(expectedAdmin, adminCfg.OIDC); diff != "" {
+		t.Errorf("generatePolicies() returned unexpected OIDC for the admin route (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedApp, appCfg.OIDC); diff != "" {
+		t.Errorf("generatePolicies() returned unexpected OIDC for the app route (-want +got):\n%s", diff)
+	}
+	if anotherAdminCfg.OIDC != adminCfg.OIDC {
+		t.Errorf("generatePolicies() didn't reuse the OIDC provider of the admin policy")
+	}
+	if len(vsc.warnings) > 0 {
+		t.Errorf("generatePolicies() returned unexpected warnings %v", vsc.warnings)
+	}
+
+	expectedProviders := []version2.OIDC{*expectedAdmin, *expectedApp}
+	if diff := cmp.Diff(expectedProviders, vsc.oidcPolCfg.generateServerProviders()); diff != "" {
+		t.Errorf("generateServerProviders() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyOIDCDiscovery(t *testing.T) {
+	discoveries := map[string]*OIDCDiscovery{
+		"https://idp.example.com/realms/test": {
+			AuthEndpoint:       "https://idp.example.com/realms/test/auth",
+			TokenEndpoint:      "https://idp.example.com/realms/test/token",
+			JWKSURI:            "https://idp.example.com/realms/test/certs",
+			EndSessionEndpoint: "https://idp.example.com/realms/test/logout",
+		},
+		"https://unavailable.example.com": {
+			Error: errors.New("unexpected response status 503"),
+		},
+	}
+
+	tests := []struct {
+		oidcPolicy *conf_v1.OIDC
+		expected   *conf_v1.OIDC
+		msg        string
+	}{
+		{
+			oidcPolicy: &conf_v1.OIDC{
+				AuthEndpoint:  "https://idp.example.com/auth",
+				TokenEndpoint: "https://idp.example.com/token",
+				JWKSURI:       "https://idp.example.com/certs",
+			},
+			expected: &conf_v1.OIDC{
+				AuthEndpoint:  "https://idp.example.com/auth",
+				TokenEndpoint: "https://idp.example.com/token",
+				JWKSURI:       "https://idp.example.com/certs",
+			},
+			msg: "no issuer",
+		},
+		{
+			oidcPolicy: &conf_v1.OIDC{
+				Issuer: "https://idp.example.com/realms/test",
+			},
+			expected: &conf_v1.OIDC{
+				Issuer:             "https://idp.example.com/realms/test",
+				AuthEndpoint:       "https://idp.example.com/realms/test/auth",
+				TokenEndpoint:      "https://idp.example.com/realms/test/token",
+				JWKSURI:            "https://idp.example.com/realms/test/certs",
+				EndSessionEndpoint: "https://idp.example.com/realms/test/logout",
+			},
+			msg: "discovered endpoints",
+		},
+		{
+			oidcPolicy: &conf_v1.OIDC{
+				Issuer:        "https://idp.example.com/realms/test",
+				TokenEndpoint: "http://idp.default.svc.cluster.local/realms/test/token",
+			},
+			expected: &conf_v1.OIDC{
+				Issuer:             "https://idp.example.com/realms/test",
+				AuthEndpoint:       "https://idp.example.com/realms/test/auth",
+				TokenEndpoint:      "http://idp.default.svc.cluster.local/realms/test/token",
+				JWKSURI:            "https://idp.example.com/realms/test/certs",
+				EndSessionEndpoint: "https://idp.example.com/realms/test/logout",
+			},
+			msg: "explicit endpoint overrides discovered endpoint",
+		},
+		{
+			oidcPolicy: &conf_v1.OIDC{
+				Issuer:        "https://unknown.example.com",
+				AuthEndpoint:  "https://unknown.example.com/auth",
+				TokenEndpoint: "https://unknown.example.com/token",
+				JWKSURI:       "https://unknown.example.com/certs",
+			},
+			expected: &conf_v1.OIDC{
+				Issuer:        "https://unknown.example.com",
+				AuthEndpoint:  "https://unknown.example.com/auth",
+				TokenEndpoint: "https://unknown.example.com/token",
+				JWKSURI:       "https://unknown.example.com/certs",
+			},
+			msg: "explicit endpoints with pending discovery",
+		},
+		{
+			oidcPolicy: &conf_v1.OIDC{
+				Issuer:        "https://unavailable.example.com",
+				AuthEndpoint:  "https://unavailable.example.com/auth",
+				TokenEndpoint: "https://unavailable.example.com/token",
+				JWKSURI:       "https://unavailable.example.com/certs",
+			},
+			expected: &conf_v1.OIDC{
+				Issuer:        "https://unavailable.example.com",
+				AuthEndpoint:  "https://unavailable.example.com/auth",
+				TokenEndpoint: "https://unavailable.example.com/token",
+				JWKSURI:       "https://unavailable.example.com/certs",
+			},
+			msg: "explicit endpoints with failed discovery",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := applyOIDCDiscovery(test.oidcPolicy, discoveries)
+		if err != nil {
+			t.Errorf("applyOIDCDiscovery() returned unexpected error %v for the case of %s", err, test.msg)
+		}
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("applyOIDCDiscovery() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+
+	for _, issuer := range []string{"https://unavailable.example.com", "https://unknown.example.com"} {
+		_, err := applyOIDCDiscovery(&conf_v1.OIDC{Issuer: issuer}, discoveries)
+		if err == nil {
+			t.Errorf("applyOIDCDiscovery() returned no error for the issuer %s", issuer)
+		}
+	}
+}
+
+func TestFindOIDCProviderConflict(t *testing.T) {
+	other := &version2.OIDC{
+		RedirectURI: "/_codexch",
+		LogoutURI:   "/logout",
+		CookieName:  "auth_token",
+	}
+
+	tests := []struct {
+		provider *version2.OIDC
+		expected string
+	}{
+		{
+			provider: &version2.OIDC{
+				RedirectURI: "/admin/_codexch",
+				LogoutURI:   "/admin/logout",
+				CookieName:  "admin_session",
+			},
+			expected: "",
+		},
+		{
+			provider: &version2.OIDC{
+				RedirectURI: "/logout",
+				LogoutURI:   "/admin/logout",
+				CookieName:  "admin_session",
+			},
+			expected: "redirect URI /logout",
+		},
+		{
+			provider: &version2.OIDC{
+				RedirectURI: "/admin/_codexch",
+				LogoutURI:   "/_codexch",
+				CookieName:  "admin_session",
+			},
+			expected: "logout URI /_codexch",
+		},
+		{
+			provider: &version2.OIDC{
+				RedirectURI: "/admin/_codexch",
+				LogoutURI:   "/admin/logout",
+				CookieName:  "auth_token",
+			},
+			expected: "session cookie auth_token",
+		},
+	}
+
+	for _, test := range tests {
+		result := findOIDCProviderConflict(test.provider, other)
+		if result != test.expected {
+			t.Errorf("findOIDCProviderConflict() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
+func TestGenerateOIDCCookieFlags(t *testing.T) {
+	tests := []struct {
+		cookie   *conf_v1.OIDCSessionCookie
+		expected string
+	}{
+		{
+			cookie:   &conf_v1.OIDCSessionCookie{Name: "session"},
+			expected: "Path=/; SameSite=Lax; HttpOnly;",
+		},
+		{
+			cookie: &conf_v1.OIDCSessionCookie{
+				Path:     "/admin",
+				Domain:   "example.com",
+				SameSite: "None",
+			},
+			expected: "Path=/admin; Domain=example.com; SameSite=None; HttpOnly;",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateOIDCCookieFlags(test.cookie)
+		if result != test.expected {
+			t.Errorf("generateOIDCCookieFlags() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
 func TestRemoveDuplicates(t *testing.T) {
 	tests := []struct {
 		rlz      []version2.LimitReqZone
@@ -3450,6 +5312,145 @@ func TestAddPoliciesCfgToLocations(t *testing.T) {
 	}
 }
 
+func TestAddServerAPIKeyToLocations(t *testing.T) {
+	serverAPIKey := &version2.APIKey{
+		Token:      "${http_x_api_key}",
+		ClientMap:  "$apikey_auth_client_default_api_key_policy_default_cafe",
+		RejectCode: 403,
+	}
+	routeAPIKey := &version2.APIKey{
+		Token:      "${arg_api_key}",
+		ClientMap:  "$apikey_auth_client_default_api_key_policy2_default_cafe",
+		RejectCode: 401,
+	}
+
+	locations := []version2.Location{
+		{
+			Path: "/",
+		},
+		{
+			Path:   "/tea",
+			APIKey: routeAPIKey,
+		},
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:   "/",
+			APIKey: serverAPIKey,
+		},
+		{
+			Path:   "/tea",
+			APIKey: routeAPIKey,
+		},
+	}
+
+	addServerAPIKeyToLocations(serverAPIKey, locations)
+	if !reflect.DeepEqual(locations, expectedLocations) {
+		t.Errorf("addServerAPIKeyToLocations() returned \n%+v but expected \n%+v", locations, expectedLocations)
+	}
+}
+
+func TestAddServerSecurityHeadersToLocations(t *testing.T) {
+	serverHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "DENY"},
+	}
+	routeHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+	}
+
+	locations := []version2.Location{
+		{
+			Path: "/",
+		},
+		{
+			Path:            "/tea",
+			SecurityHeaders: routeHeaders,
+		},
+		{
+			Path:            "/coffee",
+			SecurityHeaders: []version2.Header{},
+		},
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:            "/",
+			SecurityHeaders: serverHeaders,
+		},
+		{
+			Path:            "/tea",
+			SecurityHeaders: routeHeaders,
+		},
+		{
+			Path:            "/coffee",
+			SecurityHeaders: []version2.Header{},
+		},
+	}
+
+	addServerSecurityHeadersToLocations(serverHeaders, locations)
+	if !reflect.DeepEqual(locations, expectedLocations) {
+		t.Errorf("addServerSecurityHeadersToLocations() returned \n%+v but expected \n%+v", locations, expectedLocations)
+	}
+}
+
+func TestAddSecurityHeadersToInternalLocations(t *testing.T) {
+	serverHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "DENY"},
+	}
+	routeHeaders := []version2.Header{
+		{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+	}
+
+	errorPageLocations := []version2.ErrorPageLocation{
+		{
+			Name: "@error_page_0_0",
+		},
+		{
+			Name:            "@error_page_1_0",
+			SecurityHeaders: routeHeaders,
+		},
+	}
+	returnLocations := []version2.ReturnLocation{
+		{
+			Name:            "@return_0",
+			SecurityHeaders: routeHeaders,
+		},
+		{
+			Name: "@return_1",
+		},
+	}
+
+	expectedErrorPageLocations := []version2.ErrorPageLocation{
+		{
+			Name:            "@error_page_0_0",
+			SecurityHeaders: serverHeaders,
+		},
+		{
+			Name:            "@error_page_1_0",
+			SecurityHeaders: routeHeaders,
+		},
+	}
+	expectedReturnLocations := []version2.ReturnLocation{
+		{
+			Name:            "@return_0",
+			SecurityHeaders: routeHeaders,
+		},
+		{
+			Name:            "@return_1",
+			SecurityHeaders: serverHeaders,
+		},
+	}
+
+	addSecurityHeadersToInternalLocations(serverHeaders, errorPageLocations, returnLocations)
+	if diff := cmp.Diff(expectedErrorPageLocations, errorPageLocations); diff != "" {
+		t.Errorf("addSecurityHeadersToInternalLocations() returned unexpected error page locations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedReturnLocations, returnLocations); diff != "" {
+		t.Errorf("addSecurityHeadersToInternalLocations() returned unexpected return locations (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateUpstream(t *testing.T) {
 	name := "test-upstream"
 	upstream := conf_v1.Upstream{Service: name, Port: 80}
@@ -3689,6 +5690,38 @@ func TestGenerateProxyPass(t *testing.T) {
 	}
 }
 
+func TestGenerateProxyPassWithRewrites(t *testing.T) {
+	proxy := &conf_v1.ActionProxy{
+		Rewrites: []conf_v1.RewriteRule{
+			{
+				Match:       "^/path/(.*)$",
+				Replacement: "/$1",
+			},
+		},
+	}
+
+	tests := []struct {
+		internal bool
+		expected string
+	}{
+		{
+			internal: false,
+			expected: "http://test-upstream",
+		},
+		{
+			internal: true,
+			expected: "http://test-upstream",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxyPass(false, "test-upstream", test.internal, proxy)
+		if result != test.expected {
+			t.Errorf("generateProxyPass() returned %v for internal %v but expected %v", result, test.internal, test.expected)
+		}
+	}
+}
+
 func TestGenerateProxyPassProtocol(t *testing.T) {
 	tests := []struct {
 		upstream conf_v1.Upstream
@@ -6724,6 +8757,74 @@ func TestGenerateRewrites(t *testing.T) {
 			},
 			expected: []string{`"^/regex" "/rewrite" break`},
 		},
+		{
+			path: "/api",
+			proxy: &conf_v1.ActionProxy{
+				Rewrites: []conf_v1.RewriteRule{
+					{
+						Match:       "^/api/v[0-9]+/(.*)$",
+						Replacement: "/$1",
+					},
+					{
+						Match:           "^/api/(users|orders)/([0-9]+)$",
+						Replacement:     "/$1?id=$2",
+						DropQueryString: true,
+					},
+				},
+			},
+			expected: []string{`"^/api/v[0-9]+/(.*)$" "/$1" break`, `"^/api/(users|orders)/([0-9]+)$" "/$1?id=$2?" break`},
+		},
+		{
+			path: "~ ^/api/v[0-9]+",
+			proxy: &conf_v1.ActionProxy{
+				Rewrites: []conf_v1.RewriteRule{
+					{
+						Match:       "^/api/v[0-9]+(/.*)$",
+						Replacement: "$1",
+					},
+				},
+			},
+			expected: []string{`"^/api/v[0-9]+(/.*)$" "$1" break`},
+		},
+		{
+			path:     "/_internal_path",
+			internal: true,
+			proxy: &conf_v1.ActionProxy{
+				Rewrites: []conf_v1.RewriteRule{
+					{
+						Match:       "^/path/(.*)$",
+						Replacement: "/$1",
+					},
+				},
+			},
+			originalPath: "/path",
+			expected:     []string{`^ $request_uri`, `"^/path/(.*)$" "/$1" break`, `"^(.*)$" "$1" break`},
+		},
+		{
+			path:     "/_internal_path",
+			internal: true,
+			proxy: &conf_v1.ActionProxy{
+				Rewrites: []conf_v1.RewriteRule{
+					{
+						Match:       "^/api/v1/(.*)$",
+						Replacement: "/$1",
+					},
+					{
+						Match:           "^/api/v2/(.*)$",
+						Replacement:     "/v2/$1",
+						DropQueryString: true,
+					},
+				},
+			},
+			originalPath: "/api",
+			// a request like /api/v3/users matches no rule and is passed with the original URI by the catch-all rule
+			expected: []string{
+				`^ $request_uri`,
+				`"^/api/v1/(.*)$" "/$1" break`,
+				`"^/api/v2/(.*)$" "/v2/$1?" break`,
+				`"^(.*)$" "$1" break`,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -7392,3 +9493,197 @@ func TestGenerateTimeWithDefault(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateAPIKeyToken(t *testing.T) {
+	tests := []struct {
+		suppliedIn    *conf_v1.SuppliedIn
+		expectedToken string
+		expectedMaps  []version2.Map
+		msg           string
+	}{
+		{
+			suppliedIn: &conf_v1.SuppliedIn{
+				Header: []string{"X-API-Key"},
+			},
+			expectedToken: "${http_x_api_key}",
+			msg:           "single header",
+		},
+		{
+			suppliedIn: &conf_v1.SuppliedIn{
+				Header: []string{"X-API-Key", "Api-Key"},
+				Query:  []string{"api_key"},
+			},
+			expectedToken: "$apikey_auth_token_test_0",
+			expectedMaps: []version2.Map{
+				{
+					Source:   "${http_x_api_key}",
+					Variable: "$apikey_auth_token_test_0",
+					Parameters: []version2.Parameter{
+						{
+							Value:  `""`,
+							Result: "$apikey_auth_token_test_1",
+						},
+						{
+							Value:  "default",
+							Result: "${http_x_api_key}",
+						},
+					},
+				},
+				{
+					Source:   "${http_api_key}",
+					Variable: "$apikey_auth_token_test_1",
+					Parameters: []version2.Parameter{
+						{
+							Value:  `""`,
+							Result: "${arg_api_key}",
+						},
+						{
+							Value:  "default",
+							Result: "${http_api_key}",
+						},
+					},
+				},
+			},
+			msg: "headers and query parameter",
+		},
+	}
+
+	for _, test := range tests {
+		token, maps := generateAPIKeyToken(test.suppliedIn, "test")
+		if token != test.expectedToken {
+			t.Errorf("generateAPIKeyToken() returned token %q but expected %q for the case of %s", token, test.expectedToken, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedMaps, maps); diff != "" {
+			t.Errorf("generateAPIKeyToken() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestToVariableName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "team-a_jwt_default_cafe",
+			expected: "team_a_jwt_default_cafe_5cc3a55e",
+		},
+		{
+			name:     "team_a-jwt_default_cafe",
+			expected: "team_a_jwt_default_cafe_7d7cd122",
+		},
+		{
+			name:     "team.a_jwt_default_cafe",
+			expected: "team_a_jwt_default_cafe_f1d60fd3",
+		},
+	}
+
+	for _, test := range tests {
+		result := toVariableName(test.name)
+		if result != test.expected {
+			t.Errorf("toVariableName(%q) returned %q but expected %q", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestAppendJwksURI(t *testing.T) {
+	jwksURI := &version2.JwksURI{
+		Location:  "/_pol_jwks_default_jwt-policy_default_cafe",
+		URI:       "https://idp.example.com/keys",
+		CacheZone: "pol_jwks_default_jwt-policy_default_cafe",
+		KeyCache:  "1h",
+	}
+
+	var result []version2.JwksURI
+	result = appendJwksURI(result, nil)
+	result = appendJwksURI(result, &version2.JWTAuth{Realm: "My Api", Secret: "/etc/nginx/secrets/default-jwk-secret"})
+	result = appendJwksURI(result, &version2.JWTAuth{Realm: "My Api", JwksURI: jwksURI})
+	result = appendJwksURI(result, &version2.JWTAuth{Realm: "My Other Api", JwksURI: jwksURI})
+
+	expected := []version2.JwksURI{*jwksURI}
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("appendJwksURI() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateJWTClaimMapParameters(t *testing.T) {
+	tests := []struct {
+		claim    conf_v1.JWTClaimRequirement
+		expected []version2.Parameter
+	}{
+		{
+			claim: conf_v1.JWTClaimRequirement{
+				Name:     "iss",
+				Operator: "In",
+				Values:   []string{"https://idp.example.com", "https://idp2.example.com"},
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"~^(https://idp\.example\.com|https://idp2\.example\.com)$"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			claim: conf_v1.JWTClaimRequirement{
+				Name:     "custom_claim",
+				Operator: "Exists",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "1",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := generateJWTClaimMapParameters(test.claim)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateJWTClaimMapParameters() returned unexpected result for the operator %s (-want +got):\n%s", test.claim.Operator, diff)
+		}
+	}
+}
+
+func TestRemoveDuplicateMaps(t *testing.T) {
+	maps := []version2.Map{
+		{
+			Source:   "$jwt_claim_aud",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_0",
+		},
+		{
+			Source:   "$jwt_claim_groups",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_1",
+		},
+		{
+			Source:   "$jwt_claim_aud",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_0",
+		},
+	}
+
+	expected := []version2.Map{
+		{
+			Source:   "$jwt_claim_aud",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_0",
+		},
+		{
+			Source:   "$jwt_claim_groups",
+			Variable: "$pol_jwt_default_jwt_policy_default_cafe_claim_1",
+		},
+	}
+
+	result := removeDuplicateMaps(maps)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("removeDuplicateMaps() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -29,3 +29,46 @@ func (w Warnings) AddWarningf(obj runtime.Object, msgFmt string, args ...interfa
 func (w Warnings) AddWarning(obj runtime.Object, msg string) {
 	w[obj] = append(w[obj], msg)
 }
+
+// PolicyWarnings stores the warnings of the references to Policies for a given runtime k8s object by the key of the Policy.
+type PolicyWarnings map[runtime.Object]map[string][]string
+
+func newPolicyWarnings() PolicyWarnings {
+	return make(map[runtime.Object]map[string][]string)
+}
+
+// Add adds new PolicyWarnings to the map
+func (w PolicyWarnings) Add(warnings PolicyWarnings) {
+	for obj, policyWarnings := range warnings {
+		for key, msgs := range policyWarnings {
+			w.AddWarnings(obj, key, msgs)
+		}
+	}
+}
+
+// AddWarnings adds the warnings of the reference to the Policy with the specified key from the specified object.
+func (w PolicyWarnings) AddWarnings(obj runtime.Object, policyKey string, msgs []string) {
+	if len(msgs) == 0 {
+		return
+	}
+
+	if w[obj] == nil {
+		w[obj] = make(map[string][]string)
+	}
+
+	w[obj][policyKey] = append(w[obj][policyKey], msgs...)
+}
+
+// replaceObject moves the warnings of the object to another object, for example, from a copy of a resource to the resource.
+func (w PolicyWarnings) replaceObject(obj runtime.Object, newObj runtime.Object) {
+	if obj == newObj {
+		return
+	}
+
+	if policyWarnings, exists := w[obj]; exists {
+		delete(w, obj)
+		for key, msgs := range policyWarnings {
+			w.AddWarnings(newObj, key, msgs)
+		}
+	}
+}
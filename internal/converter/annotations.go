@@ -0,0 +1,388 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	jwtRealmAnnotation                    = "nginx.com/jwt-realm"
+	jwtTokenAnnotation                    = "nginx.com/jwt-token"
+	appProtectEnableAnnotation            = "appprotect.f5.com/app-protect-enable"
+	appProtectSecurityLogEnableAnnotation = "appprotect.f5.com/app-protect-security-log-enable"
+	defaultAppProtectLogDestination       = "syslog:server=localhost:514"
+)
+
+// convertedAnnotationPrefixes are the prefixes of the annotations of the Ingress Controller.
+// An annotation with such a prefix that is not converted gets a warning.
+var convertedAnnotationPrefixes = []string{"nginx.org/", "nginx.com/", "appprotect.f5.com/", "nsm.nginx.com/", "ingress.kubernetes.io/"}
+
+// ingressConfig holds the configuration of an Ingress parsed from its annotations.
+type ingressConfig struct {
+	sslRedirect      bool
+	redirectToHTTPS  bool
+	serverSnippets   string
+	policies         []conf_v1.PolicyReference
+	rewrites         map[string]string
+	locationSnippets string
+	pathRegex        string
+	hideHeaders      []string
+	passHeaders      []string
+	// upstream holds the fields of all upstreams of the Ingress.
+	upstream       conf_v1.Upstream
+	sslServices    map[string]bool
+	sessionCookies map[string]*conf_v1.SessionCookie
+}
+
+// parseAnnotations parses the annotations of an Ingress. The Policies for the JWT and App Protect annotations
+// are added to the result. The annotations that are not converted get a warning.
+func (c *Converter) parseAnnotations(ing *networking.Ingress, annotations map[string]string, result *Result) *ingressConfig {
+	cfg := &ingressConfig{
+		sslRedirect: true,
+	}
+
+	var jwt conf_v1.JWTAuth
+	var waf conf_v1.WAF
+	var securityLog conf_v1.SecurityLog
+
+	var keys []string
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := annotations[key]
+
+		if strings.HasPrefix(key, "nginx.com/") && !c.isPlus {
+			result.addWarning(ing, "annotation %s requires NGINX Plus and was not converted", key)
+			continue
+		}
+
+		if strings.HasPrefix(key, "appprotect.f5.com/") && !c.appProtectEnabled {
+			result.addWarning(ing, "annotation %s requires NGINX App Protect and was not converted", key)
+			continue
+		}
+
+		var err error
+
+		switch key {
+		case ingressClassAnnotation, mergeableIngressTypeAnnotation, configs.MinionNamespacesAnnotation, configs.CanaryAnnotation, configs.CanaryWeightAnnotation,
+			configs.CanaryByHeaderAnnotation, configs.CanaryByHeaderValueAnnotation, configs.CanaryByCookieAnnotation:
+			// handled by the conversion of the Ingress
+		case "nginx.org/websocket-services":
+			// VirtualServers support WebSocket for all upstreams
+		case "nginx.org/lb-method":
+			if c.isPlus {
+				_, err = configs.ParseLBMethodForPlus(value)
+			} else {
+				_, err = configs.ParseLBMethod(value)
+			}
+			cfg.upstream.LBMethod = value
+		case "nginx.org/proxy-connect-timeout":
+			cfg.upstream.ProxyConnectTimeout, err = configs.ParseTime(value)
+		case "nginx.org/proxy-read-timeout":
+			cfg.upstream.ProxyReadTimeout, err = configs.ParseTime(value)
+		case "nginx.org/proxy-send-timeout":
+			cfg.upstream.ProxySendTimeout, err = configs.ParseTime(value)
+		case "nginx.org/fail-timeout":
+			cfg.upstream.FailTimeout, err = configs.ParseTime(value)
+		case "nginx.com/slow-start":
+			cfg.upstream.SlowStart, err = configs.ParseTime(value)
+		case "nginx.org/client-max-body-size":
+			cfg.upstream.ClientMaxBodySize, err = configs.ParseOffset(value)
+		case "nginx.org/proxy-buffer-size":
+			cfg.upstream.ProxyBufferSize, err = configs.ParseSize(value)
+		case "nginx.org/proxy-buffers":
+			cfg.upstream.ProxyBuffers, err = parseProxyBuffers(value)
+		case "nginx.org/proxy-buffering":
+			cfg.upstream.ProxyBuffering, err = parseBoolPointer(value)
+		case "nginx.org/keepalive":
+			cfg.upstream.Keepalive, err = parseNonNegativeIntPointer(value)
+		case "nginx.org/max-fails":
+			cfg.upstream.MaxFails, err = parseNonNegativeIntPointer(value)
+		case "nginx.org/max-conns":
+			cfg.upstream.MaxConns, err = parseNonNegativeIntPointer(value)
+		case "nginx.com/health-checks":
+			var enabled bool
+			enabled, err = configs.ParseBool(value)
+			if enabled {
+				cfg.upstream.HealthCheck = &conf_v1.HealthCheck{
+					Enable: true,
+				}
+				result.addWarning(ing, "annotation %s was converted into health checks with the default parameters, because the parameters of the readiness probes of the pods are not converted", key)
+			}
+		case "nginx.org/ssl-services":
+			cfg.sslServices = configs.ParseServiceList(value)
+		case "nginx.com/sticky-cookie-services":
+			cfg.sessionCookies, err = parseSessionCookies(value)
+		case "nginx.org/rewrites":
+			cfg.rewrites, err = configs.ParseRewriteList(value)
+		case configs.PathRegexAnnotation:
+			if value != configs.PathRegexCaseSensitive && value != configs.PathRegexCaseInsensitive {
+				err = fmt.Errorf("must be %s or %s", configs.PathRegexCaseSensitive, configs.PathRegexCaseInsensitive)
+			} else {
+				cfg.pathRegex = value
+			}
+		case "nginx.org/location-snippets":
+			cfg.locationSnippets = value
+		case "nginx.org/server-snippets":
+			cfg.serverSnippets = value
+		case "nginx.org/proxy-hide-headers":
+			cfg.hideHeaders = parseHeaderList(value)
+		case "nginx.org/proxy-pass-headers":
+			cfg.passHeaders = parseHeaderList(value)
+		case "nginx.org/redirect-to-https":
+			cfg.redirectToHTTPS, err = configs.ParseBool(value)
+		case "ingress.kubernetes.io/ssl-redirect":
+			cfg.sslRedirect, err = configs.ParseBool(value)
+		case configs.PoliciesAnnotation:
+			var policies []conf_v1.PolicyReference
+			policies, err = configs.ParsePolicyList(value)
+			cfg.policies = append(cfg.policies, policies...)
+		case configs.JWTKeyAnnotation:
+			jwt.Secret = value
+		case jwtRealmAnnotation:
+			jwt.Realm = value
+		case jwtTokenAnnotation:
+			jwt.Token = value
+		case appProtectEnableAnnotation:
+			waf.Enable, err = configs.ParseBool(value)
+		case configs.AppProtectPolicyAnnotation:
+			waf.ApPolicy = value
+		case appProtectSecurityLogEnableAnnotation:
+			securityLog.Enable, err = configs.ParseBool(value)
+		case configs.AppProtectLogConfAnnotation:
+			if strings.Contains(value, ",") {
+				err = fmt.Errorf("a WAF policy supports only one log configuration")
+			} else {
+				securityLog.ApLogConf = value
+			}
+		case configs.AppProtectLogConfDstAnnotation:
+			securityLog.LogDest = value
+		default:
+			if hasConvertedAnnotationPrefix(key) {
+				result.addWarning(ing, "annotation %s has no equivalent in VirtualServer resources and was not converted", key)
+			}
+			continue
+		}
+
+		if err != nil {
+			result.addWarning(ing, "invalid value of annotation %s: got %q: %v; the annotation was not converted", key, value, err)
+			resetAnnotation(cfg, key)
+		}
+	}
+
+	if jwt.Secret != "" {
+		if policy := c.addPolicy(ing, "jwt", conf_v1.PolicySpec{JWTAuth: &jwt}, result); policy != nil {
+			cfg.policies = append(cfg.policies, *policy)
+			result.addWarning(ing, "the JWT annotations were converted into the Policy %s/%s, which requires the -enable-preview-policies command-line argument", ing.Namespace, policy.Name)
+		}
+	}
+
+	if waf.Enable || securityLog.Enable {
+		if securityLog.Enable || securityLog.ApLogConf != "" {
+			if securityLog.LogDest == "" {
+				securityLog.LogDest = defaultAppProtectLogDestination
+			}
+			waf.SecurityLog = &securityLog
+		}
+		if policy := c.addPolicy(ing, "waf", conf_v1.PolicySpec{WAF: &waf}, result); policy != nil {
+			cfg.policies = append(cfg.policies, *policy)
+		}
+	}
+
+	return cfg
+}
+
+// resetAnnotation resets the fields of the configuration set by an invalid annotation.
+func resetAnnotation(cfg *ingressConfig, key string) {
+	switch key {
+	case "nginx.org/lb-method":
+		cfg.upstream.LBMethod = ""
+	case "nginx.com/sticky-cookie-services":
+		cfg.sessionCookies = nil
+	case "nginx.org/rewrites":
+		cfg.rewrites = nil
+	case "nginx.org/redirect-to-https":
+		cfg.redirectToHTTPS = false
+	case "ingress.kubernetes.io/ssl-redirect":
+		cfg.sslRedirect = true
+	case "nginx.com/health-checks":
+		cfg.upstream.HealthCheck = nil
+	}
+}
+
+// addPolicy adds a Policy with the spec for the Ingress to the result and returns the reference to it.
+// An invalid Policy is not added.
+func (c *Converter) addPolicy(ing *networking.Ingress, suffix string, spec conf_v1.PolicySpec, result *Result) *conf_v1.PolicyReference {
+	spec.IngressClass = getIngressClass(ing)
+
+	policy := &conf_v1.Policy{
+		TypeMeta: policyTypeMeta,
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", ing.Name, suffix),
+			Namespace: ing.Namespace,
+		},
+		Spec: spec,
+	}
+
+	err := validation.ValidatePolicy(policy, c.isPlus, true, c.appProtectEnabled)
+	if err != nil {
+		result.addWarning(ing, "Policy %s/%s is invalid and was not converted: %v", policy.Namespace, policy.Name, err)
+		return nil
+	}
+
+	result.Policies = append(result.Policies, policy)
+
+	return &conf_v1.PolicyReference{
+		Name: policy.Name,
+	}
+}
+
+// generateUpstream generates the upstream for the backend.
+func (cfg *ingressConfig) generateUpstream(name string, backend serviceBackend) conf_v1.Upstream {
+	u := cfg.upstream
+
+	u.Name = name
+	u.Service = backend.service
+	u.Port = uint16(backend.port)
+	u.TLS.Enable = cfg.sslServices[backend.service]
+	u.SessionCookie = cfg.sessionCookies[backend.service]
+
+	return u
+}
+
+// generateAction generates the action that passes requests to the upstream. It is a proxy action if the requests
+// are rewritten or the response headers are hidden or passed.
+func (cfg *ingressConfig) generateAction(upstream string, rewrite string) *conf_v1.Action {
+	if rewrite == "" && len(cfg.hideHeaders) == 0 && len(cfg.passHeaders) == 0 {
+		return &conf_v1.Action{
+			Pass: upstream,
+		}
+	}
+
+	proxy := &conf_v1.ActionProxy{
+		Upstream:    upstream,
+		RewritePath: rewrite,
+	}
+
+	if len(cfg.hideHeaders) > 0 || len(cfg.passHeaders) > 0 {
+		proxy.ResponseHeaders = &conf_v1.ProxyResponseHeaders{
+			Hide: cfg.hideHeaders,
+			Pass: cfg.passHeaders,
+		}
+	}
+
+	return &conf_v1.Action{
+		Proxy: proxy,
+	}
+}
+
+func hasConvertedAnnotationPrefix(key string) bool {
+	for _, prefix := range convertedAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBoolPointer(s string) (*bool, error) {
+	b, err := configs.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func parseNonNegativeIntPointer(s string) (*int, error) {
+	n, err := configs.ParseInt(s)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("must be positive or zero")
+	}
+	return &n, nil
+}
+
+func parseProxyBuffers(s string) (*conf_v1.UpstreamBuffers, error) {
+	buffers, err := configs.ParseProxyBuffersSpec(s)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Fields(buffers)
+
+	number, err := configs.ParseInt(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &conf_v1.UpstreamBuffers{
+		Number: number,
+		Size:   parts[1],
+	}, nil
+}
+
+func parseHeaderList(s string) []string {
+	var headers []string
+	for _, h := range strings.Split(s, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+// parseSessionCookies parses the value of the nginx.com/sticky-cookie-services annotation into the session cookies
+// of the services. For example, "serviceName=tea srv_id expires=1h path=/tea;serviceName=coffee srv_id httponly secure".
+func parseSessionCookies(s string) (map[string]*conf_v1.SessionCookie, error) {
+	services, err := configs.ParseStickyServiceList(s)
+	if err != nil {
+		return nil, err
+	}
+
+	cookies := make(map[string]*conf_v1.SessionCookie)
+
+	for service, value := range services {
+		parts := strings.Fields(value)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("the cookie of service %s is missing", service)
+		}
+
+		cookie := &conf_v1.SessionCookie{
+			Enable: true,
+			Name:   parts[0],
+		}
+
+		for _, p := range parts[1:] {
+			switch {
+			case strings.HasPrefix(p, "expires="):
+				cookie.Expires = strings.TrimPrefix(p, "expires=")
+			case strings.HasPrefix(p, "domain="):
+				cookie.Domain = strings.TrimPrefix(p, "domain=")
+			case strings.HasPrefix(p, "path="):
+				cookie.Path = strings.TrimPrefix(p, "path=")
+			case p == "httponly":
+				cookie.HTTPOnly = true
+			case p == "secure":
+				cookie.Secure = true
+			default:
+				return nil, fmt.Errorf("the parameter %s of the cookie of service %s is not supported", p, service)
+			}
+		}
+
+		cookies[service] = cookie
+	}
+
+	return cookies, nil
+}
@@ -0,0 +1,91 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
+)
+
+// canaryConfig holds the rules of a canary Ingress that route requests to the canary.
+type canaryConfig struct {
+	weight      int
+	header      string
+	headerValue string
+	cookie      string
+}
+
+func isCanary(ing *networking.Ingress) bool {
+	canary, err := configs.ParseBool(ing.Annotations[configs.CanaryAnnotation])
+	return err == nil && canary
+}
+
+func getCanaryKey(namespace string, host string, path string) string {
+	return fmt.Sprintf("%s/%s", namespace, configs.CanaryPathKey(host, path))
+}
+
+func parseCanaryConfig(canary *networking.Ingress, result *Result) canaryConfig {
+	cfg := canaryConfig{
+		header:      canary.Annotations[configs.CanaryByHeaderAnnotation],
+		headerValue: canary.Annotations[configs.CanaryByHeaderValueAnnotation],
+		cookie:      canary.Annotations[configs.CanaryByCookieAnnotation],
+	}
+
+	if value, exists := canary.Annotations[configs.CanaryWeightAnnotation]; exists {
+		weight, err := configs.ParseInt(value)
+		if err != nil || weight < 0 || weight > 100 {
+			result.addWarning(canary, "invalid value of annotation %s: got %q: must be an integer between 0 and 100; the annotation was not converted",
+				configs.CanaryWeightAnnotation, value)
+		} else {
+			cfg.weight = weight
+		}
+	}
+
+	return cfg
+}
+
+// addCanaryToRoute adds the rules of the canary to the route. The header and the cookie are converted into matches,
+// which take precedence over the splits converted from the weight.
+func addCanaryToRoute(route *conf_v1.Route, cfg canaryConfig, canaryAction *conf_v1.Action) {
+	primaryAction := route.Action
+
+	if cfg.header != "" {
+		if cfg.headerValue != "" {
+			route.Matches = append(route.Matches, newMatch(conf_v1.Condition{Header: cfg.header, Value: cfg.headerValue}, canaryAction))
+		} else {
+			route.Matches = append(route.Matches,
+				newMatch(conf_v1.Condition{Header: cfg.header, Value: "always"}, canaryAction),
+				newMatch(conf_v1.Condition{Header: cfg.header, Value: "never"}, primaryAction))
+		}
+	}
+
+	if cfg.cookie != "" {
+		route.Matches = append(route.Matches,
+			newMatch(conf_v1.Condition{Cookie: cfg.cookie, Value: "always"}, canaryAction),
+			newMatch(conf_v1.Condition{Cookie: cfg.cookie, Value: "never"}, primaryAction))
+	}
+
+	if cfg.weight == 100 {
+		route.Action = canaryAction
+	} else if cfg.weight > 0 {
+		route.Action = nil
+		route.Splits = []conf_v1.Split{
+			{
+				Weight: 100 - cfg.weight,
+				Action: primaryAction,
+			},
+			{
+				Weight: cfg.weight,
+				Action: canaryAction,
+			},
+		}
+	}
+}
+
+func newMatch(condition conf_v1.Condition, action *conf_v1.Action) conf_v1.Match {
+	return conf_v1.Match{
+		Conditions: []conf_v1.Condition{condition},
+		Action:     action,
+	}
+}
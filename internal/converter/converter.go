@@ -0,0 +1,536 @@
+// Package converter converts Ingress resources into VirtualServer, VirtualServerRoute and Policy resources.
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ingressClassAnnotation         = "kubernetes.io/ingress.class"
+	mergeableIngressTypeAnnotation = "nginx.org/mergeable-ingress-type"
+)
+
+var (
+	virtualServerTypeMeta = meta_v1.TypeMeta{
+		APIVersion: "k8s.nginx.org/v1",
+		Kind:       "VirtualServer",
+	}
+	virtualServerRouteTypeMeta = meta_v1.TypeMeta{
+		APIVersion: "k8s.nginx.org/v1",
+		Kind:       "VirtualServerRoute",
+	}
+	policyTypeMeta = meta_v1.TypeMeta{
+		APIVersion: "k8s.nginx.org/v1",
+		Kind:       "Policy",
+	}
+)
+
+// Result holds the resources converted from Ingress resources and the warnings about the configuration that
+// was not converted.
+type Result struct {
+	VirtualServers      []*conf_v1.VirtualServer
+	VirtualServerRoutes []*conf_v1.VirtualServerRoute
+	Policies            []*conf_v1.Policy
+	Warnings            []string
+}
+
+func (r *Result) addWarning(ing *networking.Ingress, format string, a ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf("Ingress %s/%s: %s", ing.Namespace, ing.Name, fmt.Sprintf(format, a...)))
+}
+
+// Converter converts Ingress resources into VirtualServer, VirtualServerRoute and Policy resources.
+// The converted resources pass the validation of the Ingress Controller.
+type Converter struct {
+	isPlus            bool
+	appProtectEnabled bool
+	vsValidator       *validation.VirtualServerValidator
+}
+
+// NewConverter creates a new Converter.
+func NewConverter(isPlus bool, appProtectEnabled bool) *Converter {
+	return &Converter{
+		isPlus:            isPlus,
+		appProtectEnabled: appProtectEnabled,
+		vsValidator:       validation.NewVirtualServerValidator(isPlus),
+	}
+}
+
+// Convert converts the Ingress resources. A regular Ingress is converted into a VirtualServer per host.
+// A master Ingress is converted into a VirtualServer and every path of its minions into a VirtualServerRoute.
+// A canary Ingress is converted into the splits and matches of the routes of its regular Ingress.
+func (c *Converter) Convert(ingresses []*networking.Ingress) *Result {
+	result := &Result{}
+
+	var regularIngresses []*networking.Ingress
+	var masters []*networking.Ingress
+	var minions []*networking.Ingress
+	canaries := make(map[string]*networking.Ingress)
+	var canaryPaths []canaryPath
+
+	for _, ing := range ingresses {
+		if isCanary(ing) {
+			for _, rule := range ing.Spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					key := getCanaryKey(ing.Namespace, rule.Host, path.Path)
+					if _, exists := canaries[key]; exists {
+						result.addWarning(ing, "path %s of host %s is taken by another canary", path.Path, rule.Host)
+						continue
+					}
+					canaries[key] = ing
+					canaryPaths = append(canaryPaths, canaryPath{ing: ing, host: rule.Host, path: path.Path})
+				}
+			}
+			continue
+		}
+
+		switch ing.Annotations[mergeableIngressTypeAnnotation] {
+		case "master":
+			masters = append(masters, ing)
+		case "minion":
+			minions = append(minions, ing)
+		default:
+			regularIngresses = append(regularIngresses, ing)
+		}
+	}
+
+	usedCanaries := make(map[string]bool)
+	for _, ing := range regularIngresses {
+		c.convertRegularIngress(ing, canaries, usedCanaries, result)
+	}
+
+	usedMinions := make(map[*networking.Ingress]bool)
+	for _, master := range masters {
+		c.convertMergeableIngresses(master, minions, usedMinions, result)
+	}
+
+	for _, minion := range minions {
+		if !usedMinions[minion] {
+			result.addWarning(minion, "no master Ingress found for the minion; the minion was not converted")
+		}
+	}
+
+	for _, cp := range canaryPaths {
+		if !usedCanaries[getCanaryKey(cp.ing.Namespace, cp.host, cp.path)] {
+			result.addWarning(cp.ing, "no regular Ingress found for the path %s of host %s of the canary; the path was not converted", cp.path, cp.host)
+		}
+	}
+
+	return result
+}
+
+func (c *Converter) convertRegularIngress(ing *networking.Ingress, canaries map[string]*networking.Ingress, usedCanaries map[string]bool,
+	result *Result) {
+	cfg := c.parseAnnotations(ing, copyAnnotations(ing.Annotations), result)
+
+	var hosts []string
+	pathsByHost := make(map[string][]networking.HTTPIngressPath)
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" {
+			result.addWarning(ing, "a rule without a host can't be converted")
+			continue
+		}
+
+		if _, exists := pathsByHost[rule.Host]; !exists {
+			hosts = append(hosts, rule.Host)
+			pathsByHost[rule.Host] = nil
+		}
+
+		if rule.HTTP != nil {
+			pathsByHost[rule.Host] = append(pathsByHost[rule.Host], rule.HTTP.Paths...)
+		}
+	}
+
+	for _, host := range hosts {
+		paths := pathsByHost[host]
+
+		if ing.Spec.DefaultBackend != nil && !hasRootPath(paths) {
+			paths = append(paths, networking.HTTPIngressPath{
+				Path:    "/",
+				Backend: *ing.Spec.DefaultBackend,
+			})
+		}
+
+		name := ing.Name
+		if len(hosts) > 1 {
+			name = fmt.Sprintf("%s-%s", ing.Name, strings.ReplaceAll(host, ".", "-"))
+		}
+
+		vs := c.generateVirtualServer(ing, name, host, cfg, result)
+		vs.Spec.Upstreams, vs.Spec.Routes = c.generateRoutes(ing, host, paths, cfg, canaries, usedCanaries, result)
+
+		if len(vs.Spec.Routes) == 0 {
+			result.addWarning(ing, "host %s has no paths that can be converted", host)
+			continue
+		}
+
+		c.addVirtualServer(ing, vs, nil, result)
+	}
+}
+
+func (c *Converter) convertMergeableIngresses(master *networking.Ingress, minions []*networking.Ingress, usedMinions map[*networking.Ingress]bool,
+	result *Result) {
+	if len(master.Spec.Rules) != 1 {
+		result.addWarning(master, "a master Ingress must have exactly one host; the master was not converted")
+		return
+	}
+	host := master.Spec.Rules[0].Host
+
+	masterAnnotations := copyAnnotations(master.Annotations)
+	removedAnnotations := configs.FilterMasterAnnotations(masterAnnotations)
+	for _, a := range removedAnnotations {
+		result.addWarning(master, "annotation %s is ignored in a master Ingress and was not converted", a)
+	}
+
+	masterCfg := c.parseAnnotations(master, masterAnnotations, result)
+	vs := c.generateVirtualServer(master, master.Name, host, masterCfg, result)
+
+	var vsrs []*conf_v1.VirtualServerRoute
+
+	for _, minion := range minions {
+		if usedMinions[minion] || len(minion.Spec.Rules) != 1 || minion.Spec.Rules[0].Host != host {
+			continue
+		}
+		usedMinions[minion] = true
+
+		if !configs.IsMinionNamespaceAllowed(master, minion.Namespace) {
+			result.addWarning(minion, "namespace %s is not allowed to contribute minions by Ingress master %s/%s; the minion was not converted",
+				minion.Namespace, master.Namespace, master.Name)
+			continue
+		}
+
+		minionAnnotations := copyAnnotations(minion.Annotations)
+		configs.MergeMasterAnnotationsIntoMinion(minionAnnotations, master.Annotations)
+		removedAnnotations := configs.FilterMinionAnnotations(minionAnnotations)
+		for _, a := range removedAnnotations {
+			result.addWarning(minion, "annotation %s is ignored in a minion Ingress and was not converted", a)
+		}
+
+		minionCfg := c.parseAnnotations(minion, minionAnnotations, result)
+		minionCfg.hideHeaders = masterCfg.hideHeaders
+		minionCfg.passHeaders = masterCfg.passHeaders
+
+		if minion.Spec.Rules[0].HTTP == nil {
+			continue
+		}
+		paths := minion.Spec.Rules[0].HTTP.Paths
+
+		for i, path := range paths {
+			upstreams, routes := c.generateRoutes(minion, host, []networking.HTTPIngressPath{path}, minionCfg, nil, nil, result)
+			if len(routes) == 0 {
+				continue
+			}
+
+			for j := range routes {
+				routes[j].Policies = minionCfg.policies
+			}
+
+			name := minion.Name
+			if len(paths) > 1 {
+				name = fmt.Sprintf("%s-%d", minion.Name, i+1)
+			}
+
+			vsr := &conf_v1.VirtualServerRoute{
+				TypeMeta: virtualServerRouteTypeMeta,
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      name,
+					Namespace: minion.Namespace,
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					IngressClass: getIngressClass(minion),
+					Host:         host,
+					Upstreams:    upstreams,
+					Subroutes:    routes,
+				},
+			}
+
+			err := c.vsValidator.ValidateVirtualServerRouteForVirtualServer(vsr, host, routes[0].Path)
+			if err != nil {
+				result.addWarning(minion, "VirtualServerRoute %s/%s for the path %s is invalid and was not converted: %v", vsr.Namespace, vsr.Name, path.Path, err)
+				continue
+			}
+
+			if minion.Namespace != master.Namespace {
+				result.addWarning(minion, "VirtualServerRoute %s/%s is in a different namespace than VirtualServer %s/%s; a ReferenceGrant might be required",
+					vsr.Namespace, vsr.Name, vs.Namespace, vs.Name)
+			}
+
+			vs.Spec.Routes = append(vs.Spec.Routes, conf_v1.Route{
+				Path:  routes[0].Path,
+				Route: fmt.Sprintf("%s/%s", vsr.Namespace, vsr.Name),
+			})
+			vsrs = append(vsrs, vsr)
+		}
+	}
+
+	c.addVirtualServer(master, vs, vsrs, result)
+}
+
+func (c *Converter) generateVirtualServer(ing *networking.Ingress, name string, host string, cfg *ingressConfig, result *Result) *conf_v1.VirtualServer {
+	vs := &conf_v1.VirtualServer{
+		TypeMeta: virtualServerTypeMeta,
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: ing.Namespace,
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			IngressClass:   getIngressClass(ing),
+			Host:           host,
+			Policies:       cfg.policies,
+			ServerSnippets: cfg.serverSnippets,
+		},
+	}
+
+	secret, hasTLS := getTLSSecret(ing, host)
+	if !hasTLS {
+		if cfg.redirectToHTTPS {
+			result.addWarning(ing, "the redirect to HTTPS for host %s requires TLS in a VirtualServer and was not converted", host)
+		}
+		return vs
+	}
+
+	if secret == "" {
+		result.addWarning(ing, "TLS for host %s without a secret can't be converted", host)
+		return vs
+	}
+
+	vs.Spec.TLS = &conf_v1.TLS{
+		Secret: secret,
+	}
+
+	if cfg.redirectToHTTPS {
+		vs.Spec.TLS.Redirect = &conf_v1.TLSRedirect{
+			Enable:  true,
+			BasedOn: "x-forwarded-proto",
+		}
+	} else if cfg.sslRedirect {
+		vs.Spec.TLS.Redirect = &conf_v1.TLSRedirect{
+			Enable: true,
+		}
+	}
+
+	return vs
+}
+
+type canaryPath struct {
+	ing  *networking.Ingress
+	host string
+	path string
+}
+
+type serviceBackend struct {
+	service string
+	port    int32
+}
+
+// generateRoutes generates the upstreams and routes for the paths of the host of an Ingress.
+func (c *Converter) generateRoutes(ing *networking.Ingress, host string, paths []networking.HTTPIngressPath, cfg *ingressConfig,
+	canaries map[string]*networking.Ingress, usedCanaries map[string]bool, result *Result) ([]conf_v1.Upstream, []conf_v1.Route) {
+	type routeBackends struct {
+		path          string
+		rewrite       string
+		backend       serviceBackend
+		canaryBackend *serviceBackend
+		canary        *networking.Ingress
+	}
+
+	var allRouteBackends []routeBackends
+	var backends []serviceBackend
+	seenPaths := make(map[string]bool)
+
+	for _, path := range paths {
+		backend, err := getServiceBackend(path.Backend)
+		if err != nil {
+			result.addWarning(ing, "path %s of host %s was not converted: %v", path.Path, host, err)
+			continue
+		}
+
+		routePath := getRoutePath(path, cfg.pathRegex)
+		if seenPaths[routePath] {
+			result.addWarning(ing, "path %s of host %s is duplicated and was not converted", path.Path, host)
+			continue
+		}
+		seenPaths[routePath] = true
+
+		rb := routeBackends{
+			path:    routePath,
+			rewrite: cfg.rewrites[backend.service],
+			backend: backend,
+		}
+		backends = append(backends, backend)
+
+		// the Ingress Controller ignores the rewrites of the regular expression paths
+		if rb.rewrite != "" && strings.HasPrefix(routePath, "~") {
+			result.addWarning(ing, "the rewrite of path %s of host %s was not converted, because the path is a regular expression", path.Path, host)
+			rb.rewrite = ""
+		}
+
+		canaryKey := getCanaryKey(ing.Namespace, host, path.Path)
+		if canary, exists := canaries[canaryKey]; exists {
+			usedCanaries[canaryKey] = true
+
+			canaryBackend, err := getServiceBackend(*configs.FindCanaryBackend(canary, host, path.Path))
+			if err != nil {
+				result.addWarning(canary, "path %s of host %s was not converted: %v", path.Path, host, err)
+			} else if rb.rewrite != "" {
+				result.addWarning(canary, "path %s of host %s was not converted, because the path of Ingress %s/%s has a rewrite", path.Path, host, ing.Namespace, ing.Name)
+			} else {
+				rb.canaryBackend = &canaryBackend
+				rb.canary = canary
+				backends = append(backends, canaryBackend)
+			}
+		}
+
+		allRouteBackends = append(allRouteBackends, rb)
+	}
+
+	upstreamNames := getUpstreamNames(backends)
+
+	var upstreams []conf_v1.Upstream
+	addedBackends := make(map[serviceBackend]bool)
+	for _, b := range backends {
+		// the same backend can be referenced by multiple paths
+		if addedBackends[b] {
+			continue
+		}
+		addedBackends[b] = true
+		upstreams = append(upstreams, cfg.generateUpstream(upstreamNames[b], b))
+	}
+
+	var routes []conf_v1.Route
+	for _, rb := range allRouteBackends {
+		route := conf_v1.Route{
+			Path:             rb.path,
+			Action:           cfg.generateAction(upstreamNames[rb.backend], rb.rewrite),
+			LocationSnippets: cfg.locationSnippets,
+		}
+
+		if rb.canaryBackend != nil {
+			canaryAction := cfg.generateAction(upstreamNames[*rb.canaryBackend], "")
+			addCanaryToRoute(&route, parseCanaryConfig(rb.canary, result), canaryAction)
+		}
+
+		routes = append(routes, route)
+	}
+
+	return upstreams, routes
+}
+
+func (c *Converter) addVirtualServer(ing *networking.Ingress, vs *conf_v1.VirtualServer, vsrs []*conf_v1.VirtualServerRoute, result *Result) {
+	err := c.vsValidator.ValidateVirtualServer(vs)
+	if err != nil {
+		result.addWarning(ing, "VirtualServer %s/%s is invalid and was not converted: %v", vs.Namespace, vs.Name, err)
+		return
+	}
+
+	result.VirtualServers = append(result.VirtualServers, vs)
+	result.VirtualServerRoutes = append(result.VirtualServerRoutes, vsrs...)
+}
+
+// getUpstreamNames returns the names of the upstreams for the backends. The name of an upstream is the name of its
+// service, unless the service is referenced with multiple ports.
+func getUpstreamNames(backends []serviceBackend) map[serviceBackend]string {
+	ports := make(map[string]map[int32]bool)
+	for _, b := range backends {
+		if ports[b.service] == nil {
+			ports[b.service] = make(map[int32]bool)
+		}
+		ports[b.service][b.port] = true
+	}
+
+	names := make(map[serviceBackend]string)
+	for _, b := range backends {
+		if len(ports[b.service]) > 1 {
+			names[b] = fmt.Sprintf("%s-%d", b.service, b.port)
+		} else {
+			names[b] = b.service
+		}
+	}
+
+	return names
+}
+
+func getServiceBackend(backend networking.IngressBackend) (serviceBackend, error) {
+	if backend.Service == nil {
+		return serviceBackend{}, fmt.Errorf("a resource backend has no equivalent in a VirtualServer")
+	}
+
+	if backend.Service.Port.Name != "" {
+		return serviceBackend{}, fmt.Errorf("the named port %s of service %s is not supported in a VirtualServer", backend.Service.Port.Name, backend.Service.Name)
+	}
+
+	return serviceBackend{
+		service: backend.Service.Name,
+		port:    backend.Service.Port.Number,
+	}, nil
+}
+
+func getRoutePath(path networking.HTTPIngressPath, pathRegex string) string {
+	p := path.Path
+	if p == "" {
+		p = "/"
+	}
+
+	if path.PathType == nil {
+		return p
+	}
+
+	switch *path.PathType {
+	case networking.PathTypeExact:
+		return "=" + p
+	case networking.PathTypeImplementationSpecific:
+		switch pathRegex {
+		case configs.PathRegexCaseSensitive:
+			return "~ " + p
+		case configs.PathRegexCaseInsensitive:
+			return "~* " + p
+		}
+	}
+
+	return p
+}
+
+func hasRootPath(paths []networking.HTTPIngressPath) bool {
+	for _, p := range paths {
+		if p.Path == "/" || p.Path == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func getTLSSecret(ing *networking.Ingress, host string) (string, bool) {
+	for _, tls := range ing.Spec.TLS {
+		for _, h := range tls.Hosts {
+			if h == host {
+				return tls.SecretName, true
+			}
+		}
+	}
+	return "", false
+}
+
+func getIngressClass(ing *networking.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations[ingressClassAnnotation]
+}
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,572 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createTestIngress(name string, annotations map[string]string, host string, paths ...networking.HTTPIngressPath) *networking.Ingress {
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: host,
+				},
+			},
+		},
+	}
+
+	if len(paths) > 0 {
+		ing.Spec.Rules[0].HTTP = &networking.HTTPIngressRuleValue{
+			Paths: paths,
+		}
+	}
+
+	return ing
+}
+
+func createTestPath(path string, service string) networking.HTTPIngressPath {
+	return networking.HTTPIngressPath{
+		Path: path,
+		Backend: networking.IngressBackend{
+			Service: &networking.IngressServiceBackend{
+				Name: service,
+				Port: networking.ServiceBackendPort{
+					Number: 80,
+				},
+			},
+		},
+	}
+}
+
+func createTestVirtualServerMeta(name string) (meta_v1.TypeMeta, meta_v1.ObjectMeta) {
+	return virtualServerTypeMeta, meta_v1.ObjectMeta{
+		Name:      name,
+		Namespace: "default",
+	}
+}
+
+func TestConvertRegularIngress(t *testing.T) {
+	ing := createTestIngress("cafe-ingress", map[string]string{
+		"kubernetes.io/ingress.class":     "nginx",
+		"nginx.org/proxy-connect-timeout": "30s",
+		"nginx.org/max-fails":             "0",
+		"nginx.org/ssl-services":          "coffee-svc",
+		"nginx.org/rewrites":              "serviceName=tea-svc rewrite=/",
+		"nginx.org/location-snippets":     "add_header my-test-header test-value;",
+		"nginx.org/hsts":                  "true",
+	}, "cafe.example.com", createTestPath("/tea", "tea-svc"), createTestPath("/coffee", "coffee-svc"))
+	ing.Spec.TLS = []networking.IngressTLS{
+		{
+			Hosts:      []string{"cafe.example.com"},
+			SecretName: "cafe-secret",
+		},
+	}
+
+	maxFails := 0
+	typeMeta, objectMeta := createTestVirtualServerMeta("cafe-ingress")
+	expected := &Result{
+		VirtualServers: []*conf_v1.VirtualServer{
+			{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Spec: conf_v1.VirtualServerSpec{
+					IngressClass: "nginx",
+					Host:         "cafe.example.com",
+					TLS: &conf_v1.TLS{
+						Secret: "cafe-secret",
+						Redirect: &conf_v1.TLSRedirect{
+							Enable: true,
+						},
+					},
+					Upstreams: []conf_v1.Upstream{
+						{
+							Name:                "tea-svc",
+							Service:             "tea-svc",
+							Port:                80,
+							MaxFails:            &maxFails,
+							ProxyConnectTimeout: "30s",
+						},
+						{
+							Name:                "coffee-svc",
+							Service:             "coffee-svc",
+							Port:                80,
+							MaxFails:            &maxFails,
+							ProxyConnectTimeout: "30s",
+							TLS: conf_v1.UpstreamTLS{
+								Enable: true,
+							},
+						},
+					},
+					Routes: []conf_v1.Route{
+						{
+							Path: "/tea",
+							Action: &conf_v1.Action{
+								Proxy: &conf_v1.ActionProxy{
+									Upstream:    "tea-svc",
+									RewritePath: "/",
+								},
+							},
+							LocationSnippets: "add_header my-test-header test-value;",
+						},
+						{
+							Path: "/coffee",
+							Action: &conf_v1.Action{
+								Pass: "coffee-svc",
+							},
+							LocationSnippets: "add_header my-test-header test-value;",
+						},
+					},
+				},
+			},
+		},
+		Warnings: []string{
+			"Ingress default/cafe-ingress: annotation nginx.org/hsts has no equivalent in VirtualServer resources and was not converted",
+		},
+	}
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{ing})
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("Convert() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertIngressWithMultipleHosts(t *testing.T) {
+	ing := createTestIngress("cafe-ingress", nil, "cafe.example.com", createTestPath("/tea", "tea-svc"))
+	ing.Spec.Rules = append(ing.Spec.Rules,
+		networking.IngressRule{
+			Host: "bar.example.com",
+		},
+		networking.IngressRule{
+			Host: "",
+		})
+	ing.Spec.DefaultBackend = &networking.IngressBackend{
+		Service: &networking.IngressServiceBackend{
+			Name: "default-svc",
+			Port: networking.ServiceBackendPort{
+				Number: 8080,
+			},
+		},
+	}
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{ing})
+
+	expectedWarnings := []string{
+		"Ingress default/cafe-ingress: a rule without a host can't be converted",
+	}
+	if diff := cmp.Diff(expectedWarnings, result.Warnings); diff != "" {
+		t.Errorf("Convert() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+
+	if len(result.VirtualServers) != 2 {
+		t.Fatalf("Convert() returned %d VirtualServers but expected 2", len(result.VirtualServers))
+	}
+
+	expectedNames := []string{"cafe-ingress-cafe-example-com", "cafe-ingress-bar-example-com"}
+	expectedRoutes := []int{2, 1}
+	for i, vs := range result.VirtualServers {
+		if vs.Name != expectedNames[i] {
+			t.Errorf("Convert() returned VirtualServer %s but expected %s", vs.Name, expectedNames[i])
+		}
+		if len(vs.Spec.Routes) != expectedRoutes[i] {
+			t.Errorf("Convert() returned VirtualServer %s with %d routes but expected %d", vs.Name, len(vs.Spec.Routes), expectedRoutes[i])
+		}
+		lastRoute := vs.Spec.Routes[len(vs.Spec.Routes)-1]
+		if lastRoute.Path != "/" || lastRoute.Action.Pass != "default-svc" {
+			t.Errorf("Convert() returned VirtualServer %s without the route for the default backend", vs.Name)
+		}
+	}
+}
+
+func TestConvertMergeableIngresses(t *testing.T) {
+	master := createTestIngress("cafe-master", map[string]string{
+		"nginx.org/mergeable-ingress-type": "master",
+		"nginx.org/proxy-hide-headers":     "X-Powered-By",
+		"nginx.org/proxy-read-timeout":     "60s",
+		"nginx.org/ssl-services":           "tea-svc",
+	}, "cafe.example.com")
+	teaMinion := createTestIngress("tea-minion", map[string]string{
+		"nginx.org/mergeable-ingress-type": "minion",
+		"nginx.org/policies":               "rate-limit",
+		"nginx.org/redirect-to-https":      "true",
+	}, "cafe.example.com", createTestPath("/tea", "tea-svc"), createTestPath("/green-tea", "green-tea-svc"))
+	coffeeMinion := createTestIngress("coffee-minion", map[string]string{
+		"nginx.org/mergeable-ingress-type": "minion",
+		"nginx.org/proxy-read-timeout":     "30s",
+	}, "cafe.example.com", createTestPath("/coffee", "coffee-svc"))
+	coffeeMinion.Namespace = "coffee"
+	orphanMinion := createTestIngress("orphan-minion", map[string]string{
+		"nginx.org/mergeable-ingress-type": "minion",
+	}, "bar.example.com", createTestPath("/bar", "bar-svc"))
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{master, teaMinion, coffeeMinion, orphanMinion})
+
+	expectedWarnings := []string{
+		"Ingress default/cafe-master: annotation nginx.org/ssl-services is ignored in a master Ingress and was not converted",
+		"Ingress default/tea-minion: annotation nginx.org/redirect-to-https is ignored in a minion Ingress and was not converted",
+		"Ingress coffee/coffee-minion: VirtualServerRoute coffee/coffee-minion is in a different namespace than VirtualServer default/cafe-master; a ReferenceGrant might be required",
+		"Ingress default/orphan-minion: no master Ingress found for the minion; the minion was not converted",
+	}
+	if diff := cmp.Diff(expectedWarnings, result.Warnings); diff != "" {
+		t.Errorf("Convert() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+
+	expectedRoutes := []conf_v1.Route{
+		{
+			Path:  "/tea",
+			Route: "default/tea-minion-1",
+		},
+		{
+			Path:  "/green-tea",
+			Route: "default/tea-minion-2",
+		},
+		{
+			Path:  "/coffee",
+			Route: "coffee/coffee-minion",
+		},
+	}
+	if len(result.VirtualServers) != 1 {
+		t.Fatalf("Convert() returned %d VirtualServers but expected 1", len(result.VirtualServers))
+	}
+	if diff := cmp.Diff(expectedRoutes, result.VirtualServers[0].Spec.Routes); diff != "" {
+		t.Errorf("Convert() returned unexpected routes (-want +got):\n%s", diff)
+	}
+
+	expectedTeaVSR := &conf_v1.VirtualServerRoute{
+		TypeMeta: virtualServerRouteTypeMeta,
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tea-minion-1",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerRouteSpec{
+			Host: "cafe.example.com",
+			Upstreams: []conf_v1.Upstream{
+				{
+					Name:             "tea-svc",
+					Service:          "tea-svc",
+					Port:             80,
+					ProxyReadTimeout: "60s",
+				},
+			},
+			Subroutes: []conf_v1.Route{
+				{
+					Path:     "/tea",
+					Policies: []conf_v1.PolicyReference{{Name: "rate-limit"}},
+					Action: &conf_v1.Action{
+						Proxy: &conf_v1.ActionProxy{
+							Upstream: "tea-svc",
+							ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+								Hide: []string{"X-Powered-By"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if len(result.VirtualServerRoutes) != 3 {
+		t.Fatalf("Convert() returned %d VirtualServerRoutes but expected 3", len(result.VirtualServerRoutes))
+	}
+	if diff := cmp.Diff(expectedTeaVSR, result.VirtualServerRoutes[0]); diff != "" {
+		t.Errorf("Convert() returned unexpected VirtualServerRoute (-want +got):\n%s", diff)
+	}
+	if timeout := result.VirtualServerRoutes[2].Spec.Upstreams[0].ProxyReadTimeout; timeout != "30s" {
+		t.Errorf("Convert() returned the read timeout %q for the coffee minion but expected %q", timeout, "30s")
+	}
+}
+
+func TestConvertMergeableIngressesWithMinionNamespaces(t *testing.T) {
+	master := createTestIngress("cafe-master", map[string]string{
+		"nginx.org/mergeable-ingress-type":              "master",
+		"nginx.org/mergeable-ingress-minion-namespaces": "tea",
+	}, "cafe.example.com")
+	coffeeMinion := createTestIngress("coffee-minion", map[string]string{
+		"nginx.org/mergeable-ingress-type": "minion",
+	}, "cafe.example.com", createTestPath("/coffee", "coffee-svc"))
+	coffeeMinion.Namespace = "coffee"
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{master, coffeeMinion})
+
+	expectedWarnings := []string{
+		"Ingress coffee/coffee-minion: namespace coffee is not allowed to contribute minions by Ingress master default/cafe-master; the minion was not converted",
+	}
+	if diff := cmp.Diff(expectedWarnings, result.Warnings); diff != "" {
+		t.Errorf("Convert() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+	if len(result.VirtualServerRoutes) != 0 {
+		t.Errorf("Convert() returned %d VirtualServerRoutes but expected 0", len(result.VirtualServerRoutes))
+	}
+}
+
+func TestConvertCanaryIngress(t *testing.T) {
+	ing := createTestIngress("cafe-ingress", nil, "cafe.example.com", createTestPath("/coffee", "coffee-svc"))
+	canary := createTestIngress("cafe-canary", map[string]string{
+		"nginx.org/canary":                 "true",
+		"nginx.org/canary-weight":          "20",
+		"nginx.org/canary-by-header":       "X-Canary",
+		"nginx.org/canary-by-header-value": "v2",
+	}, "cafe.example.com", createTestPath("/coffee", "coffee-v2-svc"), createTestPath("/tea", "tea-v2-svc"))
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{canary, ing})
+
+	primaryAction := &conf_v1.Action{Pass: "coffee-svc"}
+	canaryAction := &conf_v1.Action{Pass: "coffee-v2-svc"}
+	expectedRoutes := []conf_v1.Route{
+		{
+			Path: "/coffee",
+			Splits: []conf_v1.Split{
+				{
+					Weight: 80,
+					Action: primaryAction,
+				},
+				{
+					Weight: 20,
+					Action: canaryAction,
+				},
+			},
+			Matches: []conf_v1.Match{
+				{
+					Conditions: []conf_v1.Condition{
+						{
+							Header: "X-Canary",
+							Value:  "v2",
+						},
+					},
+					Action: canaryAction,
+				},
+			},
+		},
+	}
+	expectedWarnings := []string{
+		"Ingress default/cafe-canary: no regular Ingress found for the path /tea of host cafe.example.com of the canary; the path was not converted",
+	}
+
+	if len(result.VirtualServers) != 1 {
+		t.Fatalf("Convert() returned %d VirtualServers but expected 1", len(result.VirtualServers))
+	}
+	if diff := cmp.Diff(expectedRoutes, result.VirtualServers[0].Spec.Routes); diff != "" {
+		t.Errorf("Convert() returned unexpected routes (-want +got):\n%s", diff)
+	}
+	if len(result.VirtualServers[0].Spec.Upstreams) != 2 {
+		t.Errorf("Convert() returned %d upstreams but expected 2", len(result.VirtualServers[0].Spec.Upstreams))
+	}
+	if diff := cmp.Diff(expectedWarnings, result.Warnings); diff != "" {
+		t.Errorf("Convert() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertIngressWithPlusAnnotations(t *testing.T) {
+	ing := createTestIngress("cafe-ingress", map[string]string{
+		"nginx.com/jwt-key":                "cafe-jwk",
+		"nginx.com/jwt-realm":              "Cafe App",
+		"nginx.com/jwt-login-url":          "https://login.example.com",
+		"nginx.com/sticky-cookie-services": "serviceName=tea-svc srv_id expires=1h path=/tea httponly",
+		"nginx.com/slow-start":             "10s",
+	}, "cafe.example.com", createTestPath("/tea", "tea-svc"))
+
+	tests := []struct {
+		isPlus           bool
+		expectedPolicies int
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			isPlus:           true,
+			expectedPolicies: 1,
+			expectedWarnings: []string{
+				"Ingress default/cafe-ingress: annotation nginx.com/jwt-login-url has no equivalent in VirtualServer resources and was not converted",
+				"Ingress default/cafe-ingress: the JWT annotations were converted into the Policy default/cafe-ingress-jwt, which requires the -enable-preview-policies command-line argument",
+			},
+			msg: "NGINX Plus",
+		},
+		{
+			isPlus:           false,
+			expectedPolicies: 0,
+			expectedWarnings: []string{
+				"Ingress default/cafe-ingress: annotation nginx.com/jwt-key requires NGINX Plus and was not converted",
+				"Ingress default/cafe-ingress: annotation nginx.com/jwt-login-url requires NGINX Plus and was not converted",
+				"Ingress default/cafe-ingress: annotation nginx.com/jwt-realm requires NGINX Plus and was not converted",
+				"Ingress default/cafe-ingress: annotation nginx.com/slow-start requires NGINX Plus and was not converted",
+				"Ingress default/cafe-ingress: annotation nginx.com/sticky-cookie-services requires NGINX Plus and was not converted",
+			},
+			msg: "NGINX",
+		},
+	}
+
+	for _, test := range tests {
+		result := NewConverter(test.isPlus, false).Convert([]*networking.Ingress{ing})
+
+		if diff := cmp.Diff(test.expectedWarnings, result.Warnings); diff != "" {
+			t.Errorf("Convert() returned unexpected warnings for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if len(result.Policies) != test.expectedPolicies {
+			t.Errorf("Convert() returned %d Policies but expected %d for the case of %s", len(result.Policies), test.expectedPolicies, test.msg)
+		}
+		if len(result.VirtualServers) != 1 {
+			t.Fatalf("Convert() returned %d VirtualServers but expected 1 for the case of %s", len(result.VirtualServers), test.msg)
+		}
+
+		vs := result.VirtualServers[0]
+		if test.isPlus {
+			expectedCookie := &conf_v1.SessionCookie{
+				Enable:   true,
+				Name:     "srv_id",
+				Expires:  "1h",
+				Path:     "/tea",
+				HTTPOnly: true,
+			}
+			if diff := cmp.Diff(expectedCookie, vs.Spec.Upstreams[0].SessionCookie); diff != "" {
+				t.Errorf("Convert() returned unexpected session cookie (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]conf_v1.PolicyReference{{Name: "cafe-ingress-jwt"}}, vs.Spec.Policies); diff != "" {
+				t.Errorf("Convert() returned unexpected policies (-want +got):\n%s", diff)
+			}
+		} else if vs.Spec.Upstreams[0].SessionCookie != nil || len(vs.Spec.Policies) != 0 {
+			t.Errorf("Convert() converted the NGINX Plus annotations for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestConvertIngressWithInvalidConfiguration(t *testing.T) {
+	pathTypeExact := networking.PathTypeExact
+	exactPath := createTestPath("/coffee", "coffee-svc")
+	exactPath.PathType = &pathTypeExact
+	namedPortPath := createTestPath("/tea", "tea-svc")
+	namedPortPath.Backend.Service.Port = networking.ServiceBackendPort{Name: "http"}
+
+	ing := createTestIngress("cafe-ingress", map[string]string{
+		"nginx.org/proxy-read-timeout": "1 minute",
+		"nginx.org/keepalive":          "-1",
+		"nginx.org/redirect-to-https":  "true",
+	}, "cafe.example.com", exactPath, namedPortPath, exactPath)
+
+	result := NewConverter(false, false).Convert([]*networking.Ingress{ing})
+
+	expectedWarnings := []string{
+		`Ingress default/cafe-ingress: invalid value of annotation nginx.org/keepalive: got "-1": must be positive or zero; the annotation was not converted`,
+		`Ingress default/cafe-ingress: invalid value of annotation nginx.org/proxy-read-timeout: got "1 minute": invalid time string; the annotation was not converted`,
+		"Ingress default/cafe-ingress: the redirect to HTTPS for host cafe.example.com requires TLS in a VirtualServer and was not converted",
+		"Ingress default/cafe-ingress: path /tea of host cafe.example.com was not converted: the named port http of service tea-svc is not supported in a VirtualServer",
+		"Ingress default/cafe-ingress: path /coffee of host cafe.example.com is duplicated and was not converted",
+	}
+	if diff := cmp.Diff(expectedWarnings, result.Warnings); diff != "" {
+		t.Errorf("Convert() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+
+	expectedUpstreams := []conf_v1.Upstream{
+		{
+			Name:    "coffee-svc",
+			Service: "coffee-svc",
+			Port:    80,
+		},
+	}
+	expectedRoutes := []conf_v1.Route{
+		{
+			Path: "=/coffee",
+			Action: &conf_v1.Action{
+				Pass: "coffee-svc",
+			},
+		},
+	}
+
+	if len(result.VirtualServers) != 1 {
+		t.Fatalf("Convert() returned %d VirtualServers but expected 1", len(result.VirtualServers))
+	}
+	if diff := cmp.Diff(expectedUpstreams, result.VirtualServers[0].Spec.Upstreams); diff != "" {
+		t.Errorf("Convert() returned unexpected upstreams (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedRoutes, result.VirtualServers[0].Spec.Routes); diff != "" {
+		t.Errorf("Convert() returned unexpected routes (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertIngressWithPathRegex(t *testing.T) {
+	pathTypeImplementationSpecific := networking.PathTypeImplementationSpecific
+	pathTypePrefix := networking.PathTypePrefix
+	regexPath := createTestPath("/coffee/[a-z]+$", "coffee-svc")
+	regexPath.PathType = &pathTypeImplementationSpecific
+	prefixPath := createTestPath("/tea", "tea-svc")
+	prefixPath.PathType = &pathTypePrefix
+
+	tests := []struct {
+		pathRegex        string
+		expectedPath     string
+		expectedWarnings []string
+	}{
+		{
+			pathRegex:    "case_sensitive",
+			expectedPath: "~ /coffee/[a-z]+$",
+			expectedWarnings: []string{
+				"Ingress default/cafe-ingress: the rewrite of path /coffee/[a-z]+$ of host cafe.example.com was not converted, because the path is a regular expression",
+			},
+		},
+		{
+			pathRegex:    "case_insensitive",
+			expectedPath: "~* /coffee/[a-z]+$",
+			expectedWarnings: []string{
+				"Ingress default/cafe-ingress: the rewrite of path /coffee/[a-z]+$ of host cafe.example.com was not converted, because the path is a regular expression",
+			},
+		},
+		{
+			pathRegex:    "invalid",
+			expectedPath: "/coffee/[a-z]+$",
+			expectedWarnings: []string{
+				`Ingress default/cafe-ingress: invalid value of annotation nginx.org/path-regex: got "invalid": must be case_sensitive or case_insensitive; the annotation was not converted`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		ing := createTestIngress("cafe-ingress", map[string]string{
+			"nginx.org/path-regex": test.pathRegex,
+			"nginx.org/rewrites":   "serviceName=coffee-svc rewrite=/beans",
+		}, "cafe.example.com", regexPath, prefixPath)
+
+		result := NewConverter(false, false).Convert([]*networking.Ingress{ing})
+
+		if diff := cmp.Diff(test.expectedWarnings, result.Warnings); diff != "" {
+			t.Errorf("Convert() returned unexpected warnings for the path regex %q (-want +got):\n%s", test.pathRegex, diff)
+		}
+		if len(result.VirtualServers) != 1 {
+			t.Fatalf("Convert() returned %d VirtualServers but expected 1", len(result.VirtualServers))
+		}
+
+		routes := result.VirtualServers[0].Spec.Routes
+		if len(routes) != 2 {
+			t.Fatalf("Convert() returned %d routes but expected 2", len(routes))
+		}
+		if routes[0].Path != test.expectedPath {
+			t.Errorf("Convert() returned the path %q for the path regex %q but expected %q", routes[0].Path, test.pathRegex, test.expectedPath)
+		}
+		if routes[1].Path != "/tea" {
+			t.Errorf("Convert() returned the path %q for a prefix path but expected %q", routes[1].Path, "/tea")
+		}
+	}
+}
+
+func TestGetUpstreamNames(t *testing.T) {
+	backends := []serviceBackend{
+		{service: "tea-svc", port: 80},
+		{service: "coffee-svc", port: 80},
+		{service: "coffee-svc", port: 8080},
+	}
+	expected := map[serviceBackend]string{
+		{service: "tea-svc", port: 80}:      "tea-svc",
+		{service: "coffee-svc", port: 80}:   "coffee-svc-80",
+		{service: "coffee-svc", port: 8080}: "coffee-svc-8080",
+	}
+
+	result := getUpstreamNames(backends)
+	if diff := cmp.Diff(expected, result, cmp.AllowUnexported(serviceBackend{})); diff != "" {
+		t.Errorf("getUpstreamNames() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
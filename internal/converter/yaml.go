@@ -0,0 +1,258 @@
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	networking "k8s.io/api/networking/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ReadIngresses reads the Ingress resources from a stream of YAML or JSON documents. A document can also be a List
+// of resources, like the output of kubectl get ingress -o yaml. The resources other than networking.k8s.io/v1 Ingresses
+// are skipped with a warning.
+func ReadIngresses(r io.Reader) ([]*networking.Ingress, []string, error) {
+	var ingresses []*networking.Ingress
+	var warnings []string
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for {
+		var obj map[string]interface{}
+		err := decoder.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding a document: %w", err)
+		}
+		if obj == nil {
+			continue
+		}
+
+		objs := []map[string]interface{}{obj}
+		if obj["kind"] == "List" {
+			objs = nil
+			items, _ := obj["items"].([]interface{})
+			for _, item := range items {
+				if o, ok := item.(map[string]interface{}); ok {
+					objs = append(objs, o)
+				}
+			}
+		}
+
+		for _, o := range objs {
+			if o["apiVersion"] != "networking.k8s.io/v1" || o["kind"] != "Ingress" {
+				warnings = append(warnings, fmt.Sprintf("skipped %v %v: only networking.k8s.io/v1 Ingress resources are converted", o["apiVersion"], o["kind"]))
+				continue
+			}
+
+			ing, err := decodeIngress(o)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			ingresses = append(ingresses, ing)
+		}
+	}
+
+	return ingresses, warnings, nil
+}
+
+func decodeIngress(obj map[string]interface{}) (*networking.Ingress, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding an Ingress: %w", err)
+	}
+
+	var ing networking.Ingress
+	err = json.Unmarshal(data, &ing)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding an Ingress: %w", err)
+	}
+
+	return &ing, nil
+}
+
+// WriteResources writes the resources of the result to w as a stream of YAML documents.
+// The fields with empty values are omitted.
+func WriteResources(w io.Writer, result *Result) error {
+	var resources []interface{}
+	for _, p := range result.Policies {
+		resources = append(resources, p)
+	}
+	for _, vs := range result.VirtualServers {
+		resources = append(resources, vs)
+	}
+	for _, vsr := range result.VirtualServerRoutes {
+		resources = append(resources, vsr)
+	}
+
+	for i, res := range resources {
+		value, _ := toYAMLValue(reflect.ValueOf(res))
+
+		data, err := yaml.Marshal(moveFieldToFront(value.(yaml.MapSlice), "apiVersion"))
+		if err != nil {
+			return fmt.Errorf("error encoding a resource: %w", err)
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveFieldToFront moves the field with the key to the front of the fields.
+func moveFieldToFront(fields yaml.MapSlice, key string) yaml.MapSlice {
+	for i, f := range fields {
+		if f.Key == key {
+			result := yaml.MapSlice{f}
+			result = append(result, fields[:i]...)
+			return append(result, fields[i+1:]...)
+		}
+	}
+	return fields
+}
+
+// toYAMLValue converts a value into a value for the YAML encoder that keeps the order of the fields of the structs
+// and omits the fields with empty values. A field with a pointer is kept even if it points to an empty value.
+// It returns false if the value is empty.
+func toYAMLValue(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false
+		}
+		return toYAMLValueOrZero(v.Elem()), true
+	case reflect.Struct:
+		if _, ok := v.Interface().(json.Marshaler); ok {
+			return jsonToYAMLValue(v)
+		}
+		return structToYAMLValue(v)
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		var values []interface{}
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, toYAMLValueOrZero(v.Index(i)))
+		}
+		return values, true
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		values := make(map[string]interface{})
+		iter := v.MapRange()
+		for iter.Next() {
+			values[fmt.Sprint(iter.Key().Interface())] = toYAMLValueOrZero(iter.Value())
+		}
+		return values, true
+	default:
+		if v.IsZero() {
+			return nil, false
+		}
+		return v.Interface(), true
+	}
+}
+
+// toYAMLValueOrZero converts a value like toYAMLValue but keeps an empty value.
+func toYAMLValueOrZero(v reflect.Value) interface{} {
+	if value, ok := toYAMLValue(v); ok {
+		return value
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return nil
+	case reflect.Struct:
+		return yaml.MapSlice{}
+	default:
+		return v.Interface()
+	}
+}
+
+func structToYAMLValue(v reflect.Value) (interface{}, bool) {
+	var fields yaml.MapSlice
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, options := parseJSONTag(f)
+		if name == "-" {
+			continue
+		}
+
+		value, ok := toYAMLValue(v.Field(i))
+		if !ok {
+			continue
+		}
+
+		if options == "inline" || (f.Anonymous && name == "") {
+			if inlined, ok := value.(yaml.MapSlice); ok {
+				fields = append(fields, inlined...)
+			}
+			continue
+		}
+
+		fields = append(fields, yaml.MapItem{Key: name, Value: value})
+	}
+
+	if len(fields) == 0 {
+		return yaml.MapSlice{}, false
+	}
+
+	return fields, true
+}
+
+func parseJSONTag(f reflect.StructField) (string, string) {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		if f.Anonymous {
+			return "", ""
+		}
+		return f.Name, ""
+	}
+
+	parts := strings.SplitN(tag, ",", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// jsonToYAMLValue converts a value with a custom JSON encoding, like a timestamp.
+func jsonToYAMLValue(v reflect.Value) (interface{}, bool) {
+	if v.IsZero() {
+		return nil, false
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
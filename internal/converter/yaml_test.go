@@ -0,0 +1,244 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+func TestReadIngresses(t *testing.T) {
+	input := `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: cafe-ingress
+  namespace: default
+spec:
+  rules:
+  - host: cafe.example.com
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: tea-svc
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: tea-ingress
+- apiVersion: extensions/v1beta1
+  kind: Ingress
+  metadata:
+    name: coffee-ingress
+`
+
+	ingresses, warnings, err := ReadIngresses(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadIngresses() returned unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, ing := range ingresses {
+		names = append(names, ing.Name)
+	}
+
+	expectedNames := []string{"cafe-ingress", "tea-ingress"}
+	if diff := cmp.Diff(expectedNames, names); diff != "" {
+		t.Errorf("ReadIngresses() returned unexpected Ingresses (-want +got):\n%s", diff)
+	}
+	if host := ingresses[0].Spec.Rules[0].Host; host != "cafe.example.com" {
+		t.Errorf("ReadIngresses() returned the host %q but expected %q", host, "cafe.example.com")
+	}
+
+	expectedWarnings := []string{
+		"skipped v1 Service: only networking.k8s.io/v1 Ingress resources are converted",
+		"skipped extensions/v1beta1 Ingress: only networking.k8s.io/v1 Ingress resources are converted",
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("ReadIngresses() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadIngressesFails(t *testing.T) {
+	_, _, err := ReadIngresses(strings.NewReader("apiVersion: [networking.k8s.io/v1"))
+	if err == nil {
+		t.Error("ReadIngresses() returned no error for invalid YAML")
+	}
+}
+
+func TestWriteResources(t *testing.T) {
+	maxFails := 0
+	result := &Result{
+		Policies: []*conf_v1.Policy{
+			{
+				TypeMeta: policyTypeMeta,
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "cafe-ingress-jwt",
+					Namespace: "default",
+				},
+				Spec: conf_v1.PolicySpec{
+					JWTAuth: &conf_v1.JWTAuth{
+						Realm:  "Cafe App",
+						Secret: "cafe-jwk",
+					},
+				},
+			},
+		},
+		VirtualServers: []*conf_v1.VirtualServer{
+			{
+				TypeMeta: virtualServerTypeMeta,
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "cafe-ingress",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1.Upstream{
+						{
+							Name:     "tea-svc",
+							Service:  "tea-svc",
+							Port:     80,
+							MaxFails: &maxFails,
+						},
+					},
+					Routes: []conf_v1.Route{
+						{
+							Path: "/tea",
+							Action: &conf_v1.Action{
+								Pass: "tea-svc",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `apiVersion: k8s.nginx.org/v1
+kind: Policy
+metadata:
+  name: cafe-ingress-jwt
+  namespace: default
+spec:
+  jwt:
+    realm: Cafe App
+    secret: cafe-jwk
+---
+apiVersion: k8s.nginx.org/v1
+kind: VirtualServer
+metadata:
+  name: cafe-ingress
+  namespace: default
+spec:
+  host: cafe.example.com
+  upstreams:
+  - name: tea-svc
+    service: tea-svc
+    port: 80
+    max-fails: 0
+  routes:
+  - path: /tea
+    action:
+      pass: tea-svc
+`
+
+	var buf bytes.Buffer
+	err := WriteResources(&buf, result)
+	if err != nil {
+		t.Fatalf("WriteResources() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("WriteResources() returned unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteResourcesRoundTrip(t *testing.T) {
+	master := createTestIngress("cafe-master", map[string]string{
+		"nginx.org/mergeable-ingress-type": "master",
+		"nginx.com/jwt-key":                "cafe-jwk",
+		"nginx.com/jwt-realm":              "Cafe App",
+		"nginx.org/proxy-read-timeout":     "60s",
+	}, "cafe.example.com")
+	minion := createTestIngress("tea-minion", map[string]string{
+		"nginx.org/mergeable-ingress-type": "minion",
+		"nginx.org/max-fails":              "0",
+		"nginx.com/sticky-cookie-services": "serviceName=tea-svc srv_id expires=1h path=/tea httponly",
+		"nginx.org/proxy-buffering":        "false",
+		"nginx.org/client-max-body-size":   "2m",
+		"nginx.org/location-snippets":      "add_header X-Location tea;",
+		"nginx.org/rewrites":               "serviceName=tea-svc rewrite=/",
+		"nginx.org/keepalive":              "32",
+		"nginx.org/lb-method":              "least_conn",
+		"nginx.org/proxy-connect-timeout":  "10s",
+		"nginx.org/proxy-send-timeout":     "10s",
+	}, "cafe.example.com", createTestPath("/tea", "tea-svc"))
+	ing := createTestIngress("bar-ingress", nil, "bar.example.com", createTestPath("/", "bar-svc"))
+
+	result := NewConverter(true, false).Convert([]*networking.Ingress{master, minion, ing})
+	if len(result.Policies) == 0 || len(result.VirtualServers) == 0 || len(result.VirtualServerRoutes) == 0 {
+		t.Fatalf("Convert() returned %d Policies, %d VirtualServers and %d VirtualServerRoutes, expected at least one of each",
+			len(result.Policies), len(result.VirtualServers), len(result.VirtualServerRoutes))
+	}
+
+	var buf bytes.Buffer
+	err := WriteResources(&buf, result)
+	if err != nil {
+		t.Fatalf("WriteResources() returned unexpected error: %v", err)
+	}
+
+	readResult := &Result{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(&buf, 4096)
+	for {
+		var obj map[string]interface{}
+		err := decoder.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to decode the output of WriteResources(): %v", err)
+		}
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to encode a resource: %v", err)
+		}
+
+		switch obj["kind"] {
+		case "Policy":
+			var pol conf_v1.Policy
+			err = json.Unmarshal(data, &pol)
+			readResult.Policies = append(readResult.Policies, &pol)
+		case "VirtualServer":
+			var vs conf_v1.VirtualServer
+			err = json.Unmarshal(data, &vs)
+			readResult.VirtualServers = append(readResult.VirtualServers, &vs)
+		case "VirtualServerRoute":
+			var vsr conf_v1.VirtualServerRoute
+			err = json.Unmarshal(data, &vsr)
+			readResult.VirtualServerRoutes = append(readResult.VirtualServerRoutes, &vsr)
+		default:
+			t.Fatalf("WriteResources() wrote an unexpected resource of the kind %v", obj["kind"])
+		}
+		if err != nil {
+			t.Fatalf("failed to decode a %v: %v", obj["kind"], err)
+		}
+	}
+
+	result.Warnings = nil
+	if diff := cmp.Diff(result, readResult, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("WriteResources() wrote resources that are read differently (-want +got):\n%s", diff)
+	}
+}
@@ -104,6 +104,19 @@ type Configuration interface {
 	DeletePolicy(key string) (changes []Change, problems []Problem)
 	DeleteLogConf(key string) (changes []Change, problems []Problem)
 	DeleteUserSig(key string) (change UserSigChange, problems []Problem)
+	GetAppResourceStatuses() []ResourceStatus
+}
+
+// ResourceStatus describes the state of an App Protect resource in the App Protect Configuration.
+type ResourceStatus struct {
+	// Kind is the kind of the resource. For example, APPolicy.
+	Kind string
+	// Key is the namespace/name of the resource.
+	Key string
+	// IsValid tells if the resource is valid.
+	IsValid bool
+	// ErrorMsg explains why the resource is invalid.
+	ErrorMsg string
 }
 
 // ConfigurationImpl holds representations of App Protect cluster resources
@@ -416,6 +429,34 @@ func (ci *ConfigurationImpl) GetAppResource(kind, key string) (*unstructured.Uns
 	return nil, fmt.Errorf("Unknown App Protect resource kind %s", kind)
 }
 
+// GetAppResourceStatuses returns the statuses of all App Protect resources sorted by kind and key.
+func (ci *ConfigurationImpl) GetAppResourceStatuses() []ResourceStatus {
+	var statuses []ResourceStatus
+
+	for key, pol := range ci.Policies {
+		statuses = append(statuses, ResourceStatus{Kind: PolicyGVK.Kind, Key: key, IsValid: pol.IsValid, ErrorMsg: pol.ErrorMsg})
+	}
+	for key, logConf := range ci.LogConfs {
+		statuses = append(statuses, ResourceStatus{Kind: LogConfGVK.Kind, Key: key, IsValid: logConf.IsValid, ErrorMsg: logConf.ErrorMsg})
+	}
+	for key, sig := range ci.UserSigs {
+		statuses = append(statuses, ResourceStatus{Kind: UserSigGVK.Kind, Key: key, IsValid: sig.IsValid, ErrorMsg: sig.ErrorMsg})
+	}
+
+	sortResourceStatuses(statuses)
+
+	return statuses
+}
+
+func sortResourceStatuses(statuses []ResourceStatus) {
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Kind == statuses[j].Kind {
+			return statuses[i].Key < statuses[j].Key
+		}
+		return statuses[i].Kind < statuses[j].Kind
+	})
+}
+
 // DeletePolicy deletes an App Protect Policy from App Protect Configuration
 func (ci *ConfigurationImpl) DeletePolicy(key string) (changes []Change, problems []Problem) {
 	if _, has := ci.Policies[key]; has {
@@ -619,3 +660,19 @@ func (fc *FakeConfiguration) DeleteLogConf(key string) (changes []Change, proble
 func (fc *FakeConfiguration) DeleteUserSig(key string) (change UserSigChange, problems []Problem) {
 	return change, problems
 }
+
+// GetAppResourceStatuses is a fake implementation of GetAppResourceStatuses.
+func (fc *FakeConfiguration) GetAppResourceStatuses() []ResourceStatus {
+	var statuses []ResourceStatus
+
+	for key, pol := range fc.Policies {
+		statuses = append(statuses, ResourceStatus{Kind: PolicyGVK.Kind, Key: key, IsValid: pol.IsValid, ErrorMsg: pol.ErrorMsg})
+	}
+	for key, logConf := range fc.LogConfs {
+		statuses = append(statuses, ResourceStatus{Kind: LogConfGVK.Kind, Key: key, IsValid: logConf.IsValid, ErrorMsg: logConf.ErrorMsg})
+	}
+
+	sortResourceStatuses(statuses)
+
+	return statuses
+}
@@ -98,8 +98,10 @@ type IngressConfiguration struct {
 	ValidHosts map[string]bool
 	// Warnings includes all the warnings for the resource.
 	Warnings []string
-	// ChildWarnings includes the warnings of the minions. The key is the namespace/name.
+	// ChildWarnings includes the warnings of the minions or the canaries. The key is the namespace/name.
 	ChildWarnings map[string][]string
+	// Canaries contains the canary Ingresses if the Ingress is a regular Ingress.
+	Canaries []*CanaryConfiguration
 }
 
 // NewRegularIngressConfiguration creates an IngressConfiguration from an Ingress resource.
@@ -173,6 +175,20 @@ func (ic *IngressConfiguration) IsEqual(resource Resource) bool {
 		}
 	}
 
+	if len(ic.Canaries) != len(ingConfig.Canaries) {
+		return false
+	}
+
+	for i := range ic.Canaries {
+		if !compareObjectMetasWithAnnotations(&ic.Canaries[i].Ingress.ObjectMeta, &ingConfig.Canaries[i].Ingress.ObjectMeta) {
+			return false
+		}
+
+		if !reflect.DeepEqual(ic.Canaries[i].ValidPaths, ingConfig.Canaries[i].ValidPaths) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -194,11 +210,35 @@ func NewMinionConfiguration(ing *networking.Ingress) *MinionConfiguration {
 	}
 }
 
+// CanaryConfiguration holds a canary Ingress resource.
+type CanaryConfiguration struct {
+	// Ingress is the canary Ingress.
+	Ingress *networking.Ingress
+	// ValidPaths marks the paths of the canary Ingress as valid (true). The key is configs.CanaryPathKey(host, path).
+	// A path is valid if the regular Ingress has the same host and path, and the path is not taken by another canary.
+	ValidPaths map[string]bool
+}
+
+// NewCanaryConfiguration creates a new CanaryConfiguration.
+func NewCanaryConfiguration(ing *networking.Ingress) *CanaryConfiguration {
+	return &CanaryConfiguration{
+		Ingress:    ing,
+		ValidPaths: make(map[string]bool),
+	}
+}
+
 // VirtualServerConfiguration holds a VirtualServer along with its VirtualServerRoutes.
 type VirtualServerConfiguration struct {
 	VirtualServer       *conf_v1.VirtualServer
 	VirtualServerRoutes []*conf_v1.VirtualServerRoute
 	Warnings            []string
+	// DefaultPolicies holds the keys of the default Policies applied to the VirtualServer.
+	DefaultPolicies []string
+	// VirtualServerRouteDefaultPolicies holds the keys of the default Policies applied to the VirtualServerRoutes by their keys.
+	VirtualServerRouteDefaultPolicies map[string][]string
+	// InheritedSubroutes holds the paths of the subroutes that inherited the policies of the VirtualServer route along with the
+	// default Policies by the keys of their VirtualServerRoutes. The inherited policies are referenced by the VirtualServer.
+	InheritedSubroutes map[string][]string
 }
 
 // NewVirtualServerConfiguration creates a VirtualServerConfiguration.
@@ -253,7 +293,11 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 		}
 	}
 
-	return true
+	if !reflect.DeepEqual(vsc.DefaultPolicies, vsConfig.DefaultPolicies) {
+		return false
+	}
+
+	return reflect.DeepEqual(vsc.VirtualServerRouteDefaultPolicies, vsConfig.VirtualServerRouteDefaultPolicies)
 }
 
 // TransportServerConfiguration holds a TransportServer resource.
@@ -329,6 +373,16 @@ type Configuration struct {
 
 	globalConfiguration *conf_v1alpha1.GlobalConfiguration
 
+	// only valid HostPolicies are stored
+	hostPolicies    map[string]*conf_v1alpha1.HostPolicy
+	hostPolicyRules *hostPolicyRules
+
+	// only valid ReferenceGrants are stored
+	referenceGrants map[string]*conf_v1alpha1.ReferenceGrant
+
+	// only valid DefaultPolicies with the matching IngressClass are stored
+	defaultPolicies map[string]*conf_v1alpha1.DefaultPolicy
+
 	hostProblems     map[string]ConfigurationProblem
 	listenerProblems map[string]ConfigurationProblem
 
@@ -344,10 +398,11 @@ type Configuration struct {
 	appPolicyReferenceChecker  *appProtectResourceReferenceChecker
 	appLogConfReferenceChecker *appProtectResourceReferenceChecker
 
-	isPlus                  bool
-	appProtectEnabled       bool
-	internalRoutesEnabled   bool
-	isTLSPassthroughEnabled bool
+	isPlus                   bool
+	appProtectEnabled        bool
+	internalRoutesEnabled    bool
+	isTLSPassthroughEnabled  bool
+	isReferenceGrantsEnabled bool
 
 	lock sync.RWMutex
 }
@@ -362,6 +417,7 @@ func NewConfiguration(
 	globalConfigurationValidator *validation.GlobalConfigurationValidator,
 	transportServerValidator *validation.TransportServerValidator,
 	isTLSPassthroughEnabled bool,
+	isReferenceGrantsEnabled bool,
 ) *Configuration {
 	return &Configuration{
 		hosts:                        make(map[string]Resource),
@@ -370,6 +426,9 @@ func NewConfiguration(
 		virtualServers:               make(map[string]*conf_v1.VirtualServer),
 		virtualServerRoutes:          make(map[string]*conf_v1.VirtualServerRoute),
 		transportServers:             make(map[string]*conf_v1alpha1.TransportServer),
+		hostPolicies:                 make(map[string]*conf_v1alpha1.HostPolicy),
+		referenceGrants:              make(map[string]*conf_v1alpha1.ReferenceGrant),
+		defaultPolicies:              make(map[string]*conf_v1alpha1.DefaultPolicy),
 		hostProblems:                 make(map[string]ConfigurationProblem),
 		hasCorrectIngressClass:       hasCorrectIngressClass,
 		virtualServerValidator:       virtualServerValidator,
@@ -385,6 +444,7 @@ func NewConfiguration(
 		appProtectEnabled:            appProtectEnabled,
 		internalRoutesEnabled:        internalRoutesEnabled,
 		isTLSPassthroughEnabled:      isTLSPassthroughEnabled,
+		isReferenceGrantsEnabled:     isReferenceGrantsEnabled,
 	}
 }
 
@@ -605,6 +665,44 @@ func (c *Configuration) GetGlobalConfiguration() *conf_v1alpha1.GlobalConfigurat
 	return c.globalConfiguration
 }
 
+// AddOrUpdateHostPolicy adds or updates the HostPolicy.
+// An invalid HostPolicy is removed from the Configuration, and the validation error is returned.
+func (c *Configuration) AddOrUpdateHostPolicy(hp *conf_v1alpha1.HostPolicy) ([]ResourceChange, []ConfigurationProblem, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := hp.Name
+
+	validationErr := validation.ValidateHostPolicy(hp)
+	if validationErr != nil {
+		delete(c.hostPolicies, key)
+	} else {
+		c.hostPolicies[key] = hp
+	}
+
+	c.hostPolicyRules = newHostPolicyRules(c.hostPolicies)
+	changes, problems := c.rebuildHosts()
+
+	return changes, problems, validationErr
+}
+
+// DeleteHostPolicy deletes the HostPolicy by its name.
+func (c *Configuration) DeleteHostPolicy(name string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, exists := c.hostPolicies[name]
+	if !exists {
+		return nil, nil
+	}
+
+	delete(c.hostPolicies, name)
+
+	c.hostPolicyRules = newHostPolicyRules(c.hostPolicies)
+
+	return c.rebuildHosts()
+}
+
 // AddOrUpdateTransportServer adds or updates the TransportServer.
 func (c *Configuration) AddOrUpdateTransportServer(ts *conf_v1alpha1.TransportServer) ([]ResourceChange, []ConfigurationProblem) {
 	c.lock.Lock()
@@ -870,8 +968,20 @@ func (c *Configuration) findResourcesForResourceReference(namespace string, name
 				continue
 			}
 
+			found := false
 			for _, fm := range impl.Minions {
 				if checker.IsReferencedByMinion(namespace, name, fm.Ingress) {
+					result = append(result, r)
+					found = true
+					break
+				}
+			}
+			if found {
+				continue
+			}
+
+			for _, cc := range impl.Canaries {
+				if checker.IsReferencedByCanary(namespace, name, cc.Ingress) {
 					result = append(result, r)
 					break
 				}
@@ -940,6 +1050,7 @@ func (c *Configuration) rebuildHosts() ([]ResourceChange, []ConfigurationProblem
 
 	c.addProblemsForResourcesWithoutActiveHost(newResources, newProblems)
 	c.addProblemsForOrphanMinions(newProblems)
+	c.addProblemsForOrphanCanaries(newProblems)
 	c.addProblemsForOrphanOrIgnoredVsrs(newProblems)
 
 	newOrUpdatedProblems := detectChangesInProblems(newProblems, c.hostProblems)
@@ -958,8 +1069,8 @@ func updateActiveHostsForIngresses(hosts map[string]Resource, resources map[stri
 		}
 
 		for _, rule := range ingConfig.Ingress.Spec.Rules {
-			res := hosts[rule.Host]
-			ingConfig.ValidHosts[rule.Host] = res.GetKeyWithKind() == r.GetKeyWithKind()
+			res, exists := hosts[rule.Host]
+			ingConfig.ValidHosts[rule.Host] = exists && res.GetKeyWithKind() == r.GetKeyWithKind()
 		}
 	}
 }
@@ -1022,18 +1133,34 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 				}
 			}
 			if !atLeastOneValidHost {
+				message := "All hosts are taken by other resources"
+				if !c.isAnyIngressHostAllowed(impl.Ingress) {
+					message = fmt.Sprintf("None of the hosts are allowed in namespace %s by HostPolicies", impl.Ingress.Namespace)
+				}
+
 				p := ConfigurationProblem{
 					Object:  impl.Ingress,
 					IsError: false,
 					Reason:  "Rejected",
-					Message: "All hosts are taken by other resources",
+					Message: message,
 				}
 				problems[r.GetKeyWithKind()] = p
 			}
 		case *VirtualServerConfiguration:
-			res := c.hosts[impl.VirtualServer.Spec.Host]
+			if !c.hostPolicyRules.isAllowed(impl.VirtualServer.Spec.Host, impl.VirtualServer.Namespace) {
+				p := ConfigurationProblem{
+					Object:  impl.VirtualServer,
+					IsError: false,
+					Reason:  "Rejected",
+					Message: fmt.Sprintf("Host %s is not allowed in namespace %s by HostPolicy", impl.VirtualServer.Spec.Host, impl.VirtualServer.Namespace),
+				}
+				problems[r.GetKeyWithKind()] = p
+				continue
+			}
+
+			res, exists := c.hosts[impl.VirtualServer.Spec.Host]
 
-			if res.GetKeyWithKind() != r.GetKeyWithKind() {
+			if !exists || res.GetKeyWithKind() != r.GetKeyWithKind() {
 				p := ConfigurationProblem{
 					Object:  impl.VirtualServer,
 					IsError: false,
@@ -1043,9 +1170,20 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 				problems[r.GetKeyWithKind()] = p
 			}
 		case *TransportServerConfiguration:
-			res := c.hosts[impl.TransportServer.Spec.Host]
+			if !c.hostPolicyRules.isAllowed(impl.TransportServer.Spec.Host, impl.TransportServer.Namespace) {
+				p := ConfigurationProblem{
+					Object:  impl.TransportServer,
+					IsError: false,
+					Reason:  "Rejected",
+					Message: fmt.Sprintf("Host %s is not allowed in namespace %s by HostPolicy", impl.TransportServer.Spec.Host, impl.TransportServer.Namespace),
+				}
+				problems[r.GetKeyWithKind()] = p
+				continue
+			}
+
+			res, exists := c.hosts[impl.TransportServer.Spec.Host]
 
-			if res.GetKeyWithKind() != r.GetKeyWithKind() {
+			if !exists || res.GetKeyWithKind() != r.GetKeyWithKind() {
 				p := ConfigurationProblem{
 					Object:  impl.TransportServer,
 					IsError: false,
@@ -1058,6 +1196,15 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 	}
 }
 
+func (c *Configuration) isAnyIngressHostAllowed(ing *networking.Ingress) bool {
+	for _, rule := range ing.Spec.Rules {
+		if c.hostPolicyRules.isAllowed(rule.Host, ing.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Configuration) addProblemsForOrphanMinions(problems map[string]ConfigurationProblem) {
 	for _, key := range getSortedIngressKeys(c.ingresses) {
 		ing := c.ingresses[key]
@@ -1066,6 +1213,18 @@ func (c *Configuration) addProblemsForOrphanMinions(problems map[string]Configur
 			continue
 		}
 
+		if !c.hostPolicyRules.isAllowed(ing.Spec.Rules[0].Host, ing.Namespace) {
+			p := ConfigurationProblem{
+				Object:  ing,
+				IsError: false,
+				Reason:  "Rejected",
+				Message: fmt.Sprintf("Host %s is not allowed in namespace %s by HostPolicy", ing.Spec.Rules[0].Host, ing.Namespace),
+			}
+			k := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
+			problems[k] = p
+			continue
+		}
+
 		r, exists := c.hosts[ing.Spec.Rules[0].Host]
 		ingressConf, ok := r.(*IngressConfiguration)
 
@@ -1078,10 +1237,71 @@ func (c *Configuration) addProblemsForOrphanMinions(problems map[string]Configur
 			}
 			k := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
 			problems[k] = p
+			continue
+		}
+
+		if !configs.IsMinionNamespaceAllowed(ingressConf.Ingress, ing.Namespace) {
+			p := ConfigurationProblem{
+				Object:  ing,
+				IsError: false,
+				Reason:  "Rejected",
+				Message: fmt.Sprintf("Namespace %s is not allowed to contribute minions by Ingress master %s", ing.Namespace, getResourceKey(&ingressConf.Ingress.ObjectMeta)),
+			}
+			k := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
+			problems[k] = p
 		}
 	}
 }
 
+func (c *Configuration) addProblemsForOrphanCanaries(problems map[string]ConfigurationProblem) {
+	for _, key := range getSortedIngressKeys(c.ingresses) {
+		ing := c.ingresses[key]
+
+		if !isCanary(ing) {
+			continue
+		}
+
+		if !c.isCanaryOfAnyIngress(ing) {
+			p := ConfigurationProblem{
+				Object:  ing,
+				IsError: false,
+				Reason:  "NoPrimaryIngressFound",
+				Message: "None of the paths of the canary match a path of a valid Ingress in the same namespace",
+			}
+			k := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
+			problems[k] = p
+		}
+	}
+}
+
+func (c *Configuration) isCanaryOfAnyIngress(canary *networking.Ingress) bool {
+	for _, rule := range canary.Spec.Rules {
+		r, exists := c.hosts[rule.Host]
+		if !exists {
+			continue
+		}
+
+		ingressConf, ok := r.(*IngressConfiguration)
+		if !ok {
+			continue
+		}
+
+		for _, cc := range ingressConf.Canaries {
+			if cc.Ingress.Namespace != canary.Namespace || cc.Ingress.Name != canary.Name {
+				continue
+			}
+
+			for _, valid := range cc.ValidPaths {
+				if valid {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 func (c *Configuration) addProblemsForOrphanOrIgnoredVsrs(problems map[string]ConfigurationProblem) {
 	for _, key := range getSortedVirtualServerRouteKeys(c.virtualServerRoutes) {
 		vsr := c.virtualServerRoutes[key]
@@ -1261,25 +1481,33 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 
 	// Step 1 - Build hosts from Ingress resources
 
+	canaryKeys := c.buildCanaryKeysIndex()
+
 	for _, key := range getSortedIngressKeys(c.ingresses) {
 		ing := c.ingresses[key]
 
-		if isMinion(ing) {
+		if isMinion(ing) || isCanary(ing) {
 			continue
 		}
 
 		var resource *IngressConfiguration
 
 		if isMaster(ing) {
-			minions, childWarnings := c.buildMinionConfigs(ing.Spec.Rules[0].Host)
+			minions, childWarnings := c.buildMinionConfigs(ing)
 			resource = NewMasterIngressConfiguration(ing, minions, childWarnings)
 		} else {
 			resource = NewRegularIngressConfiguration(ing)
+			resource.Canaries, resource.ChildWarnings = c.buildCanaryConfigs(ing, canaryKeys)
 		}
 
 		newResources[resource.GetKeyWithKind()] = resource
 
 		for _, rule := range ing.Spec.Rules {
+			if !c.hostPolicyRules.isAllowed(rule.Host, ing.Namespace) {
+				resource.AddWarning(fmt.Sprintf("host %s is not allowed in namespace %s by HostPolicy", rule.Host, ing.Namespace))
+				continue
+			}
+
 			holder, exists := newHosts[rule.Host]
 			if !exists {
 				newHosts[rule.Host] = resource
@@ -1303,10 +1531,21 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 		vs := c.virtualServers[key]
 
 		vsrs, warnings := c.buildVirtualServerRoutes(vs)
-		resource := NewVirtualServerConfiguration(vs, vsrs, warnings)
+
+		effectiveVS, vsrs, vsDefaults, vsrDefaults, inheritedSubroutes := c.applyDefaultPolicies(vs, vsrs)
+
+		warnings = append(warnings, c.getPolicyReferenceWarnings(effectiveVS, vsrs, inheritedSubroutes)...)
+		resource := NewVirtualServerConfiguration(effectiveVS, vsrs, warnings)
+		resource.DefaultPolicies = vsDefaults
+		resource.VirtualServerRouteDefaultPolicies = vsrDefaults
+		resource.InheritedSubroutes = inheritedSubroutes
 
 		newResources[resource.GetKeyWithKind()] = resource
 
+		if !c.hostPolicyRules.isAllowed(vs.Spec.Host, vs.Namespace) {
+			continue
+		}
+
 		holder, exists := newHosts[vs.Spec.Host]
 		if !exists {
 			newHosts[vs.Spec.Host] = resource
@@ -1336,6 +1575,10 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 			resource := NewTransportServerConfiguration(ts)
 			newResources[resource.GetKeyWithKind()] = resource
 
+			if !c.hostPolicyRules.isAllowed(ts.Spec.Host, ts.Namespace) {
+				continue
+			}
+
 			holder, exists := newHosts[ts.Spec.Host]
 			if !exists {
 				newHosts[ts.Spec.Host] = resource
@@ -1356,10 +1599,11 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 	return newHosts, newResources
 }
 
-func (c *Configuration) buildMinionConfigs(masterHost string) ([]*MinionConfiguration, map[string][]string) {
+func (c *Configuration) buildMinionConfigs(master *networking.Ingress) ([]*MinionConfiguration, map[string][]string) {
 	var minionConfigs []*MinionConfiguration
 	childWarnings := make(map[string][]string)
 	paths := make(map[string]*MinionConfiguration)
+	masterHost := master.Spec.Rules[0].Host
 
 	for _, minionKey := range getSortedIngressKeys(c.ingresses) {
 		ingress := c.ingresses[minionKey]
@@ -1372,6 +1616,14 @@ func (c *Configuration) buildMinionConfigs(masterHost string) ([]*MinionConfigur
 			continue
 		}
 
+		if !c.hostPolicyRules.isAllowed(masterHost, ingress.Namespace) {
+			continue
+		}
+
+		if !configs.IsMinionNamespaceAllowed(master, ingress.Namespace) {
+			continue
+		}
+
 		minionConfig := NewMinionConfiguration(ingress)
 
 		for _, p := range ingress.Spec.Rules[0].HTTP.Paths {
@@ -1403,6 +1655,116 @@ func (c *Configuration) buildMinionConfigs(masterHost string) ([]*MinionConfigur
 	return minionConfigs, childWarnings
 }
 
+// buildCanaryKeysIndex returns the sorted keys of the canary Ingresses indexed by the namespace and the host of the canaries,
+// so that the canaries of a regular Ingress are found without iterating over all Ingresses.
+func (c *Configuration) buildCanaryKeysIndex() map[string][]string {
+	index := make(map[string][]string)
+
+	for _, key := range getSortedIngressKeys(c.ingresses) {
+		ing := c.ingresses[key]
+
+		if !isCanary(ing) {
+			continue
+		}
+
+		hosts := make(map[string]bool)
+		for _, rule := range ing.Spec.Rules {
+			if hosts[rule.Host] {
+				continue
+			}
+			hosts[rule.Host] = true
+
+			indexKey := getCanaryIndexKey(ing.Namespace, rule.Host)
+			index[indexKey] = append(index[indexKey], key)
+		}
+	}
+
+	return index
+}
+
+func getCanaryIndexKey(namespace string, host string) string {
+	return fmt.Sprintf("%s/%s", namespace, host)
+}
+
+func (c *Configuration) buildCanaryConfigs(ing *networking.Ingress, canaryKeysIndex map[string][]string) ([]*CanaryConfiguration, map[string][]string) {
+	var canaryConfigs []*CanaryConfiguration
+	childWarnings := make(map[string][]string)
+
+	primaryHosts := make(map[string]bool)
+	primaryPaths := make(map[string]bool)
+	canaryKeysSet := make(map[string]bool)
+	for _, rule := range ing.Spec.Rules {
+		primaryHosts[rule.Host] = true
+		for _, key := range canaryKeysIndex[getCanaryIndexKey(ing.Namespace, rule.Host)] {
+			canaryKeysSet[key] = true
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			primaryPaths[configs.CanaryPathKey(rule.Host, p.Path)] = true
+		}
+	}
+
+	canaryKeys := make([]string, 0, len(canaryKeysSet))
+	for key := range canaryKeysSet {
+		canaryKeys = append(canaryKeys, key)
+	}
+	sort.Strings(canaryKeys)
+
+	paths := make(map[string]*CanaryConfiguration)
+
+	for _, canaryKey := range canaryKeys {
+		canary := c.ingresses[canaryKey]
+
+		canaryConfig := NewCanaryConfiguration(canary)
+
+		for _, rule := range canary.Spec.Rules {
+			if !primaryHosts[rule.Host] || rule.HTTP == nil || !c.hostPolicyRules.isAllowed(rule.Host, canary.Namespace) {
+				continue
+			}
+
+			for _, p := range rule.HTTP.Paths {
+				pathKey := configs.CanaryPathKey(rule.Host, p.Path)
+				if !primaryPaths[pathKey] {
+					canaryConfig.ValidPaths[pathKey] = false
+					key := getResourceKey(&canaryConfig.Ingress.ObjectMeta)
+					childWarnings[key] = append(childWarnings[key], fmt.Sprintf("path %s of host %s doesn't exist in Ingress %s", p.Path, rule.Host, getResourceKey(&ing.ObjectMeta)))
+					continue
+				}
+
+				holder, exists := paths[pathKey]
+				if !exists {
+					paths[pathKey] = canaryConfig
+					canaryConfig.ValidPaths[pathKey] = true
+					continue
+				}
+
+				warning := fmt.Sprintf("path %s of host %s is taken by another canary", p.Path, rule.Host)
+
+				if !chooseObjectMetaWinner(&holder.Ingress.ObjectMeta, &canary.ObjectMeta) {
+					paths[pathKey] = canaryConfig
+					canaryConfig.ValidPaths[pathKey] = true
+
+					holder.ValidPaths[pathKey] = false
+					key := getResourceKey(&holder.Ingress.ObjectMeta)
+					childWarnings[key] = append(childWarnings[key], warning)
+				} else {
+					canaryConfig.ValidPaths[pathKey] = false
+					key := getResourceKey(&canaryConfig.Ingress.ObjectMeta)
+					childWarnings[key] = append(childWarnings[key], warning)
+				}
+			}
+		}
+
+		if len(canaryConfig.ValidPaths) > 0 {
+			canaryConfigs = append(canaryConfigs, canaryConfig)
+		}
+	}
+
+	return canaryConfigs, childWarnings
+}
+
 func (c *Configuration) buildVirtualServerRoutes(vs *conf_v1.VirtualServer) ([]*conf_v1.VirtualServerRoute, []string) {
 	var vsrs []*conf_v1.VirtualServerRoute
 	var warnings []string
@@ -1426,6 +1788,11 @@ func (c *Configuration) buildVirtualServerRoutes(vs *conf_v1.VirtualServer) ([]*
 			continue
 		}
 
+		if !c.isReferenceAllowed(virtualServerKind, vs.Namespace, virtualServerRouteKind, vsr.Namespace, vsr.Name) {
+			warnings = append(warnings, newReferenceNotAllowedWarning(virtualServerRouteKind, vsrKey, vs.Namespace))
+			continue
+		}
+
 		err := c.virtualServerValidator.ValidateVirtualServerRouteForVirtualServer(vsr, vs.Spec.Host, r.Path)
 		if err != nil {
 			warning := fmt.Sprintf("VirtualServerRoute %s is invalid: %v", vsrKey, err)
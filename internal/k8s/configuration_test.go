@@ -22,6 +22,7 @@ func createTestConfiguration() *Configuration {
 	internalRoutesEnabled := false
 	isTLSPassthroughEnabled := true
 	snippetsEnabled := true
+	isReferenceGrantsEnabled := false
 	return NewConfiguration(
 		lbc.HasCorrectIngressClass,
 		isPlus,
@@ -34,6 +35,7 @@ func createTestConfiguration() *Configuration {
 		}),
 		validation.NewTransportServerValidator(isTLSPassthroughEnabled, snippetsEnabled, isPlus),
 		isTLSPassthroughEnabled,
+		isReferenceGrantsEnabled,
 	)
 }
 
@@ -70,7 +72,7 @@ func TestAddIngressForRegularIngress(t *testing.T) {
 	// Update the Ingress
 
 	updatedIng := ing.DeepCopy()
-	updatedIng.Annotations["nginx.org/max_fails"] = "1"
+	updatedIng.Annotations["nginx.org/max-fails"] = "1"
 
 	expectedChanges = []ResourceChange{
 		{
@@ -864,6 +866,224 @@ func TestMinionPathCollisions(t *testing.T) {
 	}
 }
 
+func TestCanaryIngresses(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	// Add regular Ingress
+
+	regularIng := createTestIngressWithPath("regular-ingress", "foo.example.com", "/")
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: regularIng,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+	}
+	var expectedProblems []ConfigurationProblem
+
+	changes, problems := configuration.AddOrUpdateIngress(regularIng)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add canary-1
+
+	canary1 := createTestIngressCanary("canary-1", "foo.example.com", "/")
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: regularIng,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				ChildWarnings: map[string][]string{},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"foo.example.com/": true,
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(canary1)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add canary-2 with the taken path and a path that doesn't exist in the regular Ingress
+
+	canary2 := createTestIngressCanary("canary-2", "foo.example.com", "/")
+	canary2.Spec.Rules[0].HTTP.Paths = append(canary2.Spec.Rules[0].HTTP.Paths, networking.HTTPIngressPath{Path: "/tea"})
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: regularIng,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				ChildWarnings: map[string][]string{
+					"default/canary-2": {
+						"path / of host foo.example.com is taken by another canary",
+						"path /tea of host foo.example.com doesn't exist in Ingress default/regular-ingress",
+					},
+				},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"foo.example.com/": true,
+						},
+					},
+					{
+						Ingress: canary2,
+						ValidPaths: map[string]bool{
+							"foo.example.com/":    false,
+							"foo.example.com/tea": false,
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  canary2,
+			IsError: false,
+			Reason:  "NoPrimaryIngressFound",
+			Message: "None of the paths of the canary match a path of a valid Ingress in the same namespace",
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateIngress(canary2)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add an Ingress with the same host as a canary, which doesn't cause a host collision
+
+	canary3 := createTestIngressCanary("canary-3", "bar.example.com", "/")
+	expectedChanges = nil
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  canary3,
+			IsError: false,
+			Reason:  "NoPrimaryIngressFound",
+			Message: "None of the paths of the canary match a path of a valid Ingress in the same namespace",
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateIngress(canary3)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	ing := createTestIngressWithPath("bar-ingress", "bar.example.com", "/")
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: ing,
+				ValidHosts: map[string]bool{
+					"bar.example.com": true,
+				},
+				ChildWarnings: map[string][]string{},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary3,
+						ValidPaths: map[string]bool{
+							"bar.example.com/": true,
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(ing)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete the regular Ingress
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &IngressConfiguration{
+				Ingress: regularIng,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				ChildWarnings: map[string][]string{
+					"default/canary-2": {
+						"path / of host foo.example.com is taken by another canary",
+						"path /tea of host foo.example.com doesn't exist in Ingress default/regular-ingress",
+					},
+				},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"foo.example.com/": true,
+						},
+					},
+					{
+						Ingress: canary2,
+						ValidPaths: map[string]bool{
+							"foo.example.com/":    false,
+							"foo.example.com/tea": false,
+						},
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  canary1,
+			IsError: false,
+			Reason:  "NoPrimaryIngressFound",
+			Message: "None of the paths of the canary match a path of a valid Ingress in the same namespace",
+		},
+	}
+
+	changes, problems = configuration.DeleteIngress("default/regular-ingress")
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestAddIngressWithIncorrectClass(t *testing.T) {
 	configuration := createTestConfiguration()
 
@@ -2672,45 +2892,376 @@ func TestPortCollisions(t *testing.T) {
 	}
 }
 
-func mustInitGlobalConfiguration(c *Configuration, gc *conf_v1alpha1.GlobalConfiguration) {
-	changes, problems, err := c.AddOrUpdateGlobalConfiguration(gc)
+func TestHostPolicies(t *testing.T) {
+	configuration := createTestConfiguration()
 
-	// when adding a valid GlobalConfiguration to a new Configuration, no changes, problems and errors are expected
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	ing := createTestIngress("ingress", "foo.example.com", "bar.example.com")
+	ts := createTestTLSPassthroughTransportServer("transportserver", "tea.example.com")
 
-	if len(changes) > 0 {
-		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned %d changes, expected 0", len(changes)))
+	configuration.AddOrUpdateVirtualServer(vs)
+	configuration.AddOrUpdateIngress(ing)
+	configuration.AddOrUpdateTransportServer(ts)
+
+	hp := createTestHostPolicy("host-policy", []conf_v1alpha1.HostPolicyRule{
+		{
+			Host:       "cafe.example.com",
+			Namespaces: []string{"cafe"},
+		},
+		{
+			Host:       "*.example.com",
+			Namespaces: []string{"team-a"},
+		},
+		{
+			Host:       "bar.example.com",
+			Namespaces: []string{"default"},
+		},
+		{
+			Host:       "*.tea.example.com",
+			Namespaces: []string{"default"},
+		},
+	})
+
+	// Add HostPolicy
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+			},
+		},
+		{
+			Op: Delete,
+			Resource: &TransportServerConfiguration{
+				TransportServer: ts,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress:       ing,
+				ValidHosts:    map[string]bool{"foo.example.com": false, "bar.example.com": true},
+				ChildWarnings: map[string][]string{},
+				Warnings:      []string{"host foo.example.com is not allowed in namespace default by HostPolicy"},
+			},
+		},
 	}
-	if len(problems) > 0 {
-		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned %d problems, expected 0", len(problems)))
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  ts,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Host tea.example.com is not allowed in namespace default by HostPolicy",
+		},
+		{
+			Object:  vs,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Host cafe.example.com is not allowed in namespace default by HostPolicy",
+		},
+	}
+
+	changes, problems, err := configuration.AddOrUpdateHostPolicy(hp)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
 	}
 	if err != nil {
-		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned an unexpected error %v", err))
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected error: %v", err)
 	}
-}
 
-func createTestIngressMaster(name string, host string) *networking.Ingress {
-	ing := createTestIngress(name, host)
-	ing.Annotations["nginx.org/mergeable-ingress-type"] = "master"
-	return ing
-}
+	// Update HostPolicy to deny all hosts of the Ingress
 
-func createTestIngressMinion(name string, host string, path string) *networking.Ingress {
-	ing := createTestIngress(name, host)
-	ing.Spec.Rules[0].IngressRuleValue = networking.IngressRuleValue{
-		HTTP: &networking.HTTPIngressRuleValue{
-			Paths: []networking.HTTPIngressPath{
-				{
-					Path: path,
+	updatedHP := hp.DeepCopy()
+	updatedHP.Spec.Rules[2].Namespaces = []string{"team-b"}
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &IngressConfiguration{
+				Ingress:       ing,
+				ValidHosts:    map[string]bool{"foo.example.com": false, "bar.example.com": false},
+				ChildWarnings: map[string][]string{},
+				Warnings: []string{
+					"host foo.example.com is not allowed in namespace default by HostPolicy",
+					"host bar.example.com is not allowed in namespace default by HostPolicy",
 				},
 			},
 		},
 	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  ing,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "None of the hosts are allowed in namespace default by HostPolicies",
+		},
+	}
+
+	changes, problems, err = configuration.AddOrUpdateHostPolicy(updatedHP)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected error: %v", err)
+	}
+
+	// Update HostPolicy to make it invalid
+
+	invalidHP := hp.DeepCopy()
+	invalidHP.Spec.Rules[0].Host = "cafe.*.com"
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress:       ing,
+				ValidHosts:    map[string]bool{"foo.example.com": true, "bar.example.com": true},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &TransportServerConfiguration{
+				TransportServer: ts,
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems, err = configuration.AddOrUpdateHostPolicy(invalidHP)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err == nil {
+		t.Errorf("AddOrUpdateHostPolicy() didn't return an error for an invalid HostPolicy")
+	}
+
+	// Delete non-existing HostPolicy
+
+	changes, problems = configuration.DeleteHostPolicy("host-policy")
+	if len(changes) > 0 || len(problems) > 0 {
+		t.Errorf("DeleteHostPolicy() returned %d changes and %d problems for a non-existing HostPolicy", len(changes), len(problems))
+	}
+}
+
+func TestHostPoliciesForMinions(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	master := createTestIngressMaster("master", "cafe.example.com")
+	minion := createTestIngressMinion("minion", "cafe.example.com", "/tea")
+	minion.Namespace = "tea"
+
+	hp := createTestHostPolicy("host-policy", []conf_v1alpha1.HostPolicyRule{
+		{
+			Host:       "cafe.example.com",
+			Namespaces: []string{"default"},
+		},
+	})
+
+	_, _, err := configuration.AddOrUpdateHostPolicy(hp)
+	if err != nil {
+		t.Errorf("AddOrUpdateHostPolicy() returned unexpected error: %v", err)
+	}
+
+	configuration.AddOrUpdateIngress(master)
+
+	// the minion is not added to the master
+	var expectedChanges []ResourceChange
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  minion,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Host cafe.example.com is not allowed in namespace tea by HostPolicy",
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateIngress(minion)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestMinionNamespacesOfMaster(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	master := createTestIngressMaster("master", "cafe.example.com")
+	master.Annotations["nginx.org/mergeable-ingress-minion-namespaces"] = "tea"
+	teaMinion := createTestIngressMinion("tea-minion", "cafe.example.com", "/tea")
+	teaMinion.Namespace = "tea"
+	coffeeMinion := createTestIngressMinion("coffee-minion", "cafe.example.com", "/coffee")
+	coffeeMinion.Namespace = "coffee"
+
+	configuration.AddOrUpdateIngress(master)
+	configuration.AddOrUpdateIngress(teaMinion)
+
+	// the coffee minion is not added to the master
+	var expectedChanges []ResourceChange
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  coffeeMinion,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Namespace coffee is not allowed to contribute minions by Ingress master default/master",
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateIngress(coffeeMinion)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// allow the coffee namespace
+
+	updatedMaster := master.DeepCopy()
+	updatedMaster.Annotations["nginx.org/mergeable-ingress-minion-namespaces"] = "tea,coffee"
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress:  updatedMaster,
+				IsMaster: true,
+				Minions: []*MinionConfiguration{
+					{
+						Ingress: coffeeMinion,
+						ValidPaths: map[string]bool{
+							"/coffee": true,
+						},
+					},
+					{
+						Ingress: teaMinion,
+						ValidPaths: map[string]bool{
+							"/tea": true,
+						},
+					},
+				},
+				ValidHosts: map[string]bool{
+					"cafe.example.com": true,
+				},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(updatedMaster)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func mustInitGlobalConfiguration(c *Configuration, gc *conf_v1alpha1.GlobalConfiguration) {
+	changes, problems, err := c.AddOrUpdateGlobalConfiguration(gc)
+
+	// when adding a valid GlobalConfiguration to a new Configuration, no changes, problems and errors are expected
+
+	if len(changes) > 0 {
+		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned %d changes, expected 0", len(changes)))
+	}
+	if len(problems) > 0 {
+		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned %d problems, expected 0", len(problems)))
+	}
+	if err != nil {
+		panic(fmt.Sprintf("AddOrUpdateGlobalConfiguration() returned an unexpected error %v", err))
+	}
+}
+
+func TestBuildCanaryKeysIndex(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	canary1 := createTestIngressCanary("canary-1", "foo.example.com", "/")
+	canary2 := createTestIngressCanary("canary-2", "foo.example.com", "/")
+	canary2.Spec.Rules = append(canary2.Spec.Rules, canary2.Spec.Rules[0], networking.IngressRule{Host: "bar.example.com"})
+	canary3 := createTestIngressCanary("canary-3", "foo.example.com", "/")
+	canary3.Namespace = "other"
+	regularIng := createTestIngressWithPath("regular-ingress", "foo.example.com", "/")
+
+	for _, ing := range []*networking.Ingress{canary1, canary2, canary3, regularIng} {
+		configuration.ingresses[getResourceKey(&ing.ObjectMeta)] = ing
+	}
+
+	expected := map[string][]string{
+		"default/foo.example.com": {"default/canary-1", "default/canary-2"},
+		"default/bar.example.com": {"default/canary-2"},
+		"other/foo.example.com":   {"other/canary-3"},
+	}
+
+	result := configuration.buildCanaryKeysIndex()
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("buildCanaryKeysIndex() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func createTestIngressMaster(name string, host string) *networking.Ingress {
+	ing := createTestIngress(name, host)
+	ing.Annotations["nginx.org/mergeable-ingress-type"] = "master"
+	return ing
+}
+
+func createTestIngressMinion(name string, host string, path string) *networking.Ingress {
+	ing := createTestIngress(name, host)
+	ing.Spec.Rules[0].IngressRuleValue = networking.IngressRuleValue{
+		HTTP: &networking.HTTPIngressRuleValue{
+			Paths: []networking.HTTPIngressPath{
+				{
+					Path: path,
+				},
+			},
+		},
+	}
+
+	ing.Annotations["nginx.org/mergeable-ingress-type"] = "minion"
+
+	return ing
+}
+
+func createTestIngressWithPath(name string, host string, path string) *networking.Ingress {
+	ing := createTestIngress(name, host)
+	ing.Spec.Rules[0].IngressRuleValue = networking.IngressRuleValue{
+		HTTP: &networking.HTTPIngressRuleValue{
+			Paths: []networking.HTTPIngressPath{
+				{
+					Path: path,
+				},
+			},
+		},
+	}
+
+	return ing
+}
+
+func createTestIngressCanary(name string, host string, path string) *networking.Ingress {
+	ing := createTestIngressWithPath(name, host, path)
+	ing.Annotations["nginx.org/canary"] = "true"
+	return ing
+}
 
-	ing.Annotations["nginx.org/mergeable-ingress-type"] = "minion"
-
-	return ing
-}
-
 func createTestIngress(name string, hosts ...string) *networking.Ingress {
 	var rules []networking.IngressRule
 
@@ -2825,6 +3376,394 @@ func createTestGlobalConfiguration(listeners []conf_v1alpha1.Listener) *conf_v1a
 	}
 }
 
+func createTestHostPolicy(name string, rules []conf_v1alpha1.HostPolicyRule) *conf_v1alpha1.HostPolicy {
+	return &conf_v1alpha1.HostPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: conf_v1alpha1.HostPolicySpec{
+			Rules: rules,
+		},
+	}
+}
+
+func createTestReferenceGrant(name string, namespace string, from []conf_v1alpha1.ReferenceGrantFrom, to []conf_v1alpha1.ReferenceGrantTo) *conf_v1alpha1.ReferenceGrant {
+	return &conf_v1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: conf_v1alpha1.ReferenceGrantSpec{
+			From: from,
+			To:   to,
+		},
+	}
+}
+
+func TestReferenceGrants(t *testing.T) {
+	configuration := createTestConfiguration()
+	configuration.isReferenceGrantsEnabled = true
+
+	vsr := createTestVirtualServerRoute("virtualserverroute", "foo.example.com", "/first")
+	vsr.Namespace = "team-a"
+
+	vs := createTestVirtualServerWithRoutes(
+		"virtualserver",
+		"foo.example.com",
+		[]conf_v1.Route{
+			{
+				Path:  "/first",
+				Route: "team-a/virtualserverroute",
+			},
+		})
+	vs.Spec.Policies = []conf_v1.PolicyReference{
+		{
+			Name:      "policy",
+			Namespace: "team-a",
+		},
+	}
+
+	configuration.AddOrUpdateVirtualServerRoute(vsr)
+
+	// Add VirtualServer without ReferenceGrants
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				Warnings: []string{
+					"VirtualServerRoute team-a/virtualserverroute is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+					"Policy team-a/policy is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+				},
+			},
+		},
+	}
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  vsr,
+			IsError: false,
+			Reason:  "Ignored",
+			Message: "VirtualServer default/virtualserver ignores VirtualServerRoute",
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateVirtualServer(vs)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	expectedResources := []Resource{
+		&VirtualServerConfiguration{
+			VirtualServer: vs,
+			Warnings: []string{
+				"VirtualServerRoute team-a/virtualserverroute is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+				"Policy team-a/policy is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+			},
+		},
+	}
+
+	resources := configuration.FindResourcesForReferenceGrant("team-a")
+	if diff := cmp.Diff(expectedResources, resources); diff != "" {
+		t.Errorf("FindResourcesForReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	resources = configuration.FindResourcesForReferenceGrant("team-b")
+	if len(resources) != 0 {
+		t.Errorf("FindResourcesForReferenceGrant() returned %v but expected no resources", resources)
+	}
+
+	// Add ReferenceGrant
+
+	rg := createTestReferenceGrant("reference-grant", "team-a",
+		[]conf_v1alpha1.ReferenceGrantFrom{
+			{
+				Kind:      "VirtualServer",
+				Namespace: "default",
+			},
+		},
+		[]conf_v1alpha1.ReferenceGrantTo{
+			{
+				Kind: "VirtualServerRoute",
+			},
+			{
+				Kind: "Policy",
+				Name: "policy",
+			},
+		})
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer:       vs,
+				VirtualServerRoutes: []*conf_v1.VirtualServerRoute{vsr},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems, err := configuration.AddOrUpdateReferenceGrant(rg)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateReferenceGrant() returned unexpected error: %v", err)
+	}
+
+	if !configuration.IsReferenceAllowed("VirtualServer", "default", "Policy", "team-a", "policy") {
+		t.Errorf("IsReferenceAllowed() returned false for a granted Policy")
+	}
+	if configuration.IsReferenceAllowed("VirtualServerRoute", "default", "Policy", "team-a", "policy") {
+		t.Errorf("IsReferenceAllowed() returned true for a Policy not granted to VirtualServerRoutes")
+	}
+	if configuration.IsReferenceAllowed("VirtualServer", "default", "Secret", "team-a", "secret") {
+		t.Errorf("IsReferenceAllowed() returned true for a Secret not granted")
+	}
+
+	// Update ReferenceGrant to be invalid
+
+	invalidRg := rg.DeepCopy()
+	invalidRg.Generation++
+	invalidRg.Spec.To = nil
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				Warnings: []string{
+					"VirtualServerRoute team-a/virtualserverroute is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+					"Policy team-a/policy is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a",
+				},
+			},
+		},
+	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  vsr,
+			IsError: false,
+			Reason:  "Ignored",
+			Message: "VirtualServer default/virtualserver ignores VirtualServerRoute",
+		},
+	}
+
+	changes, problems, err = configuration.AddOrUpdateReferenceGrant(invalidRg)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err == nil {
+		t.Errorf("AddOrUpdateReferenceGrant() returned no error for an invalid ReferenceGrant")
+	}
+
+	// Add back the valid ReferenceGrant
+
+	configuration.AddOrUpdateReferenceGrant(rg)
+
+	// Delete ReferenceGrant
+
+	changes, problems = configuration.DeleteReferenceGrant("team-a/reference-grant")
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteReferenceGrant() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete non-existing ReferenceGrant
+
+	changes, problems = configuration.DeleteReferenceGrant("team-a/reference-grant")
+	if len(changes) != 0 || len(problems) != 0 {
+		t.Errorf("DeleteReferenceGrant() returned changes %v and problems %v for a non-existing ReferenceGrant", changes, problems)
+	}
+}
+
+func createTestDefaultPolicy(name string, namespaces []string, policies []conf_v1alpha1.DefaultPolicyReference) *conf_v1alpha1.DefaultPolicy {
+	return &conf_v1alpha1.DefaultPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: conf_v1alpha1.DefaultPolicySpec{
+			IngressClass: "nginx",
+			Namespaces:   namespaces,
+			Policies:     policies,
+		},
+	}
+}
+
+func TestDefaultPolicies(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	vs := createTestVirtualServer("virtualserver", "foo.example.com")
+	vs.Spec.Policies = []conf_v1.PolicyReference{
+		{
+			Name: "access-control",
+		},
+	}
+
+	configuration.AddOrUpdateVirtualServer(vs)
+
+	dp := createTestDefaultPolicy("default-policy", []string{"default"}, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name: "rate-limit",
+		},
+		{
+			Name:      "waf",
+			Namespace: "security",
+		},
+	})
+
+	// Add DefaultPolicy
+
+	vsWithDefaults := vs.DeepCopy()
+	vsWithDefaults.Spec.Policies = []conf_v1.PolicyReference{
+		{
+			Name: "access-control",
+		},
+		{
+			Name: "rate-limit",
+		},
+		{
+			Name:      "waf",
+			Namespace: "security",
+		},
+	}
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer:   vsWithDefaults,
+				DefaultPolicies: []string{"default/rate-limit", "security/waf"},
+			},
+		},
+	}
+	var expectedProblems []ConfigurationProblem
+
+	changes, problems, err := configuration.AddOrUpdateDefaultPolicy(dp)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected error: %v", err)
+	}
+
+	// the Policies of the DefaultPolicy are referenced by the VirtualServer
+
+	expectedResources := []Resource{
+		&VirtualServerConfiguration{
+			VirtualServer:   vsWithDefaults,
+			DefaultPolicies: []string{"default/rate-limit", "security/waf"},
+		},
+	}
+
+	resources := configuration.FindResourcesForPolicy("security", "waf")
+	if diff := cmp.Diff(expectedResources, resources); diff != "" {
+		t.Errorf("FindResourcesForPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Update VirtualServer to opt out of the default Policies
+
+	updatedVS := vs.DeepCopy()
+	updatedVS.Generation++
+	updatedVS.Spec.IgnoreDefaultPolicies = true
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: updatedVS,
+			},
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateVirtualServer(updatedVS)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Opt back in
+
+	vs.Generation += 2
+
+	changes, _ = configuration.AddOrUpdateVirtualServer(vs)
+	if len(changes) != 1 {
+		t.Fatalf("AddOrUpdateVirtualServer() returned %d changes but expected 1", len(changes))
+	}
+
+	// Update DefaultPolicy to have a wrong ingress class
+
+	dpWithWrongClass := dp.DeepCopy()
+	dpWithWrongClass.Spec.IngressClass = "some-class"
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+			},
+		},
+	}
+
+	changes, problems, err = configuration.AddOrUpdateDefaultPolicy(dpWithWrongClass)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned unexpected error: %v", err)
+	}
+
+	// Update DefaultPolicy to be invalid
+
+	invalidDp := dp.DeepCopy()
+	invalidDp.Spec.Policies = nil
+
+	changes, problems, err = configuration.AddOrUpdateDefaultPolicy(invalidDp)
+	if len(changes) != 0 || len(problems) != 0 {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned changes %v and problems %v for an invalid DefaultPolicy", changes, problems)
+	}
+	if err == nil {
+		t.Errorf("AddOrUpdateDefaultPolicy() returned no error for an invalid DefaultPolicy")
+	}
+
+	// Add back the valid DefaultPolicy, then delete it
+
+	configuration.AddOrUpdateDefaultPolicy(dp)
+
+	changes, problems = configuration.DeleteDefaultPolicy("default-policy")
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteDefaultPolicy() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete non-existing DefaultPolicy
+
+	changes, problems = configuration.DeleteDefaultPolicy("default-policy")
+	if len(changes) != 0 || len(problems) != 0 {
+		t.Errorf("DeleteDefaultPolicy() returned changes %v and problems %v for a non-existing DefaultPolicy", changes, problems)
+	}
+}
+
 func TestChooseObjectMetaWinner(t *testing.T) {
 	now := metav1.Now()
 	afterNow := metav1.NewTime(now.Add(1 * time.Second))
@@ -3040,6 +3979,7 @@ type testReferenceChecker struct {
 	resourceNamespace       string
 	onlyIngresses           bool
 	onlyMinions             bool
+	onlyCanaries            bool
 	onlyVirtualServers      bool
 	onlyVirtualServerRoutes bool
 	onlyTransportServers    bool
@@ -3053,6 +3993,10 @@ func (rc *testReferenceChecker) IsReferencedByMinion(namespace string, name stri
 	return rc.onlyMinions && namespace == rc.resourceNamespace && name == rc.resourceName
 }
 
+func (rc *testReferenceChecker) IsReferencedByCanary(namespace string, name string, ing *networking.Ingress) bool {
+	return rc.onlyCanaries && namespace == rc.resourceNamespace && name == rc.resourceName
+}
+
 func (rc *testReferenceChecker) IsReferencedByVirtualServer(namespace string, name string, vs *conf_v1.VirtualServer) bool {
 	return rc.onlyVirtualServers && namespace == rc.resourceNamespace && name == rc.resourceName
 }
@@ -3066,7 +4010,8 @@ func (rc *testReferenceChecker) IsReferencedByTransportServer(namespace string,
 }
 
 func TestFindResourcesForResourceReference(t *testing.T) {
-	regularIng := createTestIngress("regular-ingress", "foo.example.com")
+	regularIng := createTestIngressWithPath("regular-ingress", "foo.example.com", "/")
+	canary := createTestIngressCanary("canary-ingress", "foo.example.com", "/")
 	master := createTestIngressMaster("master-ingress", "bar.example.com")
 	minion := createTestIngressMinion("minion-ingress", "bar.example.com", "/")
 	vs := createTestVirtualServer("virtualserver-1", "qwe.example.com")
@@ -3094,6 +4039,7 @@ func TestFindResourcesForResourceReference(t *testing.T) {
 	configuration := createTestConfiguration()
 
 	configuration.AddOrUpdateIngress(regularIng)
+	configuration.AddOrUpdateIngress(canary)
 	configuration.AddOrUpdateIngress(master)
 	configuration.AddOrUpdateIngress(minion)
 	configuration.AddOrUpdateVirtualServer(vs)
@@ -3131,6 +4077,17 @@ func TestFindResourcesForResourceReference(t *testing.T) {
 			},
 			msg: "only Minions",
 		},
+		{
+			rc: &testReferenceChecker{
+				resourceNamespace: "default",
+				resourceName:      "test",
+				onlyCanaries:      true,
+			},
+			expected: []Resource{
+				configuration.hosts["foo.example.com"],
+			},
+			msg: "only Canaries",
+		},
 		{
 			rc: &testReferenceChecker{
 				resourceNamespace:  "default",
@@ -25,14 +25,17 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
 	"k8s.io/client-go/informers"
+	networking_informers "k8s.io/client-go/informers/networking/v1"
 
 	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/oidc"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/spiffe/go-spiffe/workload"
 
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	core_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -52,6 +55,7 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	k8s_nginx_informers "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions"
+	conf_informers_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions/configuration/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -63,6 +67,8 @@ const (
 	ingressClassKey = "kubernetes.io/ingress.class"
 	// IngressControllerName holds Ingress Controller name
 	IngressControllerName = "nginx.org/ingress-controller"
+	// oidcDiscoveryRefreshPeriod is the period of the refresh of the metadata of the OIDC issuers
+	oidcDiscoveryRefreshPeriod = 5 * time.Minute
 )
 
 var (
@@ -97,6 +103,8 @@ type LoadBalancerController struct {
 	configMapController           cache.Controller
 	dynInformerFactory            dynamicinformer.DynamicSharedInformerFactory
 	globalConfigurationController cache.Controller
+	hostPolicyController          cache.Controller
+	defaultPolicyController       cache.Controller
 	ingressLinkInformer           cache.SharedIndexInformer
 	ingressLister                 storeToIngressLister
 	svcLister                     cache.Store
@@ -109,6 +117,9 @@ type LoadBalancerController struct {
 	appProtectPolicyLister        cache.Store
 	appProtectLogConfLister       cache.Store
 	globalConfigurationLister     cache.Store
+	hostPolicyLister              cache.Store
+	defaultPolicyLister           cache.Store
+	referenceGrantLister          cache.Store
 	appProtectUserSigLister       cache.Store
 	transportServerLister         cache.Store
 	policyLister                  cache.Store
@@ -119,6 +130,9 @@ type LoadBalancerController struct {
 	configurator                  *configs.Configurator
 	watchNginxConfigMaps          bool
 	watchGlobalConfiguration      bool
+	watchHostPolicies             bool
+	watchDefaultPolicies          bool
+	isReferenceGrantsEnabled      bool
 	watchIngressLink              bool
 	isNginxPlus                   bool
 	appProtectEnabled             bool
@@ -132,6 +146,9 @@ type LoadBalancerController struct {
 	leaderElectionLockName        string
 	resync                        time.Duration
 	namespace                     string
+	watchedNamespaces             *watchedNamespaces
+	namespacedInformers           []namespacedInformer
+	watchLabelSelector            string
 	controllerNamespace           string
 	wildcardTLSSecret             string
 	areCustomResourcesEnabled     bool
@@ -145,7 +162,10 @@ type LoadBalancerController struct {
 	isNginxReady                  bool
 	isPrometheusEnabled           bool
 	isLatencyMetricsEnabled       bool
+	isDebugAPIEnabled             bool
 	configuration                 *Configuration
+	policyReferenceProblems       *policyReferenceProblems
+	oidcDiscoverer                *oidc.Discoverer
 	secretStore                   secrets.SecretStore
 	appProtectConfiguration       appprotect.Configuration
 	configMap                     *api_v1.ConfigMap
@@ -159,7 +179,9 @@ type NewLoadBalancerControllerInput struct {
 	ConfClient                   k8s_nginx.Interface
 	DynClient                    dynamic.Interface
 	ResyncPeriod                 time.Duration
-	Namespace                    string
+	Namespaces                   []string
+	NamespaceLabelSelector       labels.Selector
+	WatchLabelSelector           string
 	NginxConfigurator            *configs.Configurator
 	DefaultServerSecret          string
 	AppProtectEnabled            bool
@@ -176,6 +198,9 @@ type NewLoadBalancerControllerInput struct {
 	GlobalConfiguration          string
 	AreCustomResourcesEnabled    bool
 	EnablePreviewPolicies        bool
+	AreHostPoliciesEnabled       bool
+	AreReferenceGrantsEnabled    bool
+	AreDefaultPoliciesEnabled    bool
 	MetricsCollector             collectors.ControllerCollector
 	GlobalConfigurationValidator *validation.GlobalConfigurationValidator
 	TransportServerValidator     *validation.TransportServerValidator
@@ -185,6 +210,7 @@ type NewLoadBalancerControllerInput struct {
 	IsPrometheusEnabled          bool
 	IsLatencyMetricsEnabled      bool
 	IsTLSPassthroughEnabled      bool
+	IsDebugAPIEnabled            bool
 }
 
 // NewLoadBalancerController creates a controller
@@ -202,17 +228,19 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		isLeaderElectionEnabled:      input.IsLeaderElectionEnabled,
 		leaderElectionLockName:       input.LeaderElectionLockName,
 		resync:                       input.ResyncPeriod,
-		namespace:                    input.Namespace,
+		watchLabelSelector:           input.WatchLabelSelector,
 		controllerNamespace:          input.ControllerNamespace,
 		wildcardTLSSecret:            input.WildcardTLSSecret,
 		areCustomResourcesEnabled:    input.AreCustomResourcesEnabled,
 		enablePreviewPolicies:        input.EnablePreviewPolicies,
+		isReferenceGrantsEnabled:     input.AreReferenceGrantsEnabled,
 		metricsCollector:             input.MetricsCollector,
 		globalConfigurationValidator: input.GlobalConfigurationValidator,
 		transportServerValidator:     input.TransportServerValidator,
 		internalRoutesEnabled:        input.InternalRoutesEnabled,
 		isPrometheusEnabled:          input.IsPrometheusEnabled,
 		isLatencyMetricsEnabled:      input.IsLatencyMetricsEnabled,
+		isDebugAPIEnabled:            input.IsDebugAPIEnabled,
 	}
 
 	eventBroadcaster := record.NewBroadcaster()
@@ -234,6 +262,14 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 
 	glog.V(3).Infof("Nginx Ingress Controller has class: %v", input.IngressClass)
 
+	// a single namespace is watched by namespaced informers, while for multiple namespaces the informers watch all namespaces
+	// and the resources are filtered by the watched namespaces
+	if len(input.Namespaces) == 1 && input.NamespaceLabelSelector == nil {
+		lbc.namespace = input.Namespaces[0]
+	} else if len(input.Namespaces) > 1 || input.NamespaceLabelSelector != nil {
+		lbc.watchedNamespaces = newWatchedNamespaces(input.Namespaces, input.NamespaceLabelSelector)
+	}
+
 	lbc.sharedInformerFactory = informers.NewSharedInformerFactoryWithOptions(lbc.client, input.ResyncPeriod, informers.WithNamespace(lbc.namespace))
 
 	// create handlers for resources we care about
@@ -243,6 +279,10 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	lbc.addEndpointHandler(createEndpointHandlers(lbc))
 	lbc.addPodHandler()
 
+	if input.NamespaceLabelSelector != nil {
+		lbc.addNamespaceHandler(createNamespaceHandlers(lbc))
+	}
+
 	if lbc.appProtectEnabled {
 		lbc.dynInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(lbc.dynClient, 0)
 
@@ -252,7 +292,9 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	}
 
 	if lbc.areCustomResourcesEnabled {
-		lbc.confSharedInformerFactorry = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, input.ResyncPeriod, k8s_nginx_informers.WithNamespace(lbc.namespace))
+		// all the resources of the factory are filtered by the watch label selector
+		lbc.confSharedInformerFactorry = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, input.ResyncPeriod,
+			k8s_nginx_informers.WithNamespace(lbc.namespace), k8s_nginx_informers.WithTweakListOptions(lbc.tweakListOptionsForWatchLabelSelector))
 
 		lbc.addVirtualServerHandler(createVirtualServerHandlers(lbc))
 		lbc.addVirtualServerRouteHandler(createVirtualServerRouteHandlers(lbc))
@@ -264,6 +306,20 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 			ns, name, _ := ParseNamespaceName(input.GlobalConfiguration)
 			lbc.addGlobalConfigurationHandler(createGlobalConfigurationHandlers(lbc), ns, name)
 		}
+
+		if input.AreHostPoliciesEnabled {
+			lbc.watchHostPolicies = true
+			lbc.addHostPolicyHandler(createHostPolicyHandlers(lbc))
+		}
+
+		if input.AreDefaultPoliciesEnabled {
+			lbc.watchDefaultPolicies = true
+			lbc.addDefaultPolicyHandler(createDefaultPolicyHandlers(lbc))
+		}
+
+		if lbc.isReferenceGrantsEnabled {
+			lbc.addReferenceGrantHandler(createReferenceGrantHandlers(lbc))
+		}
 	}
 
 	if input.ConfigMaps != "" {
@@ -299,6 +355,9 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		hasCorrectIngressClass:   lbc.HasCorrectIngressClass,
 	}
 
+	lbc.policyReferenceProblems = newPolicyReferenceProblems()
+	lbc.oidcDiscoverer = oidc.NewDiscoverer(nil, lbc.enqueuePoliciesForOIDCIssuer)
+
 	lbc.configuration = NewConfiguration(
 		lbc.HasCorrectIngressClass,
 		input.IsNginxPlus,
@@ -307,7 +366,8 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		input.VirtualServerValidator,
 		input.GlobalConfigurationValidator,
 		input.TransportServerValidator,
-		input.IsTLSPassthroughEnabled)
+		input.IsTLSPassthroughEnabled,
+		input.AreReferenceGrantsEnabled)
 
 	lbc.appProtectConfiguration = appprotect.NewConfiguration()
 
@@ -361,8 +421,7 @@ func (lbc *LoadBalancerController) addAppProtectUserSigHandler(handlers cache.Re
 // addSecretHandler adds the handler for secrets to the controller
 func (lbc *LoadBalancerController) addSecretHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.sharedInformerFactory.Core().V1().Secrets().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.secretLister = informer.GetStore()
+	lbc.secretLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
@@ -370,26 +429,32 @@ func (lbc *LoadBalancerController) addSecretHandler(handlers cache.ResourceEvent
 // addServiceHandler adds the handler for services to the controller
 func (lbc *LoadBalancerController) addServiceHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.sharedInformerFactory.Core().V1().Services().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.svcLister = informer.GetStore()
+	lbc.svcLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
 // addIngressHandler adds the handler for ingresses to the controller
 func (lbc *LoadBalancerController) addIngressHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.sharedInformerFactory.Networking().V1().Ingresses().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.ingressLister.Store = informer.GetStore()
+	// unlike the other resources of the shared informer factory, Ingresses are filtered by the watch label selector
+	informer := lbc.sharedInformerFactory.InformerFor(&networking.Ingress{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		return networking_informers.NewFilteredIngressInformer(client, lbc.namespace, resync,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, lbc.tweakListOptionsForWatchLabelSelector)
+	})
+	lbc.ingressLister.Store = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
+// tweakListOptionsForWatchLabelSelector limits the watched resources to the resources that match the watch label selector.
+func (lbc *LoadBalancerController) tweakListOptionsForWatchLabelSelector(options *meta_v1.ListOptions) {
+	options.LabelSelector = lbc.watchLabelSelector
+}
+
 // addEndpointHandler adds the handler for endpoints to the controller
 func (lbc *LoadBalancerController) addEndpointHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.sharedInformerFactory.Core().V1().Endpoints().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.endpointLister.Store = informer.GetStore()
+	lbc.endpointLister.Store = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
@@ -409,6 +474,14 @@ func (lbc *LoadBalancerController) addConfigMapHandler(handlers cache.ResourceEv
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.configMapController.HasSynced)
 }
 
+// addNamespaceHandler adds the handler for namespaces to the controller
+func (lbc *LoadBalancerController) addNamespaceHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := lbc.sharedInformerFactory.Core().V1().Namespaces().Informer()
+	informer.AddEventHandler(handlers)
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) addPodHandler() {
 	informer := lbc.sharedInformerFactory.Core().V1().Pods().Informer()
 	lbc.podLister.Indexer = informer.GetIndexer()
@@ -418,24 +491,21 @@ func (lbc *LoadBalancerController) addPodHandler() {
 
 func (lbc *LoadBalancerController) addVirtualServerHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.confSharedInformerFactorry.K8s().V1().VirtualServers().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.virtualServerLister = informer.GetStore()
+	lbc.virtualServerLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
 func (lbc *LoadBalancerController) addVirtualServerRouteHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.confSharedInformerFactorry.K8s().V1().VirtualServerRoutes().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.virtualServerRouteLister = informer.GetStore()
+	lbc.virtualServerRouteLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
 func (lbc *LoadBalancerController) addPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.confSharedInformerFactorry.K8s().V1().Policies().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.policyLister = informer.GetStore()
+	lbc.policyLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
@@ -454,10 +524,53 @@ func (lbc *LoadBalancerController) addGlobalConfigurationHandler(handlers cache.
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.globalConfigurationController.HasSynced)
 }
 
+// addHostPolicyHandler watches all HostPolicies, which are cluster-scoped. The HostPolicies are not filtered by the watch label selector,
+// so that all the Ingress Controllers in the cluster enforce the same policies.
+func (lbc *LoadBalancerController) addHostPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.hostPolicyLister, lbc.hostPolicyController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.K8sV1alpha1().RESTClient(),
+			"hostpolicies",
+			"",
+			fields.Everything()),
+		&conf_v1alpha1.HostPolicy{},
+		lbc.resync,
+		handlers,
+	)
+	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.hostPolicyController.HasSynced)
+}
+
+// addDefaultPolicyHandler watches all DefaultPolicies, which are cluster-scoped. The DefaultPolicies are not filtered by the watch label selector,
+// so that the default Policies apply to all the resources of the ingress class.
+func (lbc *LoadBalancerController) addDefaultPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
+	lbc.defaultPolicyLister, lbc.defaultPolicyController = cache.NewInformer(
+		cache.NewListWatchFromClient(
+			lbc.confClient.K8sV1alpha1().RESTClient(),
+			"defaultpolicies",
+			"",
+			fields.Everything()),
+		&conf_v1alpha1.DefaultPolicy{},
+		lbc.resync,
+		handlers,
+	)
+	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.defaultPolicyController.HasSynced)
+}
+
+// addReferenceGrantHandler adds the handler for ReferenceGrants. Unlike the other resources of the shared informer factory,
+// ReferenceGrants are not filtered by the watch label selector, because they apply to all the resources in their namespaces.
+func (lbc *LoadBalancerController) addReferenceGrantHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := lbc.confSharedInformerFactorry.InformerFor(&conf_v1alpha1.ReferenceGrant{}, func(client k8s_nginx.Interface, resync time.Duration) cache.SharedIndexInformer {
+		return conf_informers_v1alpha1.NewReferenceGrantInformer(client, lbc.namespace, resync,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+	lbc.referenceGrantLister = lbc.addNamespacedInformerHandlers(informer, handlers)
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) addTransportServerHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.confSharedInformerFactorry.K8s().V1alpha1().TransportServers().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.transportServerLister = informer.GetStore()
+	lbc.transportServerLister = lbc.addNamespacedInformerHandlers(informer, handlers)
 
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
@@ -502,6 +615,12 @@ func (lbc *LoadBalancerController) Run() {
 	if lbc.watchGlobalConfiguration {
 		go lbc.globalConfigurationController.Run(lbc.ctx.Done())
 	}
+	if lbc.watchHostPolicies {
+		go lbc.hostPolicyController.Run(lbc.ctx.Done())
+	}
+	if lbc.watchDefaultPolicies {
+		go lbc.defaultPolicyController.Run(lbc.ctx.Done())
+	}
 	if lbc.watchIngressLink {
 		go lbc.ingressLinkInformer.Run(lbc.ctx.Done())
 	}
@@ -520,6 +639,9 @@ func (lbc *LoadBalancerController) Run() {
 	glog.V(3).Infof("Starting the queue with %d initial elements", lbc.syncQueue.Len())
 
 	go lbc.syncQueue.Run(time.Second, lbc.ctx.Done())
+	if lbc.areCustomResourcesEnabled {
+		go wait.Until(lbc.refreshOIDCDiscoveries, oidcDiscoveryRefreshPeriod, lbc.ctx.Done())
+	}
 	<-lbc.ctx.Done()
 }
 
@@ -591,14 +713,14 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 		switch impl := r.(type) {
 		case *VirtualServerConfiguration:
 			vs := impl.VirtualServer
-			vsEx := lbc.createVirtualServerEx(vs, impl.VirtualServerRoutes)
+			vsEx := lbc.createVirtualServerEx(vs, impl.VirtualServerRoutes, impl.InheritedSubroutes)
 			result.VirtualServerExes = append(result.VirtualServerExes, vsEx)
 		case *IngressConfiguration:
 			if impl.IsMaster {
 				mergeableIng := lbc.createMergeableIngresses(impl)
 				result.MergeableIngresses = append(result.MergeableIngresses, mergeableIng)
 			} else {
-				ingEx := lbc.createIngressEx(impl.Ingress, impl.ValidHosts, nil)
+				ingEx := lbc.createRegularIngressEx(impl)
 				result.IngressExes = append(result.IngressExes, ingEx)
 			}
 		case *TransportServerConfiguration:
@@ -702,7 +824,7 @@ func (lbc *LoadBalancerController) preSyncSecrets() {
 
 func (lbc *LoadBalancerController) sync(task task) {
 	glog.V(3).Infof("Syncing %v", task.Key)
-	if lbc.spiffeController != nil {
+	if lbc.spiffeController != nil || lbc.isDebugAPIEnabled {
 		lbc.syncLock.Lock()
 		defer lbc.syncLock.Unlock()
 	}
@@ -728,6 +850,12 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncGlobalConfiguration(task)
 	case transportserver:
 		lbc.syncTransportServer(task)
+	case hostPolicy:
+		lbc.syncHostPolicy(task)
+	case referenceGrant:
+		lbc.syncReferenceGrant(task)
+	case defaultPolicy:
+		lbc.syncDefaultPolicy(task)
 	case policy:
 		lbc.syncPolicy(task)
 	case appProtectPolicy:
@@ -823,7 +951,17 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 			lbc.recorder.Eventf(pol, api_v1.EventTypeWarning, "Rejected", msg)
 
 			if lbc.reportCustomResourceStatusEnabled() {
-				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateInvalid, "Rejected", msg)
+				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateInvalid, "Rejected", msg, lbc.getPolicyReferencedBy(pol.Namespace, pol.Name))
+				if err != nil {
+					glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
+				}
+			}
+		} else if discoveryErr := lbc.getOIDCDiscoveryError(pol); discoveryErr != nil {
+			msg := fmt.Sprintf("Policy %v/%v was added or updated with warning: %v", pol.Namespace, pol.Name, discoveryErr)
+			lbc.recorder.Eventf(pol, api_v1.EventTypeWarning, "AddedOrUpdatedWithWarning", msg)
+
+			if lbc.reportCustomResourceStatusEnabled() {
+				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateWarning, "AddedOrUpdatedWithWarning", msg, lbc.getPolicyReferencedBy(pol.Namespace, pol.Name))
 				if err != nil {
 					glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
 				}
@@ -833,7 +971,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 			lbc.recorder.Eventf(pol, api_v1.EventTypeNormal, "AddedOrUpdated", msg)
 
 			if lbc.reportCustomResourceStatusEnabled() {
-				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateValid, "AddedOrUpdated", msg)
+				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateValid, "AddedOrUpdated", msg, lbc.getPolicyReferencedBy(pol.Namespace, pol.Name))
 				if err != nil {
 					glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
 				}
@@ -844,15 +982,14 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	// it is safe to ignore the error
 	namespace, name, _ := ParseNamespaceName(key)
 
-	resources := lbc.configuration.FindResourcesForPolicy(namespace, name)
-	resourceExes := lbc.createExtendedResources(resources)
-
-	// Only VirtualServers support policies
-	if len(resourceExes.VirtualServerExes) == 0 {
+	resources := removeDuplicateResources(lbc.configuration.FindResourcesForPolicy(namespace, name))
+	if len(resources) == 0 {
 		return
 	}
 
-	warnings, updateErr := lbc.configurator.AddOrUpdateVirtualServers(resourceExes.VirtualServerExes)
+	resourceExes := lbc.createExtendedResources(resources)
+
+	warnings, updateErr := lbc.configurator.AddOrUpdateResources(resourceExes)
 	lbc.updateResourcesStatusAndEvents(resources, warnings, updateErr)
 
 	// Note: updating the status of a policy based on a reload is not needed.
@@ -931,6 +1068,151 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 	lbc.processProblems(problems)
 }
 
+func (lbc *LoadBalancerController) syncHostPolicy(task task) {
+	key := task.Key
+	obj, hpExists, err := lbc.hostPolicyLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	var changes []ResourceChange
+	var problems []ConfigurationProblem
+	var validationErr error
+
+	if !hpExists {
+		glog.V(2).Infof("Deleting HostPolicy: %v\n", key)
+
+		changes, problems = lbc.configuration.DeleteHostPolicy(key)
+	} else {
+		glog.V(2).Infof("Adding or Updating HostPolicy: %v\n", key)
+
+		hp := obj.(*conf_v1alpha1.HostPolicy)
+		changes, problems, validationErr = lbc.configuration.AddOrUpdateHostPolicy(hp)
+	}
+
+	lbc.processChanges(changes)
+
+	if hpExists {
+		eventTitle := "Updated"
+		eventType := api_v1.EventTypeNormal
+		eventMessage := fmt.Sprintf("HostPolicy %s was added or updated", key)
+
+		if validationErr != nil {
+			eventTitle = "Rejected"
+			eventType = api_v1.EventTypeWarning
+			eventMessage = fmt.Sprintf("HostPolicy %s is invalid and was ignored: %v", key, validationErr)
+		}
+
+		hp := obj.(*conf_v1alpha1.HostPolicy)
+		lbc.recorder.Eventf(hp, eventType, eventTitle, eventMessage)
+	}
+
+	lbc.processProblems(problems)
+}
+
+func (lbc *LoadBalancerController) syncDefaultPolicy(task task) {
+	key := task.Key
+	obj, dpExists, err := lbc.defaultPolicyLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	var changes []ResourceChange
+	var problems []ConfigurationProblem
+	var validationErr error
+
+	if !dpExists {
+		glog.V(2).Infof("Deleting DefaultPolicy: %v\n", key)
+
+		changes, problems = lbc.configuration.DeleteDefaultPolicy(key)
+	} else {
+		glog.V(2).Infof("Adding or Updating DefaultPolicy: %v\n", key)
+
+		dp := obj.(*conf_v1alpha1.DefaultPolicy)
+		changes, problems, validationErr = lbc.configuration.AddOrUpdateDefaultPolicy(dp)
+	}
+
+	lbc.processChanges(changes)
+
+	if dpExists && lbc.HasCorrectIngressClass(obj) {
+		eventTitle := "Updated"
+		eventType := api_v1.EventTypeNormal
+		eventMessage := fmt.Sprintf("DefaultPolicy %s was added or updated", key)
+
+		if validationErr != nil {
+			eventTitle = "Rejected"
+			eventType = api_v1.EventTypeWarning
+			eventMessage = fmt.Sprintf("DefaultPolicy %s is invalid and was ignored: %v", key, validationErr)
+		}
+
+		dp := obj.(*conf_v1alpha1.DefaultPolicy)
+		lbc.recorder.Eventf(dp, eventType, eventTitle, eventMessage)
+	}
+
+	lbc.processProblems(problems)
+}
+
+func (lbc *LoadBalancerController) syncReferenceGrant(task task) {
+	key := task.Key
+	obj, rgExists, err := lbc.referenceGrantLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	var changes []ResourceChange
+	var problems []ConfigurationProblem
+	var validationErr error
+
+	if !rgExists {
+		glog.V(2).Infof("Deleting ReferenceGrant: %v\n", key)
+
+		changes, problems = lbc.configuration.DeleteReferenceGrant(key)
+	} else {
+		glog.V(2).Infof("Adding or Updating ReferenceGrant: %v\n", key)
+
+		rg := obj.(*conf_v1alpha1.ReferenceGrant)
+		changes, problems, validationErr = lbc.configuration.AddOrUpdateReferenceGrant(rg)
+	}
+
+	lbc.processChanges(changes)
+
+	if rgExists {
+		eventTitle := "Updated"
+		eventType := api_v1.EventTypeNormal
+		eventMessage := fmt.Sprintf("ReferenceGrant %s was added or updated", key)
+
+		if validationErr != nil {
+			eventTitle = "Rejected"
+			eventType = api_v1.EventTypeWarning
+			eventMessage = fmt.Sprintf("ReferenceGrant %s is invalid and was ignored: %v", key, validationErr)
+		}
+
+		rg := obj.(*conf_v1alpha1.ReferenceGrant)
+		lbc.recorder.Eventf(rg, eventType, eventTitle, eventMessage)
+	}
+
+	lbc.processProblems(problems)
+
+	// The VirtualServers and Ingresses that reference Policies from the namespace of the ReferenceGrant must be updated,
+	// because the Policies and their Secrets might have become allowed or not allowed.
+
+	// it is safe to ignore the error
+	namespace, _, _ := ParseNamespaceName(key)
+
+	resources := lbc.configuration.FindResourcesForReferenceGrant(namespace)
+	if len(resources) == 0 {
+		return
+	}
+
+	resourceExes := lbc.createExtendedResources(resources)
+
+	warnings, updateErr := lbc.configurator.AddOrUpdateResources(resourceExes)
+	lbc.updateResourcesStatusAndEvents(resources, warnings, updateErr)
+}
+
 func (lbc *LoadBalancerController) syncVirtualServer(task task) {
 	key := task.Key
 	obj, vsExists, err := lbc.virtualServerLister.GetByKey(key)
@@ -988,7 +1270,7 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 				}
 			case *conf_v1.VirtualServerRoute:
 				var emptyVSes []*conf_v1.VirtualServer
-				err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(obj, state, p.Reason, p.Message, emptyVSes)
+				err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(obj, state, p.Reason, p.Message, emptyVSes, nil)
 				if err != nil {
 					glog.Errorf("Error when updating the status for VirtualServerRoute %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
@@ -1004,7 +1286,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 		if c.Op == AddOrUpdate {
 			switch impl := c.Resource.(type) {
 			case *VirtualServerConfiguration:
-				vsEx := lbc.createVirtualServerEx(impl.VirtualServer, impl.VirtualServerRoutes)
+				vsEx := lbc.createVirtualServerEx(impl.VirtualServer, impl.VirtualServerRoutes, impl.InheritedSubroutes)
 
 				warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateVirtualServer(vsEx)
 				lbc.updateVirtualServerStatusAndEvents(impl, warnings, addOrUpdateErr)
@@ -1015,8 +1297,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 					warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateMergeableIngress(mergeableIng)
 					lbc.updateMergeableIngressStatusAndEvents(impl, warnings, addOrUpdateErr)
 				} else {
-					// for regular Ingress, validMinionPaths is nil
-					ingEx := lbc.createIngressEx(impl.Ingress, impl.ValidHosts, nil)
+					ingEx := lbc.createRegularIngressEx(impl)
 
 					warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateIngress(ingEx)
 					lbc.updateRegularIngressStatusAndEvents(impl, warnings, addOrUpdateErr)
@@ -1045,6 +1326,8 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if vsExists {
 					lbc.UpdateVirtualServerStatusAndEventsOnDelete(impl, c.Error, deleteErr)
 				}
+
+				lbc.deletePolicyReferences(impl)
 			case *IngressConfiguration:
 				key := getResourceKey(&impl.Ingress.ObjectMeta)
 
@@ -1063,6 +1346,8 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				if ingExists {
 					lbc.UpdateIngressStatusAndEventsOnDelete(impl, c.Error, deleteErr)
 				}
+
+				lbc.deleteIngressPolicyReferences(impl)
 			case *TransportServerConfiguration:
 				key := getResourceKey(&impl.TransportServer.ObjectMeta)
 
@@ -1423,6 +1708,8 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 		lbc.recorder.Eventf(fm.Ingress, minionEventType, minionEventTitle, minionMsg)
 	}
 
+	lbc.updateIngressPolicyReferences(ingConfig)
+
 	if lbc.reportStatusEnabled() {
 		ings := []networking.Ingress{*ingConfig.Ingress}
 
@@ -1463,8 +1750,50 @@ func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&ingConfig.Ingress.ObjectMeta), eventWarningMessage)
 	lbc.recorder.Eventf(ingConfig.Ingress, eventType, eventTitle, msg)
 
+	for _, cc := range ingConfig.Canaries {
+		canaryEventType := api_v1.EventTypeNormal
+		canaryEventTitle := "AddedOrUpdated"
+		canaryEventWarningMessage := ""
+
+		canaryChangeWarnings := ingConfig.ChildWarnings[getResourceKey(&cc.Ingress.ObjectMeta)]
+		if len(canaryChangeWarnings) > 0 {
+			canaryEventType = api_v1.EventTypeWarning
+			canaryEventTitle = "AddedOrUpdatedWithWarning"
+			canaryEventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(canaryChangeWarnings))
+		}
+
+		if messages, ok := warnings[cc.Ingress]; ok {
+			canaryEventType = api_v1.EventTypeWarning
+			canaryEventTitle = "AddedOrUpdatedWithWarning"
+			canaryEventWarningMessage = fmt.Sprintf("%s; with warning(s): %v", canaryEventWarningMessage, formatWarningMessages(messages))
+		}
+
+		if operationErr != nil {
+			canaryEventType = api_v1.EventTypeWarning
+			canaryEventTitle = "AddedOrUpdatedWithError"
+			canaryEventWarningMessage = fmt.Sprintf("%s; but was not applied: %v", canaryEventWarningMessage, operationErr)
+		}
+
+		canaryMsg := fmt.Sprintf("Configuration for canary %v/%v of %v was added or updated %s", cc.Ingress.Namespace, cc.Ingress.Name,
+			getResourceKey(&ingConfig.Ingress.ObjectMeta), canaryEventWarningMessage)
+		lbc.recorder.Eventf(cc.Ingress, canaryEventType, canaryEventTitle, canaryMsg)
+	}
+
+	lbc.updateIngressPolicyReferences(ingConfig)
+
 	if lbc.reportStatusEnabled() {
-		err := lbc.statusUpdater.UpdateIngressStatus(*ingConfig.Ingress)
+		var err error
+		if len(ingConfig.Canaries) > 0 {
+			ings := []networking.Ingress{*ingConfig.Ingress}
+
+			for _, cc := range ingConfig.Canaries {
+				ings = append(ings, *cc.Ingress)
+			}
+
+			err = lbc.statusUpdater.BulkUpdateIngressStatus(ings)
+		} else {
+			err = lbc.statusUpdater.UpdateIngressStatus(*ingConfig.Ingress)
+		}
 		if err != nil {
 			glog.V(3).Infof("error updating ing status: %v", err)
 		}
@@ -1533,7 +1862,7 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 	lbc.recorder.Eventf(vsConfig.VirtualServer, eventType, eventTitle, msg)
 
 	if lbc.reportCustomResourceStatusEnabled() {
-		err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
+		err := lbc.statusUpdater.UpdateVirtualServerStatusWithDefaultPolicies(vsConfig.VirtualServer, state, eventTitle, msg, vsConfig.DefaultPolicies)
 		if err != nil {
 			glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
 		}
@@ -1564,12 +1893,15 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 
 		if lbc.reportCustomResourceStatusEnabled() {
 			vss := []*conf_v1.VirtualServer{vsConfig.VirtualServer}
-			err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(vsr, vsrState, vsrEventTitle, msg, vss)
+			vsrDefaultPolicies := vsConfig.VirtualServerRouteDefaultPolicies[getResourceKey(&vsr.ObjectMeta)]
+			err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(vsr, vsrState, vsrEventTitle, msg, vss, vsrDefaultPolicies)
 			if err != nil {
 				glog.Errorf("Error when updating the status for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 		}
 	}
+
+	lbc.updatePolicyReferences(vsConfig)
 }
 
 func (lbc *LoadBalancerController) syncVirtualServerRoute(task task) {
@@ -1966,13 +2298,13 @@ func (lbc *LoadBalancerController) updatePoliciesStatus() error {
 		err := validation.ValidatePolicy(pol, lbc.isNginxPlus, lbc.enablePreviewPolicies, lbc.appProtectEnabled)
 		if err != nil {
 			msg := fmt.Sprintf("Policy %v/%v is invalid and was rejected: %v", pol.Namespace, pol.Name, err)
-			err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateInvalid, "Rejected", msg)
+			err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateInvalid, "Rejected", msg, lbc.getPolicyReferencedBy(pol.Namespace, pol.Name))
 			if err != nil {
 				allErrs = append(allErrs, err)
 			}
 		} else {
 			msg := fmt.Sprintf("Policy %v/%v was added or updated", pol.Namespace, pol.Name)
-			err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateValid, "AddedOrUpdated", msg)
+			err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateValid, "AddedOrUpdated", msg, lbc.getPolicyReferencedBy(pol.Namespace, pol.Name))
 			if err != nil {
 				allErrs = append(allErrs, err)
 			}
@@ -2047,11 +2379,33 @@ func (lbc *LoadBalancerController) createMergeableIngresses(ingConfig *IngressCo
 	}
 }
 
+func (lbc *LoadBalancerController) createRegularIngressEx(ingConfig *IngressConfiguration) *configs.IngressEx {
+	// for regular Ingress, validMinionPaths is nil
+	ingEx := lbc.createIngressEx(ingConfig.Ingress, ingConfig.ValidHosts, nil)
+
+	if len(ingConfig.Canaries) > 0 {
+		ingEx.Canaries = make(map[string]*configs.IngressEx)
+	}
+
+	for _, c := range ingConfig.Canaries {
+		canaryEx := lbc.createIngressEx(c.Ingress, ingConfig.ValidHosts, nil)
+
+		for pathKey, valid := range c.ValidPaths {
+			if valid {
+				ingEx.Canaries[pathKey] = canaryEx
+			}
+		}
+	}
+
+	return ingEx
+}
+
 func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, validHosts map[string]bool, validMinionPaths map[string]bool) *configs.IngressEx {
 	ingEx := &configs.IngressEx{
-		Ingress:          ing,
-		ValidHosts:       validHosts,
-		ValidMinionPaths: validMinionPaths,
+		Ingress:            ing,
+		ValidHosts:         validHosts,
+		ValidMinionPaths:   validMinionPaths,
+		AnnotationWarnings: validateIngressAnnotationNames(ing.Annotations),
 	}
 
 	ingEx.SecretRefs = make(map[string]*secrets.SecretReference)
@@ -2101,6 +2455,28 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		}
 	}
 
+	policies, policyErrors := lbc.getPolicies(configs.GetPolicyReferences(ing), ingressKind, ing.Namespace)
+	for _, err := range policyErrors {
+		glog.Warningf("Error getting policy for Ingress %s/%s: %v", ing.Namespace, ing.Name, err)
+	}
+	// the errors are reported in the status of the referenced Policies
+	lbc.policyReferenceProblems.setErrors(getResourceKeyWithKind(ingressKind, &ing.ObjectMeta), policyErrors)
+
+	err := lbc.addJWTSecretRefs(ingEx.SecretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting JWT secrets for Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+	}
+	err = lbc.addIngressMTLSSecretRefs(ingEx.SecretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting IngressMTLS secret for Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+	}
+	err = lbc.addEgressMTLSSecretRefs(ingEx.SecretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting EgressMTLS secrets for Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+	}
+
+	ingEx.Policies = createPolicyMap(policies)
+
 	ingEx.Endpoints = make(map[string][]string)
 	ingEx.HealthChecks = make(map[string]*api_v1.Probe)
 	ingEx.ExternalNameSvcs = make(map[string]bool)
@@ -2249,7 +2625,7 @@ func (lbc *LoadBalancerController) getAppProtectPolicy(ing *networking.Ingress)
 	return apPolicy, nil
 }
 
-func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.VirtualServer, virtualServerRoutes []*conf_v1.VirtualServerRoute) *configs.VirtualServerEx {
+func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.VirtualServer, virtualServerRoutes []*conf_v1.VirtualServerRoute, inheritedSubroutes map[string][]string) *configs.VirtualServerEx {
 	virtualServerEx := configs.VirtualServerEx{
 		VirtualServer: virtualServer,
 		SecretRefs:    make(map[string]*secrets.SecretReference),
@@ -2268,10 +2644,11 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		virtualServerEx.SecretRefs[secretKey] = secretRef
 	}
 
-	policies, policyErrors := lbc.getPolicies(virtualServer.Spec.Policies, virtualServer.Namespace)
+	policies, policyErrors := lbc.getPolicies(virtualServer.Spec.Policies, virtualServerKind, virtualServer.Namespace)
 	for _, err := range policyErrors {
 		glog.Warningf("Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
+	vsPolicyErrors := policyErrors
 
 	err := lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
@@ -2289,6 +2666,10 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	if err != nil {
 		glog.Warningf("Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
+	err = lbc.addAPIKeySecretRefs(virtualServerEx.SecretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting API key secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+	}
 
 	err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, policies)
 	if err != nil {
@@ -2347,10 +2728,11 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	}
 
 	for _, r := range virtualServer.Spec.Routes {
-		vsRoutePolicies, policyErrors := lbc.getPolicies(r.Policies, virtualServer.Namespace)
+		vsRoutePolicies, policyErrors := lbc.getPolicies(r.Policies, virtualServerKind, virtualServer.Namespace)
 		for _, err := range policyErrors {
 			glog.Warningf("Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
+		vsPolicyErrors = append(vsPolicyErrors, policyErrors...)
 		policies = append(policies, vsRoutePolicies...)
 
 		err = lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
@@ -2370,14 +2752,26 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		if err != nil {
 			glog.Warningf("Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
+		err = lbc.addAPIKeySecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
+		if err != nil {
+			glog.Warningf("Error getting API key secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		}
 	}
 
+	// the errors are reported in the status of the referenced Policies
+	lbc.policyReferenceProblems.setErrors(getResourceKeyWithKind(virtualServerKind, &virtualServer.ObjectMeta), vsPolicyErrors)
+
 	for _, vsr := range virtualServerRoutes {
+		var vsrPolicyErrors []error
+
 		for _, sr := range vsr.Spec.Subroutes {
-			vsrSubroutePolicies, policyErrors := lbc.getPolicies(sr.Policies, vsr.Namespace)
+			// the policies inherited from the VirtualServer route are already fetched along with the VirtualServer routes
+			subroutePolicies := getOwnSubroutePolicies(virtualServer, vsr, sr, inheritedSubroutes)
+			vsrSubroutePolicies, policyErrors := lbc.getPolicies(subroutePolicies, virtualServerRouteKind, vsr.Namespace)
 			for _, err := range policyErrors {
 				glog.Warningf("Error getting policy for VirtualServerRoute %s/%s: %v", vsr.Namespace, vsr.Name, err)
 			}
+			vsrPolicyErrors = append(vsrPolicyErrors, policyErrors...)
 			policies = append(policies, vsrSubroutePolicies...)
 
 			err = lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
@@ -2395,12 +2789,19 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				glog.Warningf("Error getting OIDC secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
+			err = lbc.addAPIKeySecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
+			if err != nil {
+				glog.Warningf("Error getting API key secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+			}
+
 			err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, vsrSubroutePolicies)
 			if err != nil {
 				glog.Warningf("Error getting WAF policies for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 		}
 
+		lbc.policyReferenceProblems.setErrors(getResourceKeyWithKind(virtualServerRouteKind, &vsr.ObjectMeta), vsrPolicyErrors)
+
 		for _, u := range vsr.Spec.Upstreams {
 			endpointsKey := configs.GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
 
@@ -2449,6 +2850,7 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	virtualServerEx.VirtualServerRoutes = virtualServerRoutes
 	virtualServerEx.ExternalNameSvcs = externalNameSvcs
 	virtualServerEx.Policies = createPolicyMap(policies)
+	virtualServerEx.OIDCDiscoveries = lbc.getOIDCDiscoveries(policies)
 	virtualServerEx.PodsByIP = podsByIP
 
 	return &virtualServerEx
@@ -2483,7 +2885,7 @@ func (lbc *LoadBalancerController) getAllPolicies() []*conf_v1.Policy {
 	return policies
 }
 
-func (lbc *LoadBalancerController) getPolicies(policies []conf_v1.PolicyReference, ownerNamespace string) ([]*conf_v1.Policy, []error) {
+func (lbc *LoadBalancerController) getPolicies(policies []conf_v1.PolicyReference, ownerKind string, ownerNamespace string) ([]*conf_v1.Policy, []error) {
 	var result []*conf_v1.Policy
 	var errors []error
 
@@ -2495,43 +2897,78 @@ func (lbc *LoadBalancerController) getPolicies(policies []conf_v1.PolicyReferenc
 
 		policyKey := fmt.Sprintf("%s/%s", polNamespace, p.Name)
 
+		if lbc.isReferenceGrantsEnabled && !lbc.configuration.IsReferenceAllowed(ownerKind, ownerNamespace, policyKind, polNamespace, p.Name) {
+			errors = append(errors, newPolicyError(policyKey, fmt.Errorf("Policy %s is not allowed to be referenced from namespace %s: no ReferenceGrant in namespace %s", policyKey, ownerNamespace, polNamespace)))
+			continue
+		}
+
 		policyObj, exists, err := lbc.policyLister.GetByKey(policyKey)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("Failed to get policy %s: %w", policyKey, err))
+			errors = append(errors, newPolicyError(policyKey, fmt.Errorf("Failed to get policy %s: %w", policyKey, err)))
 			continue
 		}
 
 		if !exists {
-			errors = append(errors, fmt.Errorf("Policy %s doesn't exist", policyKey))
+			errors = append(errors, newPolicyError(policyKey, fmt.Errorf("Policy %s doesn't exist", policyKey)))
 			continue
 		}
 
 		policy := policyObj.(*conf_v1.Policy)
 
 		if !lbc.HasCorrectIngressClass(policy) {
-			errors = append(errors, fmt.Errorf("referenced policy %s has incorrect ingress class: %s (controller ingress class: %s)", policyKey, policy.Spec.IngressClass, lbc.ingressClass))
+			errors = append(errors, newPolicyError(policyKey, fmt.Errorf("referenced policy %s has incorrect ingress class: %s (controller ingress class: %s)", policyKey, policy.Spec.IngressClass, lbc.ingressClass)))
 			continue
 		}
 
 		err = validation.ValidatePolicy(policy, lbc.isNginxPlus, lbc.enablePreviewPolicies, lbc.appProtectEnabled)
 		if err != nil {
-			errors = append(errors, fmt.Errorf("Policy %s is invalid: %w", policyKey, err))
+			errors = append(errors, newPolicyError(policyKey, fmt.Errorf("Policy %s is invalid: %w", policyKey, err)))
 			continue
 		}
 
+		if lbc.isReferenceGrantsEnabled {
+			err = lbc.checkPolicySecretReferences(policy, ownerKind, ownerNamespace)
+			if err != nil {
+				errors = append(errors, newPolicyError(policyKey, err))
+				continue
+			}
+		}
+
 		result = append(result, policy)
 	}
 
 	return result, errors
 }
 
+// checkPolicySecretReferences checks that the owner of the Policy reference can reference the Secrets of the Policy
+// from another namespace, because those Secrets become part of the configuration of the owner.
+func (lbc *LoadBalancerController) checkPolicySecretReferences(policy *conf_v1.Policy, ownerKind string, ownerNamespace string) error {
+	for _, name := range getPolicySecretNames(policy) {
+		if !lbc.configuration.IsReferenceAllowed(ownerKind, ownerNamespace, secretKind, policy.Namespace, name) {
+			return fmt.Errorf("Secret %s/%s of Policy %s/%s is not allowed to be referenced from namespace %s: no ReferenceGrant in namespace %s",
+				policy.Namespace, name, policy.Namespace, policy.Name, ownerNamespace, policy.Namespace)
+		}
+	}
+
+	return nil
+}
+
 func (lbc *LoadBalancerController) addJWTSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
 	for _, pol := range policies {
 		if pol.Spec.JWTAuth == nil {
 			continue
 		}
 
-		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, pol.Spec.JWTAuth.Secret)
+		// a JWT policy with a JWKS URI references a secret with the trusted CA certificate instead of a secret with the JWK
+		secretName := pol.Spec.JWTAuth.Secret
+		if secretName == "" {
+			secretName = pol.Spec.JWTAuth.TrustedCertSecret
+		}
+		if secretName == "" {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, secretName)
 		secretRef := lbc.secretStore.GetSecret(secretKey)
 
 		secretRefs[secretKey] = secretRef
@@ -2609,6 +3046,107 @@ func (lbc *LoadBalancerController) addOIDCSecretRefs(secretRefs map[string]*secr
 	return nil
 }
 
+func (lbc *LoadBalancerController) addAPIKeySecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
+	for _, pol := range policies {
+		if pol.Spec.APIKey == nil {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, pol.Spec.APIKey.ClientSecret)
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+
+		secretRefs[secretKey] = secretRef
+
+		if secretRef.Error != nil {
+			return secretRef.Error
+		}
+	}
+	return nil
+}
+
+// getOIDCDiscoveries returns the discoveries of the issuers of the OIDC policies. The issuers whose discovery
+// hasn't completed yet are not included. When the discovery completes, the policies of the issuer are enqueued.
+func (lbc *LoadBalancerController) getOIDCDiscoveries(policies []*conf_v1.Policy) map[string]*configs.OIDCDiscovery {
+	discoveries := make(map[string]*configs.OIDCDiscovery)
+
+	for _, pol := range policies {
+		if pol.Spec.OIDC == nil || pol.Spec.OIDC.Issuer == "" {
+			continue
+		}
+
+		issuer := pol.Spec.OIDC.Issuer
+		if _, exists := discoveries[issuer]; exists {
+			continue
+		}
+
+		ref := lbc.oidcDiscoverer.GetDiscovery(issuer)
+		if ref == nil {
+			continue
+		}
+
+		// after a failed refresh, the previously discovered metadata is still used
+		if ref.Metadata == nil {
+			discoveries[issuer] = &configs.OIDCDiscovery{
+				Error: ref.Error,
+			}
+			continue
+		}
+
+		discoveries[issuer] = &configs.OIDCDiscovery{
+			AuthEndpoint:       ref.Metadata.AuthorizationEndpoint,
+			TokenEndpoint:      ref.Metadata.TokenEndpoint,
+			JWKSURI:            ref.Metadata.JWKSURI,
+			EndSessionEndpoint: ref.Metadata.EndSessionEndpoint,
+		}
+	}
+
+	return discoveries
+}
+
+// getOIDCDiscoveryError returns the error of the discovery of the issuer of the OIDC policy, if any.
+func (lbc *LoadBalancerController) getOIDCDiscoveryError(pol *conf_v1.Policy) error {
+	if pol.Spec.OIDC == nil || pol.Spec.OIDC.Issuer == "" {
+		return nil
+	}
+
+	ref := lbc.oidcDiscoverer.GetDiscovery(pol.Spec.OIDC.Issuer)
+	if ref != nil && ref.Error != nil {
+		return fmt.Errorf("the discovery of the metadata of the issuer %s failed: %w", pol.Spec.OIDC.Issuer, ref.Error)
+	}
+
+	return nil
+}
+
+// refreshOIDCDiscoveries refreshes the discoveries of the issuers of the OIDC policies.
+// The policies whose issuer discovery has changed are enqueued by enqueuePoliciesForOIDCIssuer.
+func (lbc *LoadBalancerController) refreshOIDCDiscoveries() {
+	var issuers []string
+
+	for _, obj := range lbc.policyLister.List() {
+		pol := obj.(*conf_v1.Policy)
+		if pol.Spec.OIDC == nil || pol.Spec.OIDC.Issuer == "" {
+			continue
+		}
+
+		issuers = append(issuers, pol.Spec.OIDC.Issuer)
+	}
+
+	lbc.oidcDiscoverer.Refresh(issuers)
+}
+
+// enqueuePoliciesForOIDCIssuer enqueues the OIDC policies of the issuer, so that the resources that reference them
+// are updated with the discovery of the issuer. It is called by the OIDC discoverer when a discovery completes or changes.
+func (lbc *LoadBalancerController) enqueuePoliciesForOIDCIssuer(issuer string) {
+	glog.V(3).Infof("The discovery of the metadata of the OIDC issuer %s has changed", issuer)
+
+	for _, obj := range lbc.policyLister.List() {
+		pol := obj.(*conf_v1.Policy)
+		if pol.Spec.OIDC != nil && pol.Spec.OIDC.Issuer == issuer {
+			lbc.syncQueue.Enqueue(pol)
+		}
+	}
+}
+
 // addWAFPolicyRefs ensures the app protect resources that are referenced in policies exist.
 func (lbc *LoadBalancerController) addWAFPolicyRefs(
 	apPolRef, logConfRef map[string]*unstructured.Unstructured,
@@ -2661,12 +3199,16 @@ func findPoliciesForSecret(policies []*conf_v1.Policy, secretNamespace string, s
 			res = append(res, pol)
 		} else if pol.Spec.JWTAuth != nil && pol.Spec.JWTAuth.Secret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
+		} else if pol.Spec.JWTAuth != nil && pol.Spec.JWTAuth.TrustedCertSecret == secretName && pol.Namespace == secretNamespace {
+			res = append(res, pol)
 		} else if pol.Spec.EgressMTLS != nil && pol.Spec.EgressMTLS.TLSSecret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
 		} else if pol.Spec.EgressMTLS != nil && pol.Spec.EgressMTLS.TrustedCertSecret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
 		} else if pol.Spec.OIDC != nil && pol.Spec.OIDC.ClientSecret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
+		} else if pol.Spec.APIKey != nil && pol.Spec.APIKey.ClientSecret == secretName && pol.Namespace == secretNamespace {
+			res = append(res, pol)
 		}
 	}
 
@@ -3073,6 +3615,8 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 		class = obj.Spec.IngressClass
 	case *conf_v1.Policy:
 		class = obj.Spec.IngressClass
+	case *conf_v1alpha1.DefaultPolicy:
+		class = obj.Spec.IngressClass
 	case *networking.Ingress:
 		class = obj.Annotations[ingressClassKey]
 		if class == "" && obj.Spec.IngressClassName != nil {
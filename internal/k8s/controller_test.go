@@ -1,18 +1,23 @@
 package k8s
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/oidc"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
@@ -682,13 +687,13 @@ func TestGetPolicies(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("Policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `jwt`, `oidc`, `waf`"),
+		errors.New("Policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `securityHeaders`, `jwt`, `oidc`, `apiKey`, `waf`"),
 		errors.New("Policy nginx-ingress/valid-policy doesn't exist"),
 		errors.New("Failed to get policy nginx-ingress/some-policy: GetByKey error"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
 	}
 
-	result, errors := lbc.getPolicies(policyRefs, "default")
+	result, errors := lbc.getPolicies(policyRefs, virtualServerKind, "default")
 	if !reflect.DeepEqual(result, expectedPolicies) {
 		t.Errorf("lbc.getPolicies() returned \n%v but \nexpected %v", result, expectedPolicies)
 	}
@@ -697,6 +702,102 @@ func TestGetPolicies(t *testing.T) {
 	}
 }
 
+func TestGetPoliciesWithReferenceGrants(t *testing.T) {
+	accessControlPolicy := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "access-control-policy",
+			Namespace: "team-a",
+		},
+		Spec: conf_v1.PolicySpec{
+			AccessControl: &conf_v1.AccessControl{
+				Allow: []string{"127.0.0.1"},
+			},
+		},
+	}
+
+	jwtPolicy := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "jwt-policy",
+			Namespace: "team-a",
+		},
+		Spec: conf_v1.PolicySpec{
+			JWTAuth: &conf_v1.JWTAuth{
+				Realm:  "My API",
+				Secret: "jwk-secret",
+			},
+		},
+	}
+
+	configuration := createTestConfiguration()
+	configuration.isReferenceGrantsEnabled = true
+	configuration.AddOrUpdateReferenceGrant(createTestReferenceGrant("reference-grant", "team-a",
+		[]conf_v1alpha1.ReferenceGrantFrom{
+			{
+				Kind:      "VirtualServer",
+				Namespace: "default",
+			},
+		},
+		[]conf_v1alpha1.ReferenceGrantTo{
+			{
+				Kind: "Policy",
+			},
+		}))
+
+	lbc := LoadBalancerController{
+		isNginxPlus:              true,
+		enablePreviewPolicies:    true,
+		isReferenceGrantsEnabled: true,
+		configuration:            configuration,
+		policyLister: &cache.FakeCustomStore{
+			GetByKeyFunc: func(key string) (item interface{}, exists bool, err error) {
+				switch key {
+				case "team-a/access-control-policy":
+					return accessControlPolicy, true, nil
+				case "team-a/jwt-policy":
+					return jwtPolicy, true, nil
+				default:
+					return nil, false, errors.New("GetByKey error")
+				}
+			},
+		},
+	}
+
+	policyRefs := []conf_v1.PolicyReference{
+		{
+			Name:      "access-control-policy",
+			Namespace: "team-a",
+		},
+		{
+			Name:      "jwt-policy", // its secret is not granted
+			Namespace: "team-a",
+		},
+		{
+			Name:      "access-control-policy", // no ReferenceGrant in team-b
+			Namespace: "team-b",
+		},
+	}
+
+	expectedPolicies := []*conf_v1.Policy{accessControlPolicy}
+	expectedErrors := []error{
+		errors.New("Secret team-a/jwk-secret of Policy team-a/jwt-policy is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-a"),
+		errors.New("Policy team-b/access-control-policy is not allowed to be referenced from namespace default: no ReferenceGrant in namespace team-b"),
+	}
+
+	result, errors := lbc.getPolicies(policyRefs, virtualServerKind, "default")
+	if !reflect.DeepEqual(result, expectedPolicies) {
+		t.Errorf("lbc.getPolicies() returned \n%v but \nexpected %v", result, expectedPolicies)
+	}
+	if diff := cmp.Diff(expectedErrors, errors, cmp.Comparer(errorComparer)); diff != "" {
+		t.Errorf("lbc.getPolicies() mismatch (-want +got):\n%s", diff)
+	}
+
+	// VirtualServerRoutes are not granted
+	result, errors = lbc.getPolicies(policyRefs[:1], virtualServerRouteKind, "default")
+	if len(result) != 0 || len(errors) != 1 {
+		t.Errorf("lbc.getPolicies() returned policies %v and errors %v but expected no policies and one error", result, errors)
+	}
+}
+
 func TestCreatePolicyMap(t *testing.T) {
 	policies := []*conf_v1.Policy{
 		{
@@ -1002,6 +1103,17 @@ func TestFindPoliciesForSecret(t *testing.T) {
 			},
 		},
 	}
+	apiKeyPol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "api-key-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			APIKey: &conf_v1.APIKey{
+				ClientSecret: "api-key-secret",
+			},
+		},
+	}
 
 	tests := []struct {
 		policies        []*conf_v1.Policy
@@ -1087,6 +1199,20 @@ func TestFindPoliciesForSecret(t *testing.T) {
 			expected:        []*conf_v1.Policy{oidcPol},
 			msg:             "Find policy in default ns, ignore other types",
 		},
+		{
+			policies:        []*conf_v1.Policy{apiKeyPol},
+			secretNamespace: "default",
+			secretName:      "api-key-secret",
+			expected:        []*conf_v1.Policy{apiKeyPol},
+			msg:             "Find policy in default ns",
+		},
+		{
+			policies:        []*conf_v1.Policy{oidcPol, apiKeyPol},
+			secretNamespace: "default",
+			secretName:      "api-key-secret",
+			expected:        []*conf_v1.Policy{apiKeyPol},
+			msg:             "Find policy in default ns, ignore other types",
+		},
 	}
 	for _, test := range tests {
 		result := findPoliciesForSecret(test.policies, test.secretNamespace, test.secretName)
@@ -1120,6 +1246,13 @@ func TestAddJWTSecrets(t *testing.T) {
 		},
 		Type: secrets.SecretTypeJWK,
 	}
+	validJWKSCASecret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "valid-jwks-ca-secret",
+			Namespace: "default",
+		},
+		Type: secrets.SecretTypeCA,
+	}
 
 	tests := []struct {
 		policies           []*conf_v1.Policy
@@ -1151,6 +1284,32 @@ func TestAddJWTSecrets(t *testing.T) {
 			wantErr: false,
 			msg:     "test getting valid secret",
 		},
+		{
+			policies: []*conf_v1.Policy{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "jwt-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						JWTAuth: &conf_v1.JWTAuth{
+							Realm:             "My API",
+							JwksURI:           "https://idp.example.com/keys",
+							KeyCache:          "1h",
+							TrustedCertSecret: "valid-jwks-ca-secret",
+						},
+					},
+				},
+			},
+			expectedSecretRefs: map[string]*secrets.SecretReference{
+				"default/valid-jwks-ca-secret": {
+					Secret: validJWKSCASecret,
+					Path:   "/etc/nginx/secrets/default-valid-jwks-ca-secret",
+				},
+			},
+			wantErr: false,
+			msg:     "test getting valid trusted CA secret of a jwksURI",
+		},
 		{
 			policies:           []*conf_v1.Policy{},
 			expectedSecretRefs: map[string]*secrets.SecretReference{},
@@ -1211,6 +1370,10 @@ func TestAddJWTSecrets(t *testing.T) {
 				Secret: invalidJWKSecret,
 				Error:  invalidErr,
 			},
+			"default/valid-jwks-ca-secret": {
+				Secret: validJWKSCASecret,
+				Path:   "/etc/nginx/secrets/default-valid-jwks-ca-secret",
+			},
 		}),
 	}
 
@@ -1690,6 +1853,110 @@ func TestAddOidcSecret(t *testing.T) {
 	}
 }
 
+func TestGetOIDCDiscoveries(t *testing.T) {
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/auth",
+			"token_endpoint":         issuer + "/token",
+			"jwks_uri":               issuer + "/certs",
+		})
+	}))
+	defer server.Close()
+
+	issuer = server.URL
+	missingIssuer := server.URL + "/missing"
+
+	newOIDCPolicy := func(name string, issuer string) *conf_v1.Policy {
+		return &conf_v1.Policy{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				OIDC: &conf_v1.OIDC{
+					Issuer: issuer,
+				},
+			},
+		}
+	}
+
+	validPolicy := newOIDCPolicy("oidc-policy", issuer)
+	missingPolicy := newOIDCPolicy("missing-oidc-policy", missingIssuer)
+	policies := []*conf_v1.Policy{
+		validPolicy,
+		missingPolicy,
+		newOIDCPolicy("oidc-policy-without-issuer", ""),
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "access-control",
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				AccessControl: &conf_v1.AccessControl{
+					Allow: []string{"127.0.0.1"},
+				},
+			},
+		},
+	}
+
+	discovered := make(chan string, 2)
+	lbc := LoadBalancerController{
+		oidcDiscoverer: oidc.NewDiscoverer(server.Client(), func(issuer string) {
+			discovered <- issuer
+		}),
+	}
+
+	// the discoveries complete in the background
+	result := lbc.getOIDCDiscoveries(policies)
+	if len(result) != 0 {
+		t.Errorf("getOIDCDiscoveries() returned %d discoveries before the discovery, expected 0", len(result))
+	}
+	if err := lbc.getOIDCDiscoveryError(missingPolicy); err != nil {
+		t.Errorf("getOIDCDiscoveryError() returned unexpected error %v before the discovery", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-discovered:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the discovery of the issuers didn't complete")
+		}
+	}
+
+	result = lbc.getOIDCDiscoveries(policies)
+
+	expected := map[string]*configs.OIDCDiscovery{
+		issuer: {
+			AuthEndpoint:  issuer + "/auth",
+			TokenEndpoint: issuer + "/token",
+			JWKSURI:       issuer + "/certs",
+		},
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("getOIDCDiscoveries() returned %d discoveries, expected 2", len(result))
+	}
+	if diff := cmp.Diff(expected[issuer], result[issuer]); diff != "" {
+		t.Errorf("getOIDCDiscoveries() returned unexpected discovery (-want +got):\n%s", diff)
+	}
+	if result[missingIssuer].Error == nil {
+		t.Errorf("getOIDCDiscoveries() returned no error for the issuer %s", missingIssuer)
+	}
+
+	if err := lbc.getOIDCDiscoveryError(validPolicy); err != nil {
+		t.Errorf("getOIDCDiscoveryError() returned unexpected error %v", err)
+	}
+	if err := lbc.getOIDCDiscoveryError(missingPolicy); err == nil {
+		t.Errorf("getOIDCDiscoveryError() returned no error for the issuer %s", missingIssuer)
+	}
+}
+
 func TestAddWAFPolicyRefs(t *testing.T) {
 	apPol := &unstructured.Unstructured{
 		Object: map[string]interface{}{
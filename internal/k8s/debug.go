@@ -0,0 +1,269 @@
+package k8s
+
+import (
+	"sort"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+// DebugState is a read-only snapshot of the internal model of the Ingress Controller.
+// It is exposed by the debug API for troubleshooting and never includes any secret data.
+type DebugState struct {
+	Resources  []DebugResource        `json:"resources"`
+	Problems   []DebugProblem         `json:"problems"`
+	Secrets    []DebugSecret          `json:"secrets"`
+	AppProtect []DebugAppProtectState `json:"appProtect,omitempty"`
+}
+
+// DebugResource describes a resource of the Configuration along with its active hosts and listeners.
+type DebugResource struct {
+	Kind                string   `json:"kind"`
+	Namespace           string   `json:"namespace"`
+	Name                string   `json:"name"`
+	Hosts               []string `json:"hosts,omitempty"`
+	Listeners           []string `json:"listeners,omitempty"`
+	Minions             []string `json:"minions,omitempty"`
+	Canaries            []string `json:"canaries,omitempty"`
+	VirtualServerRoutes []string `json:"virtualServerRoutes,omitempty"`
+	Warnings            []string `json:"warnings,omitempty"`
+	ConfigFile          string   `json:"configFile"`
+}
+
+// DebugProblem describes a ConfigurationProblem.
+type DebugProblem struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	IsError   bool   `json:"isError"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+}
+
+// DebugSecret describes the metadata of a Secret in the SecretStore.
+type DebugSecret struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Path      string `json:"path,omitempty"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DebugAppProtectState describes the state of an App Protect resource.
+type DebugAppProtectState struct {
+	Kind    string `json:"kind"`
+	Key     string `json:"key"`
+	IsValid bool   `json:"isValid"`
+	Error   string `json:"error,omitempty"`
+}
+
+// GetDebugState returns a snapshot of the internal model of the controller.
+func (lbc *LoadBalancerController) GetDebugState() *DebugState {
+	// the SecretStore and the App Protect Configuration are not safe for concurrent use,
+	// so we wait for the current sync to finish
+	lbc.syncLock.Lock()
+	defer lbc.syncLock.Unlock()
+
+	state := &DebugState{
+		Resources: lbc.configuration.getDebugResources(),
+		Problems:  lbc.configuration.getDebugProblems(),
+		Secrets:   getDebugSecrets(lbc),
+	}
+
+	for i := range state.Resources {
+		state.Resources[i].ConfigFile = lbc.getConfigFilenameForDebugResource(state.Resources[i])
+	}
+
+	if lbc.appProtectEnabled {
+		for _, s := range lbc.appProtectConfiguration.GetAppResourceStatuses() {
+			state.AppProtect = append(state.AppProtect, DebugAppProtectState{
+				Kind:    s.Kind,
+				Key:     s.Key,
+				IsValid: s.IsValid,
+				Error:   s.ErrorMsg,
+			})
+		}
+	}
+
+	return state
+}
+
+func (lbc *LoadBalancerController) getConfigFilenameForDebugResource(r DebugResource) string {
+	objectMeta := metav1.ObjectMeta{
+		Namespace: r.Namespace,
+		Name:      r.Name,
+	}
+
+	switch r.Kind {
+	case ingressKind:
+		return lbc.configurator.GetConfigFilenameForIngress(&networking.Ingress{ObjectMeta: objectMeta})
+	case virtualServerKind:
+		return lbc.configurator.GetConfigFilenameForVirtualServer(&conf_v1.VirtualServer{ObjectMeta: objectMeta})
+	case transportServerKind:
+		return lbc.configurator.GetConfigFilenameForTransportServer(&conf_v1alpha1.TransportServer{ObjectMeta: objectMeta})
+	}
+
+	return ""
+}
+
+func getDebugSecrets(lbc *LoadBalancerController) []DebugSecret {
+	secretRefs := lbc.secretStore.GetSecretReferences()
+
+	var keys []string
+	for k := range secretRefs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result []DebugSecret
+
+	for _, k := range keys {
+		secretRef := secretRefs[k]
+
+		s := DebugSecret{
+			Path:  secretRef.Path,
+			Valid: secretRef.Error == nil,
+		}
+
+		if secretRef.Secret != nil {
+			s.Namespace = secretRef.Secret.Namespace
+			s.Name = secretRef.Secret.Name
+			s.Type = string(secretRef.Secret.Type)
+		}
+
+		if secretRef.Error != nil {
+			s.Error = secretRef.Error.Error()
+		}
+
+		result = append(result, s)
+	}
+
+	return result
+}
+
+func (c *Configuration) getDebugResources() []DebugResource {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	resources := make(map[string]*DebugResource)
+
+	getOrCreate := func(r Resource, kind string) *DebugResource {
+		key := r.GetKeyWithKind()
+
+		dr, exists := resources[key]
+		if !exists {
+			objectMeta := r.GetObjectMeta()
+			dr = &DebugResource{
+				Kind:      kind,
+				Namespace: objectMeta.Namespace,
+				Name:      objectMeta.Name,
+			}
+			resources[key] = dr
+		}
+
+		return dr
+	}
+
+	for _, host := range getSortedResourceKeys(c.hosts) {
+		r := c.hosts[host]
+
+		switch impl := r.(type) {
+		case *IngressConfiguration:
+			dr := getOrCreate(r, ingressKind)
+			dr.Hosts = append(dr.Hosts, host)
+			dr.Warnings = append([]string(nil), impl.Warnings...)
+			dr.Minions = nil
+			for _, m := range impl.Minions {
+				dr.Minions = append(dr.Minions, getResourceKey(&m.Ingress.ObjectMeta))
+			}
+			dr.Canaries = nil
+			for _, cc := range impl.Canaries {
+				dr.Canaries = append(dr.Canaries, getResourceKey(&cc.Ingress.ObjectMeta))
+			}
+		case *VirtualServerConfiguration:
+			dr := getOrCreate(r, virtualServerKind)
+			dr.Hosts = append(dr.Hosts, host)
+			dr.Warnings = append([]string(nil), impl.Warnings...)
+			dr.VirtualServerRoutes = nil
+			for _, vsr := range impl.VirtualServerRoutes {
+				dr.VirtualServerRoutes = append(dr.VirtualServerRoutes, getResourceKey(&vsr.ObjectMeta))
+			}
+		case *TransportServerConfiguration:
+			dr := getOrCreate(r, transportServerKind)
+			dr.Hosts = append(dr.Hosts, host)
+			dr.Warnings = append([]string(nil), impl.Warnings...)
+		}
+	}
+
+	for _, listener := range getSortedTransportServerConfigurationKeys(c.listeners) {
+		tsc := c.listeners[listener]
+
+		dr := getOrCreate(tsc, transportServerKind)
+		dr.Listeners = append(dr.Listeners, listener)
+		dr.Warnings = append([]string(nil), tsc.Warnings...)
+	}
+
+	var keys []string
+	for k := range resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]DebugResource, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, *resources[k])
+	}
+
+	return result
+}
+
+func (c *Configuration) getDebugProblems() []DebugProblem {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	problems := make(map[string]ConfigurationProblem)
+
+	for k, p := range c.hostProblems {
+		problems[k] = p
+	}
+	for k, p := range c.listenerProblems {
+		problems[k] = p
+	}
+
+	result := make([]DebugProblem, 0, len(problems))
+
+	for _, k := range getSortedProblemKeys(problems) {
+		p := problems[k]
+
+		dp := DebugProblem{
+			IsError: p.IsError,
+			Reason:  p.Reason,
+			Message: p.Message,
+		}
+
+		switch p.Object.(type) {
+		case *networking.Ingress:
+			dp.Kind = ingressKind
+		case *conf_v1.VirtualServer:
+			dp.Kind = virtualServerKind
+		case *conf_v1.VirtualServerRoute:
+			dp.Kind = virtualServerRouteKind
+		case *conf_v1alpha1.TransportServer:
+			dp.Kind = transportServerKind
+		}
+
+		if accessor, err := meta.Accessor(p.Object); err == nil {
+			dp.Namespace = accessor.GetNamespace()
+			dp.Name = accessor.GetName()
+		}
+
+		result = append(result, dp)
+	}
+
+	return result
+}
@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDebugResourcesAndProblems(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	ing := createTestIngress("ingress", "foo.example.com", "bar.example.com")
+	ing.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+	vs := createTestVirtualServer("virtualserver", "baz.example.com")
+
+	rejectedVS := createTestVirtualServer("rejected-virtualserver", "foo.example.com")
+
+	configuration.AddOrUpdateIngress(ing)
+	configuration.AddOrUpdateVirtualServer(vs)
+	configuration.AddOrUpdateVirtualServer(rejectedVS)
+
+	expectedResources := []DebugResource{
+		{
+			Kind:      ingressKind,
+			Namespace: "default",
+			Name:      "ingress",
+			Hosts:     []string{"bar.example.com", "foo.example.com"},
+		},
+		{
+			Kind:      virtualServerKind,
+			Namespace: "default",
+			Name:      "virtualserver",
+			Hosts:     []string{"baz.example.com"},
+		},
+	}
+
+	resources := configuration.getDebugResources()
+	if diff := cmp.Diff(expectedResources, resources); diff != "" {
+		t.Errorf("getDebugResources() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	expectedProblems := []DebugProblem{
+		{
+			Kind:      virtualServerKind,
+			Namespace: "default",
+			Name:      "rejected-virtualserver",
+			IsError:   false,
+			Reason:    "Rejected",
+			Message:   "Host is taken by another resource",
+		},
+	}
+
+	problems := configuration.getDebugProblems()
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("getDebugProblems() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,291 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+)
+
+// AddOrUpdateDefaultPolicy adds or updates the DefaultPolicy.
+// An invalid DefaultPolicy or a DefaultPolicy with a wrong ingress class is removed from the Configuration.
+// For an invalid DefaultPolicy, the validation error is returned.
+func (c *Configuration) AddOrUpdateDefaultPolicy(dp *conf_v1alpha1.DefaultPolicy) ([]ResourceChange, []ConfigurationProblem, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := dp.Name
+
+	var validationErr error
+
+	if !c.hasCorrectIngressClass(dp) {
+		delete(c.defaultPolicies, key)
+	} else {
+		validationErr = validation.ValidateDefaultPolicy(dp)
+		if validationErr != nil {
+			delete(c.defaultPolicies, key)
+		} else {
+			c.defaultPolicies[key] = dp
+		}
+	}
+
+	changes, problems := c.rebuildHosts()
+
+	return changes, problems, validationErr
+}
+
+// DeleteDefaultPolicy deletes the DefaultPolicy by its name.
+func (c *Configuration) DeleteDefaultPolicy(name string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, exists := c.defaultPolicies[name]
+	if !exists {
+		return nil, nil
+	}
+
+	delete(c.defaultPolicies, name)
+
+	return c.rebuildHosts()
+}
+
+// getDefaultPolicies returns the references of the default Policies for the resources of the namespace
+// in the order of the names of the DefaultPolicies.
+func (c *Configuration) getDefaultPolicies(namespace string) []conf_v1.PolicyReference {
+	var names []string
+	for name := range c.defaultPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []conf_v1.PolicyReference
+
+	for _, name := range names {
+		dp := c.defaultPolicies[name]
+
+		if !isDefaultPolicyForNamespace(dp, namespace) {
+			continue
+		}
+
+		for _, p := range dp.Spec.Policies {
+			result = append(result, conf_v1.PolicyReference{
+				Name:      p.Name,
+				Namespace: p.Namespace,
+			})
+		}
+	}
+
+	return result
+}
+
+func isDefaultPolicyForNamespace(dp *conf_v1alpha1.DefaultPolicy, namespace string) bool {
+	if len(dp.Spec.Namespaces) == 0 {
+		return true
+	}
+
+	for _, ns := range dp.Spec.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getPolicyReferenceKey(p conf_v1.PolicyReference, ownerNamespace string) string {
+	polNamespace := p.Namespace
+	if polNamespace == "" {
+		polNamespace = ownerNamespace
+	}
+
+	return fmt.Sprintf("%s/%s", polNamespace, p.Name)
+}
+
+// appendDefaultPolicies appends the default Policies to the policies, skipping the Policies that are already referenced
+// by the policies or whose keys are in the skipped keys. It returns the resulting policies and the keys of the appended Policies.
+func appendDefaultPolicies(policies []conf_v1.PolicyReference, defaults []conf_v1.PolicyReference, ownerNamespace string, skippedKeys map[string]bool) ([]conf_v1.PolicyReference, []string) {
+	referenced := make(map[string]bool)
+	for _, p := range policies {
+		referenced[getPolicyReferenceKey(p, ownerNamespace)] = true
+	}
+
+	// the policies are copied so that the slice of the caller is never modified
+	result := make([]conf_v1.PolicyReference, 0, len(policies)+len(defaults))
+	result = append(result, policies...)
+
+	var appended []string
+
+	for _, p := range defaults {
+		key := getPolicyReferenceKey(p, ownerNamespace)
+		if referenced[key] || skippedKeys[key] {
+			continue
+		}
+
+		referenced[key] = true
+		result = append(result, p)
+		appended = append(appended, key)
+	}
+
+	return result, appended
+}
+
+// applyDefaultPolicies applies the default Policies to the VirtualServer and its VirtualServerRoutes.
+// The default Policies are appended to the policies of the VirtualServer spec, so that the Policies referenced by the VirtualServer
+// take precedence. For a VirtualServerRoute, the default Policies that are not already applied to the spec of the VirtualServer
+// are appended to the policies of every subroute. A subroute without policies gets the policies it would inherit from the route of
+// the VirtualServer first. Those inherited policies are still referenced by the VirtualServer, so the paths of such subroutes are returned
+// by the keys of their VirtualServerRoutes.
+// The resources with the applied default Policies are copies, so that the resources stored in the Configuration remain unchanged.
+func (c *Configuration) applyDefaultPolicies(vs *conf_v1.VirtualServer, vsrs []*conf_v1.VirtualServerRoute) (*conf_v1.VirtualServer, []*conf_v1.VirtualServerRoute, []string, map[string][]string, map[string][]string) {
+	if len(c.defaultPolicies) == 0 {
+		return vs, vsrs, nil, nil, nil
+	}
+
+	resultVS := vs
+	var vsDefaults []string
+
+	if !vs.Spec.IgnoreDefaultPolicies {
+		policies, appended := appendDefaultPolicies(vs.Spec.Policies, c.getDefaultPolicies(vs.Namespace), vs.Namespace, nil)
+		if len(appended) > 0 {
+			resultVS = vs.DeepCopy()
+			resultVS.Spec.Policies = policies
+			vsDefaults = appended
+		}
+	}
+
+	// the Policies of the VirtualServer spec apply to the locations of the VirtualServerRoutes too
+	vsPolicies := make(map[string]bool)
+	for _, p := range resultVS.Spec.Policies {
+		vsPolicies[getPolicyReferenceKey(p, vs.Namespace)] = true
+	}
+
+	var resultVSRs []*conf_v1.VirtualServerRoute
+	var vsrDefaults map[string][]string
+	var vsrInherited map[string][]string
+
+	for _, vsr := range vsrs {
+		if vsr.Spec.IgnoreDefaultPolicies {
+			resultVSRs = append(resultVSRs, vsr)
+			continue
+		}
+
+		defaults := c.getDefaultPolicies(vsr.Namespace)
+		if len(defaults) == 0 {
+			resultVSRs = append(resultVSRs, vsr)
+			continue
+		}
+
+		vsrCopy := vsr.DeepCopy()
+		appendedKeys := make(map[string]bool)
+		var applied []string
+		var inheritedPaths []string
+
+		for i := range vsrCopy.Spec.Subroutes {
+			sr := &vsrCopy.Spec.Subroutes[i]
+
+			policies := sr.Policies
+			inherited := false
+			if len(policies) == 0 {
+				policies = getInheritedPolicies(vs, vsr)
+				inherited = len(policies) > 0
+			}
+
+			policies, appended := appendDefaultPolicies(policies, defaults, vsr.Namespace, vsPolicies)
+			if len(appended) == 0 {
+				continue
+			}
+
+			sr.Policies = policies
+			if inherited {
+				inheritedPaths = append(inheritedPaths, sr.Path)
+			}
+
+			for _, key := range appended {
+				if !appendedKeys[key] {
+					appendedKeys[key] = true
+					applied = append(applied, key)
+				}
+			}
+		}
+
+		if len(applied) == 0 {
+			resultVSRs = append(resultVSRs, vsr)
+			continue
+		}
+
+		if vsrDefaults == nil {
+			vsrDefaults = make(map[string][]string)
+		}
+		vsrDefaults[getResourceKey(&vsr.ObjectMeta)] = applied
+
+		if len(inheritedPaths) > 0 {
+			if vsrInherited == nil {
+				vsrInherited = make(map[string][]string)
+			}
+			vsrInherited[getResourceKey(&vsr.ObjectMeta)] = inheritedPaths
+		}
+
+		resultVSRs = append(resultVSRs, vsrCopy)
+	}
+
+	return resultVS, resultVSRs, vsDefaults, vsrDefaults, vsrInherited
+}
+
+// getOwnSubroutePolicies returns the policies of the subroute of the VirtualServerRoute without the policies inherited from
+// the route of the VirtualServer. The inherited policies are referenced by the VirtualServer, which is why they are checked
+// and fetched along with the policies of the VirtualServer routes.
+// The inherited subroutes are the paths of the subroutes that inherited policies by the keys of the VirtualServerRoutes.
+func getOwnSubroutePolicies(vs *conf_v1.VirtualServer, vsr *conf_v1.VirtualServerRoute, sr conf_v1.Route, inheritedSubroutes map[string][]string) []conf_v1.PolicyReference {
+	for _, path := range inheritedSubroutes[getResourceKey(&vsr.ObjectMeta)] {
+		if path != sr.Path {
+			continue
+		}
+
+		// the inherited policies precede the default Policies appended to the subroute
+		n := len(getInheritedPolicies(vs, vsr))
+		if n > len(sr.Policies) {
+			n = len(sr.Policies)
+		}
+
+		return sr.Policies[n:]
+	}
+
+	return sr.Policies
+}
+
+// getInheritedPolicies returns the policies of the route of the VirtualServer that references the VirtualServerRoute.
+// The subroutes of the VirtualServerRoute without policies inherit those policies. The namespaces of the policies are set explicitly,
+// because the policies of a subroute are referenced from the namespace of the VirtualServerRoute.
+func getInheritedPolicies(vs *conf_v1.VirtualServer, vsr *conf_v1.VirtualServerRoute) []conf_v1.PolicyReference {
+	vsrKey := getResourceKey(&vsr.ObjectMeta)
+
+	for _, r := range vs.Spec.Routes {
+		if r.Route == "" {
+			continue
+		}
+
+		routeKey := r.Route
+		if !strings.Contains(routeKey, "/") {
+			routeKey = fmt.Sprintf("%s/%s", vs.Namespace, r.Route)
+		}
+
+		if routeKey != vsrKey {
+			continue
+		}
+
+		var result []conf_v1.PolicyReference
+		for _, p := range r.Policies {
+			if p.Namespace == "" {
+				p.Namespace = vs.Namespace
+			}
+			result = append(result, p)
+		}
+
+		return result
+	}
+
+	return nil
+}
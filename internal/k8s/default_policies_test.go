@@ -0,0 +1,223 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+func TestApplyDefaultPoliciesToVirtualServerRoutes(t *testing.T) {
+	configuration := createTestConfiguration()
+	configuration.AddOrUpdateDefaultPolicy(createTestDefaultPolicy("b-default-policy", nil, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name:      "waf",
+			Namespace: "security",
+		},
+	}))
+	configuration.AddOrUpdateDefaultPolicy(createTestDefaultPolicy("a-default-policy", []string{"team-a"}, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name: "rate-limit",
+		},
+	}))
+
+	vs := createTestVirtualServerWithRoutes(
+		"virtualserver",
+		"foo.example.com",
+		[]conf_v1.Route{
+			{
+				Path:  "/",
+				Route: "team-a/virtualserverroute",
+				Policies: []conf_v1.PolicyReference{
+					{
+						Name: "route-policy",
+					},
+				},
+			},
+		})
+
+	vsr := createTestVirtualServerRoute("virtualserverroute", "foo.example.com", "/first")
+	vsr.Namespace = "team-a"
+	vsr.Spec.Subroutes = append(vsr.Spec.Subroutes, conf_v1.Route{
+		Path: "/second",
+		Policies: []conf_v1.PolicyReference{
+			{
+				Name: "subroute-policy",
+			},
+		},
+	})
+
+	ignoringVsr := createTestVirtualServerRoute("ignoring-virtualserverroute", "foo.example.com", "/third")
+	ignoringVsr.Namespace = "team-a"
+	ignoringVsr.Spec.IgnoreDefaultPolicies = true
+
+	resultVS, resultVSRs, vsDefaults, vsrDefaults, inheritedSubroutes := configuration.applyDefaultPolicies(vs, []*conf_v1.VirtualServerRoute{vsr, ignoringVsr})
+
+	expectedVS := vs.DeepCopy()
+	expectedVS.Spec.Policies = []conf_v1.PolicyReference{
+		{
+			Name:      "waf",
+			Namespace: "security",
+		},
+	}
+
+	// security/waf already applies to the VirtualServerRoute through the VirtualServer spec
+	expectedVSR := vsr.DeepCopy()
+	expectedVSR.Spec.Subroutes[0].Policies = []conf_v1.PolicyReference{
+		{
+			Name:      "route-policy",
+			Namespace: "default",
+		},
+		{
+			Name: "rate-limit",
+		},
+	}
+	expectedVSR.Spec.Subroutes[1].Policies = []conf_v1.PolicyReference{
+		{
+			Name: "subroute-policy",
+		},
+		{
+			Name: "rate-limit",
+		},
+	}
+
+	expectedVsDefaults := []string{"security/waf"}
+	expectedVsrDefaults := map[string][]string{
+		"team-a/virtualserverroute": {"team-a/rate-limit"},
+	}
+	expectedInheritedSubroutes := map[string][]string{
+		"team-a/virtualserverroute": {"/first"},
+	}
+
+	if diff := cmp.Diff(expectedVS, resultVS); diff != "" {
+		t.Errorf("applyDefaultPolicies() returned unexpected VirtualServer (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*conf_v1.VirtualServerRoute{expectedVSR, ignoringVsr}, resultVSRs); diff != "" {
+		t.Errorf("applyDefaultPolicies() returned unexpected VirtualServerRoutes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedVsDefaults, vsDefaults); diff != "" {
+		t.Errorf("applyDefaultPolicies() returned unexpected VirtualServer default policies (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedVsrDefaults, vsrDefaults); diff != "" {
+		t.Errorf("applyDefaultPolicies() returned unexpected VirtualServerRoute default policies (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedInheritedSubroutes, inheritedSubroutes); diff != "" {
+		t.Errorf("applyDefaultPolicies() returned unexpected inherited subroutes (-want +got):\n%s", diff)
+	}
+
+	// the resources stored in the Configuration must not change
+	if len(vs.Spec.Policies) != 0 || len(vsr.Spec.Subroutes[0].Policies) != 0 || len(vsr.Spec.Subroutes[1].Policies) != 1 {
+		t.Errorf("applyDefaultPolicies() modified the original resources")
+	}
+}
+
+func TestGetPolicyReferenceWarningsForInheritedPolicies(t *testing.T) {
+	configuration := createTestConfiguration()
+	configuration.isReferenceGrantsEnabled = true
+	configuration.AddOrUpdateDefaultPolicy(createTestDefaultPolicy("default-policy", []string{"team-a"}, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name: "rate-limit",
+		},
+	}))
+
+	vs := createTestVirtualServerWithRoutes(
+		"virtualserver",
+		"foo.example.com",
+		[]conf_v1.Route{
+			{
+				Path:  "/",
+				Route: "team-a/virtualserverroute",
+				Policies: []conf_v1.PolicyReference{
+					{
+						Name: "route-policy",
+					},
+				},
+			},
+		})
+
+	vsr := createTestVirtualServerRoute("virtualserverroute", "foo.example.com", "/first")
+	vsr.Namespace = "team-a"
+	vsr.Spec.Subroutes = append(vsr.Spec.Subroutes, conf_v1.Route{
+		Path: "/second",
+		Policies: []conf_v1.PolicyReference{
+			{
+				Name:      "route-policy",
+				Namespace: "default",
+			},
+		},
+	})
+
+	effectiveVS, vsrs, _, _, inheritedSubroutes := configuration.applyDefaultPolicies(vs, []*conf_v1.VirtualServerRoute{vsr})
+
+	// default/route-policy is inherited by the first subroute from the VirtualServer in the same namespace,
+	// while the second subroute references it from the namespace of the VirtualServerRoute
+	expectedWarnings := []string{
+		"Policy default/route-policy is not allowed to be referenced from namespace team-a: no ReferenceGrant in namespace default",
+	}
+
+	warnings := configuration.getPolicyReferenceWarnings(effectiveVS, vsrs, inheritedSubroutes)
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("getPolicyReferenceWarnings() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	expectedOwnPolicies := []conf_v1.PolicyReference{
+		{
+			Name: "rate-limit",
+		},
+	}
+
+	ownPolicies := getOwnSubroutePolicies(effectiveVS, vsrs[0], vsrs[0].Spec.Subroutes[0], inheritedSubroutes)
+	if diff := cmp.Diff(expectedOwnPolicies, ownPolicies); diff != "" {
+		t.Errorf("getOwnSubroutePolicies() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetDefaultPolicies(t *testing.T) {
+	configuration := createTestConfiguration()
+	configuration.AddOrUpdateDefaultPolicy(createTestDefaultPolicy("b-default-policy", nil, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name:      "waf",
+			Namespace: "security",
+		},
+	}))
+	configuration.AddOrUpdateDefaultPolicy(createTestDefaultPolicy("a-default-policy", []string{"team-a"}, []conf_v1alpha1.DefaultPolicyReference{
+		{
+			Name: "rate-limit",
+		},
+	}))
+
+	tests := []struct {
+		namespace string
+		expected  []conf_v1.PolicyReference
+	}{
+		{
+			namespace: "team-a",
+			expected: []conf_v1.PolicyReference{
+				{
+					Name: "rate-limit",
+				},
+				{
+					Name:      "waf",
+					Namespace: "security",
+				},
+			},
+		},
+		{
+			namespace: "team-b",
+			expected: []conf_v1.PolicyReference{
+				{
+					Name:      "waf",
+					Namespace: "security",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := configuration.getDefaultPolicies(test.namespace)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("getDefaultPolicies(%q) returned unexpected result (-want +got):\n%s", test.namespace, diff)
+		}
+	}
+}
@@ -385,6 +385,108 @@ func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.Resour
 	}
 }
 
+func createHostPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			hp := obj.(*conf_v1alpha1.HostPolicy)
+			glog.V(3).Infof("Adding HostPolicy: %v", hp.Name)
+			lbc.AddSyncQueue(hp)
+		},
+		DeleteFunc: func(obj interface{}) {
+			hp, isHp := obj.(*conf_v1alpha1.HostPolicy)
+			if !isHp {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				hp, ok = deletedState.Obj.(*conf_v1alpha1.HostPolicy)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-HostPolicy object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing HostPolicy: %v", hp.Name)
+			lbc.AddSyncQueue(hp)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curHp := cur.(*conf_v1alpha1.HostPolicy)
+			if !reflect.DeepEqual(old, cur) {
+				glog.V(3).Infof("HostPolicy %v changed, syncing", curHp.Name)
+				lbc.AddSyncQueue(curHp)
+			}
+		},
+	}
+}
+
+func createDefaultPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			dp := obj.(*conf_v1alpha1.DefaultPolicy)
+			glog.V(3).Infof("Adding DefaultPolicy: %v", dp.Name)
+			lbc.AddSyncQueue(dp)
+		},
+		DeleteFunc: func(obj interface{}) {
+			dp, isDp := obj.(*conf_v1alpha1.DefaultPolicy)
+			if !isDp {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				dp, ok = deletedState.Obj.(*conf_v1alpha1.DefaultPolicy)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-DefaultPolicy object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing DefaultPolicy: %v", dp.Name)
+			lbc.AddSyncQueue(dp)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curDp := cur.(*conf_v1alpha1.DefaultPolicy)
+			if !reflect.DeepEqual(old, cur) {
+				glog.V(3).Infof("DefaultPolicy %v changed, syncing", curDp.Name)
+				lbc.AddSyncQueue(curDp)
+			}
+		},
+	}
+}
+
+func createReferenceGrantHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			rg := obj.(*conf_v1alpha1.ReferenceGrant)
+			glog.V(3).Infof("Adding ReferenceGrant: %v", rg.Name)
+			lbc.AddSyncQueue(rg)
+		},
+		DeleteFunc: func(obj interface{}) {
+			rg, isRg := obj.(*conf_v1alpha1.ReferenceGrant)
+			if !isRg {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				rg, ok = deletedState.Obj.(*conf_v1alpha1.ReferenceGrant)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-ReferenceGrant object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing ReferenceGrant: %v", rg.Name)
+			lbc.AddSyncQueue(rg)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curRg := cur.(*conf_v1alpha1.ReferenceGrant)
+			if !reflect.DeepEqual(old, cur) {
+				glog.V(3).Infof("ReferenceGrant %v changed, syncing", curRg.Name)
+				lbc.AddSyncQueue(curRg)
+			}
+		},
+	}
+}
+
 func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -454,6 +556,45 @@ func createPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandle
 	}
 }
 
+// createNamespaceHandlers builds the handler funcs for namespaces.
+// The handlers update the namespaces selected by the namespace label selector.
+func createNamespaceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ns := obj.(*v1.Namespace)
+			wasWatched, isWatched := lbc.watchedNamespaces.addOrUpdateNamespace(ns)
+			lbc.syncWatchedNamespace(ns.Name, wasWatched, isWatched)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ns, isNs := obj.(*v1.Namespace)
+			if !isNs {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				ns, ok = deletedState.Obj.(*v1.Namespace)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Namespace object: %v", deletedState.Obj)
+					return
+				}
+			}
+
+			wasWatched, isWatched := lbc.watchedNamespaces.deleteNamespace(ns.Name)
+			lbc.syncWatchedNamespace(ns.Name, wasWatched, isWatched)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldNs := old.(*v1.Namespace)
+			curNs := cur.(*v1.Namespace)
+			if !reflect.DeepEqual(oldNs.Labels, curNs.Labels) {
+				glog.V(3).Infof("Labels of Namespace %v changed, syncing", curNs.Name)
+				wasWatched, isWatched := lbc.watchedNamespaces.addOrUpdateNamespace(curNs)
+				lbc.syncWatchedNamespace(curNs.Name, wasWatched, isWatched)
+			}
+		},
+	}
+}
+
 func createIngressLinkHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
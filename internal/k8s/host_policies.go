@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"strings"
+
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+// hostPolicyRules holds the rules of the HostPolicies, which restrict the namespaces whose resources can claim the hosts.
+// The namespaces of the rules with the same host from different HostPolicies are combined.
+type hostPolicyRules struct {
+	// exact maps an exact host to the allowed namespaces
+	exact map[string]map[string]bool
+	// wildcard maps the domain of a wildcard host (example.com for *.example.com) to the allowed namespaces
+	wildcard map[string]map[string]bool
+}
+
+func newHostPolicyRules(hostPolicies map[string]*conf_v1alpha1.HostPolicy) *hostPolicyRules {
+	rules := &hostPolicyRules{
+		exact:    make(map[string]map[string]bool),
+		wildcard: make(map[string]map[string]bool),
+	}
+
+	for _, hp := range hostPolicies {
+		for _, r := range hp.Spec.Rules {
+			m := rules.exact
+			host := r.Host
+
+			if strings.HasPrefix(host, "*.") {
+				m = rules.wildcard
+				host = strings.TrimPrefix(host, "*.")
+			}
+
+			if m[host] == nil {
+				m[host] = make(map[string]bool)
+			}
+			for _, ns := range r.Namespaces {
+				m[host][ns] = true
+			}
+		}
+	}
+
+	return rules
+}
+
+// isAllowed checks if the resources from the namespace can claim the host.
+// The rule for the exact host takes precedence over the wildcard rules. Among the wildcard rules, the rule with the longest
+// domain wins. If no rule matches the host, the resources from any namespace can claim it.
+func (r *hostPolicyRules) isAllowed(host string, namespace string) bool {
+	if r == nil {
+		return true
+	}
+
+	if namespaces, exists := r.exact[host]; exists {
+		return namespaces[namespace]
+	}
+
+	domain := host
+	for {
+		i := strings.Index(domain, ".")
+		if i == -1 {
+			return true
+		}
+		domain = domain[i+1:]
+
+		if namespaces, exists := r.wildcard[domain]; exists {
+			return namespaces[namespace]
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"testing"
+
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+func TestHostPolicyRulesIsAllowed(t *testing.T) {
+	hostPolicies := map[string]*conf_v1alpha1.HostPolicy{
+		"policy-1": createTestHostPolicy("policy-1", []conf_v1alpha1.HostPolicyRule{
+			{
+				Host:       "*.example.com",
+				Namespaces: []string{"team-a"},
+			},
+			{
+				Host:       "cafe.example.com",
+				Namespaces: []string{"cafe"},
+			},
+		}),
+		"policy-2": createTestHostPolicy("policy-2", []conf_v1alpha1.HostPolicyRule{
+			{
+				Host:       "*.example.com",
+				Namespaces: []string{"team-b"},
+			},
+			{
+				Host:       "*.tea.example.com",
+				Namespaces: []string{"tea"},
+			},
+		}),
+	}
+
+	rules := newHostPolicyRules(hostPolicies)
+
+	tests := []struct {
+		host      string
+		namespace string
+		expected  bool
+		msg       string
+	}{
+		{
+			host:      "cafe.example.com",
+			namespace: "cafe",
+			expected:  true,
+			msg:       "exact host",
+		},
+		{
+			host:      "cafe.example.com",
+			namespace: "team-a",
+			expected:  false,
+			msg:       "exact host takes precedence over wildcard host",
+		},
+		{
+			host:      "foo.example.com",
+			namespace: "team-a",
+			expected:  true,
+			msg:       "wildcard host",
+		},
+		{
+			host:      "foo.example.com",
+			namespace: "team-b",
+			expected:  true,
+			msg:       "wildcard host from another policy",
+		},
+		{
+			host:      "foo.bar.example.com",
+			namespace: "team-a",
+			expected:  true,
+			msg:       "wildcard host matches all subdomains",
+		},
+		{
+			host:      "green.tea.example.com",
+			namespace: "team-a",
+			expected:  false,
+			msg:       "longest wildcard host takes precedence",
+		},
+		{
+			host:      "green.tea.example.com",
+			namespace: "tea",
+			expected:  true,
+			msg:       "longest wildcard host",
+		},
+		{
+			host:      "*.example.com",
+			namespace: "team-a",
+			expected:  true,
+			msg:       "wildcard host of a resource",
+		},
+		{
+			host:      "example.com",
+			namespace: "default",
+			expected:  true,
+			msg:       "wildcard host doesn't match its domain",
+		},
+		{
+			host:      "example.org",
+			namespace: "default",
+			expected:  true,
+			msg:       "no matching rule",
+		},
+		{
+			host:      "",
+			namespace: "default",
+			expected:  true,
+			msg:       "empty host",
+		},
+	}
+
+	for _, test := range tests {
+		result := rules.isAllowed(test.host, test.namespace)
+		if result != test.expected {
+			t.Errorf("isAllowed(%q, %q) returned %v but expected %v for the case of %s", test.host, test.namespace, result, test.expected, test.msg)
+		}
+	}
+
+	var nilRules *hostPolicyRules
+	if !nilRules.isAllowed("cafe.example.com", "default") {
+		t.Errorf("isAllowed() returned false for no HostPolicies")
+	}
+}
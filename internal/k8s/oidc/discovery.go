@@ -0,0 +1,291 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// maxDiscoveryDocumentSize limits the size of the discovery document read from an issuer.
+	maxDiscoveryDocumentSize = 1 << 20
+
+	// defaultDiscoveryTimeout is the timeout of the requests to the issuers.
+	defaultDiscoveryTimeout = 10 * time.Second
+	// defaultRetryDelay is the delay of the first retry of a failed discovery.
+	defaultRetryDelay = 5 * time.Second
+	// defaultMaxRetryDelay limits the delay of the retries of a failed discovery.
+	defaultMaxRetryDelay = 2 * time.Minute
+)
+
+// endpointForbiddenChars are the characters that an endpoint can't include, because the endpoints are used
+// in the NGINX configuration.
+const endpointForbiddenChars = " \t\n\r;{}$\"'\\#"
+
+// ProviderMetadata holds the endpoints of an OpenID Provider, as published in its discovery document.
+type ProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// DiscoveryReference holds the result of the discovery of the metadata of an issuer.
+// If a refresh of the metadata fails, the reference keeps the previously discovered metadata along with the error.
+type DiscoveryReference struct {
+	Metadata *ProviderMetadata
+	Error    error
+}
+
+// Discoverer discovers the metadata of OpenID Providers from their issuer URLs in the background and caches it.
+// When the discovery of an issuer completes or changes, the Discoverer calls its onChange function with the issuer.
+// A failed discovery is retried with an exponential backoff until it succeeds or the issuer is forgotten.
+// Discoverer is safe for concurrent use.
+type Discoverer struct {
+	client        *http.Client
+	onChange      func(issuer string)
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+	mu            sync.Mutex
+	// discoveries holds a nil reference for the issuers whose first discovery hasn't completed yet.
+	discoveries map[string]*DiscoveryReference
+	// fetching holds the issuers whose discovery is in progress or is going to be retried.
+	fetching map[string]bool
+}
+
+// NewDiscoverer creates a new Discoverer. If client is nil, an HTTP client with a default timeout is used.
+// onChange is called from the goroutines of the Discoverer and must be safe for concurrent use.
+func NewDiscoverer(client *http.Client, onChange func(issuer string)) *Discoverer {
+	if client == nil {
+		client = &http.Client{
+			Timeout: defaultDiscoveryTimeout,
+		}
+	}
+
+	return &Discoverer{
+		client:        client,
+		onChange:      onChange,
+		retryDelay:    defaultRetryDelay,
+		maxRetryDelay: defaultMaxRetryDelay,
+		discoveries:   make(map[string]*DiscoveryReference),
+		fetching:      make(map[string]bool),
+	}
+}
+
+// GetDiscovery returns the discovery of the issuer. GetDiscovery doesn't block: if the issuer wasn't discovered yet,
+// it starts the discovery in the background and returns nil.
+func (d *Discoverer) GetDiscovery(issuer string) *DiscoveryReference {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ref, exists := d.discoveries[issuer]
+	if exists {
+		return ref
+	}
+
+	d.discoveries[issuer] = nil
+
+	// a forgotten issuer might still have a pending retry, which will discover it again
+	if !d.fetching[issuer] {
+		d.fetching[issuer] = true
+		go d.fetch(issuer, d.retryDelay)
+	}
+
+	return nil
+}
+
+// Refresh fetches the metadata of the issuers again and forgets the issuers that are not in the list.
+// The issuers whose discovery is going to be retried are not fetched.
+func (d *Discoverer) Refresh(issuers []string) {
+	keep := make(map[string]bool)
+	for _, issuer := range issuers {
+		keep[issuer] = true
+	}
+
+	d.mu.Lock()
+
+	var refreshed []string
+	for issuer := range d.discoveries {
+		if !keep[issuer] {
+			delete(d.discoveries, issuer)
+		} else if !d.fetching[issuer] {
+			d.fetching[issuer] = true
+			refreshed = append(refreshed, issuer)
+		}
+	}
+
+	d.mu.Unlock()
+
+	for _, issuer := range refreshed {
+		d.fetch(issuer, d.retryDelay)
+	}
+}
+
+// fetch discovers the metadata of the issuer and calls onChange if the discovery has changed.
+// If the discovery fails, it's retried in the background after retryDelay.
+func (d *Discoverer) fetch(issuer string, retryDelay time.Duration) {
+	if d.forgetFetching(issuer) {
+		return
+	}
+
+	ref := d.discover(issuer)
+
+	d.mu.Lock()
+
+	old, exists := d.discoveries[issuer]
+	if !exists {
+		delete(d.fetching, issuer)
+		d.mu.Unlock()
+		return
+	}
+
+	if old != nil && ref.Error != nil && ref.Metadata == nil {
+		ref.Metadata = old.Metadata
+	}
+
+	changed := old == nil || !isDiscoveryEqual(old, ref)
+	if changed {
+		d.discoveries[issuer] = ref
+	}
+
+	if ref.Error == nil {
+		delete(d.fetching, issuer)
+	}
+
+	d.mu.Unlock()
+
+	if ref.Error != nil {
+		nextRetryDelay := 2 * retryDelay
+		if nextRetryDelay > d.maxRetryDelay {
+			nextRetryDelay = d.maxRetryDelay
+		}
+		time.AfterFunc(retryDelay, func() {
+			d.fetch(issuer, nextRetryDelay)
+		})
+	}
+
+	if changed && d.onChange != nil {
+		d.onChange(issuer)
+	}
+}
+
+// forgetFetching stops the fetching of the issuer if the issuer was forgotten. It returns true if the issuer was forgotten.
+func (d *Discoverer) forgetFetching(issuer string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.discoveries[issuer]; exists {
+		return false
+	}
+
+	delete(d.fetching, issuer)
+	return true
+}
+
+func (d *Discoverer) discover(issuer string) *DiscoveryReference {
+	metadata, err := Discover(d.client, issuer)
+	return &DiscoveryReference{
+		Metadata: metadata,
+		Error:    err,
+	}
+}
+
+func isDiscoveryEqual(a *DiscoveryReference, b *DiscoveryReference) bool {
+	if (a.Error == nil) != (b.Error == nil) {
+		return false
+	}
+	if a.Error != nil && a.Error.Error() != b.Error.Error() {
+		return false
+	}
+	return reflect.DeepEqual(a.Metadata, b.Metadata)
+}
+
+// Discover fetches the metadata of the issuer from its discovery document, as defined by
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig
+func Discover(client *http.Client, issuer string) (*ProviderMetadata, error) {
+	resp, err := client.Get(strings.TrimSuffix(issuer, "/") + discoveryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the discovery document: unexpected response status %v", resp.StatusCode)
+	}
+
+	var metadata ProviderMetadata
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxDiscoveryDocumentSize)).Decode(&metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the discovery document: %w", err)
+	}
+
+	err = validateMetadata(&metadata, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid discovery document: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+func validateMetadata(metadata *ProviderMetadata, issuer string) error {
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return fmt.Errorf("issuer %q doesn't match the expected issuer %q", metadata.Issuer, issuer)
+	}
+
+	endpoints := []struct {
+		name     string
+		value    string
+		required bool
+	}{
+		{name: "authorization_endpoint", value: metadata.AuthorizationEndpoint, required: true},
+		{name: "token_endpoint", value: metadata.TokenEndpoint, required: true},
+		{name: "jwks_uri", value: metadata.JWKSURI, required: true},
+		{name: "end_session_endpoint", value: metadata.EndSessionEndpoint, required: false},
+	}
+
+	for _, e := range endpoints {
+		if e.value == "" {
+			if e.required {
+				return fmt.Errorf("%s is missing", e.name)
+			}
+			continue
+		}
+
+		err := validateEndpoint(e.value)
+		if err != nil {
+			return fmt.Errorf("%s %q is invalid: %w", e.name, e.value, err)
+		}
+	}
+
+	return nil
+}
+
+func validateEndpoint(endpoint string) error {
+	if strings.ContainsAny(endpoint, endpointForbiddenChars) {
+		return fmt.Errorf("must not contain any of the characters %q", endpointForbiddenChars)
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("hostname required")
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeIssuer is a stand-in for an OpenID Provider that serves a discovery document.
+type fakeIssuer struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	status   int
+	document map[string]string
+	requests int
+}
+
+func newFakeIssuer() *fakeIssuer {
+	i := &fakeIssuer{
+		status: http.StatusOK,
+	}
+
+	i.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+
+		i.requests++
+
+		if r.URL.Path != "/realms/test/.well-known/openid-configuration" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(i.status)
+		if i.status == http.StatusOK {
+			_ = json.NewEncoder(w).Encode(i.document)
+		}
+	}))
+
+	i.document = i.newDocument("/protocol/openid-connect")
+
+	return i
+}
+
+func (i *fakeIssuer) issuer() string {
+	return i.server.URL + "/realms/test"
+}
+
+func (i *fakeIssuer) newDocument(prefix string) map[string]string {
+	return map[string]string{
+		"issuer":                 i.issuer(),
+		"authorization_endpoint": i.issuer() + prefix + "/auth",
+		"token_endpoint":         i.issuer() + prefix + "/token",
+		"jwks_uri":               i.issuer() + prefix + "/certs",
+		"end_session_endpoint":   i.issuer() + prefix + "/logout",
+	}
+}
+
+func (i *fakeIssuer) expectedMetadata(prefix string) *ProviderMetadata {
+	return &ProviderMetadata{
+		Issuer:                i.issuer(),
+		AuthorizationEndpoint: i.issuer() + prefix + "/auth",
+		TokenEndpoint:         i.issuer() + prefix + "/token",
+		JWKSURI:               i.issuer() + prefix + "/certs",
+		EndSessionEndpoint:    i.issuer() + prefix + "/logout",
+	}
+}
+
+func (i *fakeIssuer) update(status int, document map[string]string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.status = status
+	i.document = document
+}
+
+func (i *fakeIssuer) getRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.requests
+}
+
+func TestDiscover(t *testing.T) {
+	issuer := newFakeIssuer()
+	defer issuer.server.Close()
+
+	for _, iss := range []string{issuer.issuer(), issuer.issuer() + "/"} {
+		metadata, err := Discover(issuer.server.Client(), iss)
+		if err != nil {
+			t.Fatalf("Discover(%q) returned unexpected error: %v", iss, err)
+		}
+
+		if diff := cmp.Diff(issuer.expectedMetadata("/protocol/openid-connect"), metadata); diff != "" {
+			t.Errorf("Discover(%q) returned unexpected result (-want +got):\n%s", iss, diff)
+		}
+	}
+}
+
+func TestDiscoverFails(t *testing.T) {
+	issuer := newFakeIssuer()
+	defer issuer.server.Close()
+
+	valid := issuer.newDocument("/protocol/openid-connect")
+
+	withValue := func(key string, value string) map[string]string {
+		document := make(map[string]string)
+		for k, v := range valid {
+			document[k] = v
+		}
+		document[key] = value
+		return document
+	}
+
+	tests := []struct {
+		status   int
+		document map[string]string
+		msg      string
+	}{
+		{
+			status: http.StatusInternalServerError,
+			msg:    "error response",
+		},
+		{
+			status:   http.StatusOK,
+			document: withValue("issuer", "https://idp.example.com"),
+			msg:      "issuer mismatch",
+		},
+		{
+			status:   http.StatusOK,
+			document: withValue("token_endpoint", ""),
+			msg:      "missing token endpoint",
+		},
+		{
+			status:   http.StatusOK,
+			document: withValue("authorization_endpoint", `https://idp.example.com/auth"; return 200 "`),
+			msg:      "forbidden characters in authorization endpoint",
+		},
+		{
+			status:   http.StatusOK,
+			document: withValue("jwks_uri", "ftp://idp.example.com/certs"),
+			msg:      "invalid scheme of jwks uri",
+		},
+		{
+			status:   http.StatusOK,
+			document: withValue("end_session_endpoint", "/logout"),
+			msg:      "relative end session endpoint",
+		},
+	}
+
+	for _, test := range tests {
+		issuer.update(test.status, test.document)
+
+		_, err := Discover(issuer.server.Client(), issuer.issuer())
+		if err == nil {
+			t.Errorf("Discover() returned no error for the case of %s", test.msg)
+		}
+	}
+
+	_, err := Discover(issuer.server.Client(), issuer.server.URL+"/realms/missing")
+	if err == nil {
+		t.Errorf("Discover() returned no error for a missing discovery document")
+	}
+}
+
+// newTestDiscoverer creates a Discoverer that sends the issuers whose discovery has changed to the returned channel.
+func newTestDiscoverer(client *http.Client) (*Discoverer, chan string) {
+	changed := make(chan string, 10)
+	d := NewDiscoverer(client, func(issuer string) {
+		changed <- issuer
+	})
+	d.retryDelay = 10 * time.Millisecond
+	d.maxRetryDelay = 20 * time.Millisecond
+
+	return d, changed
+}
+
+func waitForChange(t *testing.T, changed chan string, issuer string) {
+	t.Helper()
+
+	select {
+	case i := <-changed:
+		if i != issuer {
+			t.Errorf("the discovery of the issuer %s has changed, expected %s", i, issuer)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the discovery of the issuer %s didn't change", issuer)
+	}
+}
+
+func expectNoChange(t *testing.T, changed chan string) {
+	t.Helper()
+
+	select {
+	case i := <-changed:
+		t.Errorf("the discovery of the issuer %s has changed unexpectedly", i)
+	default:
+	}
+}
+
+func TestDiscovererGetDiscovery(t *testing.T) {
+	issuer := newFakeIssuer()
+	defer issuer.server.Close()
+
+	d, changed := newTestDiscoverer(issuer.server.Client())
+
+	if ref := d.GetDiscovery(issuer.issuer()); ref != nil {
+		t.Errorf("GetDiscovery() returned %v before the discovery, expected nil", ref)
+	}
+
+	waitForChange(t, changed, issuer.issuer())
+
+	ref := d.GetDiscovery(issuer.issuer())
+	if ref.Error != nil {
+		t.Fatalf("GetDiscovery() returned unexpected error: %v", ref.Error)
+	}
+	if diff := cmp.Diff(issuer.expectedMetadata("/protocol/openid-connect"), ref.Metadata); diff != "" {
+		t.Errorf("GetDiscovery() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	cached := d.GetDiscovery(issuer.issuer())
+	if cached != ref {
+		t.Errorf("GetDiscovery() didn't return the cached discovery")
+	}
+	if requests := issuer.getRequests(); requests != 1 {
+		t.Errorf("GetDiscovery() sent %d requests to the issuer, expected 1", requests)
+	}
+}
+
+func TestDiscovererRetriesFailedDiscovery(t *testing.T) {
+	issuer := newFakeIssuer()
+	defer issuer.server.Close()
+
+	issuer.update(http.StatusServiceUnavailable, nil)
+
+	d, changed := newTestDiscoverer(issuer.server.Client())
+
+	d.GetDiscovery(issuer.issuer())
+	waitForChange(t, changed, issuer.issuer())
+
+	ref := d.GetDiscovery(issuer.issuer())
+	if ref.Error == nil {
+		t.Errorf("GetDiscovery() returned no error for an unavailable issuer")
+	}
+
+	// the provider becomes available
+	issuer.update(http.StatusOK, issuer.newDocument("/protocol/openid-connect"))
+	waitForChange(t, changed, issuer.issuer())
+
+	ref = d.GetDiscovery(issuer.issuer())
+	if ref.Error != nil {
+		t.Errorf("GetDiscovery() returned unexpected error after a retry: %v", ref.Error)
+	}
+	if diff := cmp.Diff(issuer.expectedMetadata("/protocol/openid-connect"), ref.Metadata); diff != "" {
+		t.Errorf("GetDiscovery() returned unexpected result after a retry (-want +got):\n%s", diff)
+	}
+
+	// the retries stop after a successful discovery
+	requests := issuer.getRequests()
+	time.Sleep(50 * time.Millisecond)
+	if issuer.getRequests() != requests {
+		t.Errorf("the discovery was retried after it succeeded")
+	}
+}
+
+func TestDiscovererRefresh(t *testing.T) {
+	issuer := newFakeIssuer()
+	defer issuer.server.Close()
+
+	d, changed := newTestDiscoverer(issuer.server.Client())
+	d.GetDiscovery(issuer.issuer())
+	waitForChange(t, changed, issuer.issuer())
+
+	d.Refresh([]string{issuer.issuer()})
+	expectNoChange(t, changed)
+
+	// the provider rotates its endpoints
+	issuer.update(http.StatusOK, issuer.newDocument("/protocol/openid-connect/v2"))
+
+	d.Refresh([]string{issuer.issuer()})
+	waitForChange(t, changed, issuer.issuer())
+
+	ref := d.GetDiscovery(issuer.issuer())
+	if diff := cmp.Diff(issuer.expectedMetadata("/protocol/openid-connect/v2"), ref.Metadata); diff != "" {
+		t.Errorf("GetDiscovery() returned unexpected result after the refresh (-want +got):\n%s", diff)
+	}
+
+	// the provider becomes unavailable
+	issuer.update(http.StatusServiceUnavailable, nil)
+
+	d.Refresh([]string{issuer.issuer()})
+	waitForChange(t, changed, issuer.issuer())
+
+	ref = d.GetDiscovery(issuer.issuer())
+	if ref.Error == nil {
+		t.Errorf("GetDiscovery() returned no error after a failed refresh")
+	}
+	if diff := cmp.Diff(issuer.expectedMetadata("/protocol/openid-connect/v2"), ref.Metadata); diff != "" {
+		t.Errorf("GetDiscovery() didn't keep the previous metadata after a failed refresh (-want +got):\n%s", diff)
+	}
+
+	// the issuer is no longer used, which also stops the retries
+	d.Refresh(nil)
+	time.Sleep(50 * time.Millisecond)
+
+	requests := issuer.getRequests()
+	d.Refresh([]string{issuer.issuer()})
+	time.Sleep(50 * time.Millisecond)
+	if issuer.getRequests() != requests {
+		t.Errorf("the Discoverer sent a request for an issuer that was forgotten")
+	}
+	expectNoChange(t, changed)
+}
@@ -0,0 +1,346 @@
+package k8s
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	networking "k8s.io/api/networking/v1"
+)
+
+// policyError is an error of the reference to the Policy with the key.
+type policyError struct {
+	policyKey string
+	err       error
+}
+
+func newPolicyError(policyKey string, err error) *policyError {
+	return &policyError{
+		policyKey: policyKey,
+		err:       err,
+	}
+}
+
+func (e *policyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *policyError) Unwrap() error {
+	return e.err
+}
+
+// policyReferenceProblems stores the Policy references of VirtualServers, VirtualServerRoutes and Ingresses along with the problems
+// of those references found during the generation of the configuration. The problems are reported in the status of the Policies.
+type policyReferenceProblems struct {
+	// problems are stored by the key (with the kind) of the referencing resource and the key of the Policy
+	problems map[string]map[string][]string
+	// errors of getting the referenced Policies are stored like the problems until the problems of the resource are updated
+	errors map[string]map[string][]string
+}
+
+func newPolicyReferenceProblems() *policyReferenceProblems {
+	return &policyReferenceProblems{
+		problems: make(map[string]map[string][]string),
+		errors:   make(map[string]map[string][]string),
+	}
+}
+
+// setErrors replaces the errors of getting the Policies referenced by the resource.
+func (p *policyReferenceProblems) setErrors(resourceKey string, errs []error) {
+	delete(p.errors, resourceKey)
+
+	for _, err := range errs {
+		var polErr *policyError
+		if !errors.As(err, &polErr) {
+			continue
+		}
+
+		if p.errors[resourceKey] == nil {
+			p.errors[resourceKey] = make(map[string][]string)
+		}
+		p.errors[resourceKey][polErr.policyKey] = append(p.errors[resourceKey][polErr.policyKey], err.Error())
+	}
+}
+
+// getErrors returns the errors of getting the Policies referenced by the resource by the keys of the Policies.
+func (p *policyReferenceProblems) getErrors(resourceKey string) map[string][]string {
+	return p.errors[resourceKey]
+}
+
+// update replaces the Policy references of the resource and returns the keys of the Policies that were or are referenced by it.
+func (p *policyReferenceProblems) update(resourceKey string, problems map[string][]string) []string {
+	affected := p.deleteProblems(resourceKey)
+
+	if len(problems) > 0 {
+		p.problems[resourceKey] = problems
+	}
+
+	for polKey := range problems {
+		affected = append(affected, polKey)
+	}
+
+	return removeDuplicates(affected)
+}
+
+// delete deletes the Policy references of the resource and returns the keys of the Policies that were referenced by it.
+func (p *policyReferenceProblems) delete(resourceKey string) []string {
+	delete(p.errors, resourceKey)
+	return p.deleteProblems(resourceKey)
+}
+
+func (p *policyReferenceProblems) deleteProblems(resourceKey string) []string {
+	var affected []string
+
+	for polKey := range p.problems[resourceKey] {
+		affected = append(affected, polKey)
+	}
+
+	delete(p.problems, resourceKey)
+
+	sort.Strings(affected)
+
+	return affected
+}
+
+// get returns the problems of the reference of the Policy from the resource.
+func (p *policyReferenceProblems) get(resourceKey string, policyKey string) []string {
+	return p.problems[resourceKey][policyKey]
+}
+
+func removeDuplicates(keys []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, k := range keys {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// getPolicyReferenceProblems returns the problems of the Policy references by the key of the Policy.
+// The problems of a reference are the errors of getting the referenced Policy and the warnings of the reference
+// found during the generation of the configuration, both by the key of the Policy.
+// Every referenced Policy gets an entry, even if its reference doesn't have problems.
+func getPolicyReferenceProblems(policies []conf_v1.PolicyReference, ownerNamespace string, policyErrors map[string][]string, warnings map[string][]string) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, p := range policies {
+		key := getPolicyReferenceKey(p, ownerNamespace)
+		if _, exists := result[key]; exists {
+			continue
+		}
+
+		var problems []string
+		seen := make(map[string]bool)
+
+		for _, msgs := range [][]string{policyErrors[key], warnings[key]} {
+			for _, msg := range msgs {
+				if seen[msg] {
+					continue
+				}
+				seen[msg] = true
+				problems = append(problems, msg)
+			}
+		}
+
+		result[key] = problems
+	}
+
+	return result
+}
+
+// updatePolicyReferences updates the Policy references of the VirtualServer and its VirtualServerRoutes with the problems
+// found during the generation of their configuration and updates the status of the affected Policies.
+// The errors of getting the referenced Policies are set when the VirtualServerEx is created.
+func (lbc *LoadBalancerController) updatePolicyReferences(vsConfig *VirtualServerConfiguration) {
+	vs := vsConfig.VirtualServer
+	policyWarnings := lbc.configurator.GetVirtualServerPolicyWarnings(vs)
+
+	var policies []conf_v1.PolicyReference
+	policies = append(policies, vs.Spec.Policies...)
+	for _, r := range vs.Spec.Routes {
+		policies = append(policies, r.Policies...)
+	}
+
+	vsKey := getResourceKeyWithKind(virtualServerKind, &vs.ObjectMeta)
+	problems := getPolicyReferenceProblems(policies, vs.Namespace, lbc.policyReferenceProblems.getErrors(vsKey), policyWarnings[vs])
+	affected := lbc.policyReferenceProblems.update(vsKey, problems)
+
+	for _, vsr := range vsConfig.VirtualServerRoutes {
+		var vsrPolicies []conf_v1.PolicyReference
+		for _, sr := range vsr.Spec.Subroutes {
+			vsrPolicies = append(vsrPolicies, getOwnSubroutePolicies(vs, vsr, sr, vsConfig.InheritedSubroutes)...)
+		}
+
+		vsrKey := getResourceKeyWithKind(virtualServerRouteKind, &vsr.ObjectMeta)
+		vsrProblems := getPolicyReferenceProblems(vsrPolicies, vsr.Namespace, lbc.policyReferenceProblems.getErrors(vsrKey), policyWarnings[vsr])
+		affected = append(affected, lbc.policyReferenceProblems.update(vsrKey, vsrProblems)...)
+	}
+
+	lbc.updatePoliciesReferencedByStatus(removeDuplicates(affected))
+}
+
+// deletePolicyReferences deletes the Policy references of the VirtualServer and its VirtualServerRoutes
+// and updates the status of the affected Policies.
+func (lbc *LoadBalancerController) deletePolicyReferences(vsConfig *VirtualServerConfiguration) {
+	affected := lbc.policyReferenceProblems.delete(getResourceKeyWithKind(virtualServerKind, &vsConfig.VirtualServer.ObjectMeta))
+
+	for _, vsr := range vsConfig.VirtualServerRoutes {
+		affected = append(affected, lbc.policyReferenceProblems.delete(getResourceKeyWithKind(virtualServerRouteKind, &vsr.ObjectMeta))...)
+	}
+
+	lbc.updatePoliciesReferencedByStatus(removeDuplicates(affected))
+}
+
+// updateIngressPolicyReferences updates the Policy references of the Ingress and its minions with the problems
+// found during the generation of their configuration and updates the status of the affected Policies.
+// The errors of getting the referenced Policies are set when the IngressEx is created.
+func (lbc *LoadBalancerController) updateIngressPolicyReferences(ingConfig *IngressConfiguration) {
+	policyWarnings := lbc.configurator.GetIngressPolicyWarnings(ingConfig.Ingress)
+
+	ings := []*networking.Ingress{ingConfig.Ingress}
+	for _, m := range ingConfig.Minions {
+		ings = append(ings, m.Ingress)
+	}
+
+	var affected []string
+
+	for _, ing := range ings {
+		ingKey := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
+		problems := getPolicyReferenceProblems(configs.GetPolicyReferences(ing), ing.Namespace, lbc.policyReferenceProblems.getErrors(ingKey), policyWarnings[ing])
+		affected = append(affected, lbc.policyReferenceProblems.update(ingKey, problems)...)
+	}
+
+	lbc.updatePoliciesReferencedByStatus(removeDuplicates(affected))
+}
+
+// deleteIngressPolicyReferences deletes the Policy references of the Ingress and its minions
+// and updates the status of the affected Policies.
+func (lbc *LoadBalancerController) deleteIngressPolicyReferences(ingConfig *IngressConfiguration) {
+	affected := lbc.policyReferenceProblems.delete(getResourceKeyWithKind(ingressKind, &ingConfig.Ingress.ObjectMeta))
+
+	for _, m := range ingConfig.Minions {
+		affected = append(affected, lbc.policyReferenceProblems.delete(getResourceKeyWithKind(ingressKind, &m.Ingress.ObjectMeta))...)
+	}
+
+	lbc.updatePoliciesReferencedByStatus(removeDuplicates(affected))
+}
+
+// getPolicyReferencedBy returns the VirtualServers, VirtualServerRoutes and Ingresses that reference the Policy
+// along with the problems of their references.
+func (lbc *LoadBalancerController) getPolicyReferencedBy(policyNamespace string, policyName string) []conf_v1.PolicyReferencedBy {
+	policyKey := policyNamespace + "/" + policyName
+	checker := newPolicyReferenceChecker()
+
+	var result []conf_v1.PolicyReferencedBy
+
+	for _, r := range removeDuplicateResources(lbc.configuration.FindResourcesForPolicy(policyNamespace, policyName)) {
+		if ingConfig, ok := r.(*IngressConfiguration); ok {
+			result = append(result, lbc.getPolicyReferencedByIngress(policyKey, ingConfig)...)
+			continue
+		}
+
+		vsConfig, ok := r.(*VirtualServerConfiguration)
+		if !ok {
+			continue
+		}
+
+		vs := vsConfig.VirtualServer
+		if checker.IsReferencedByVirtualServer(policyNamespace, policyName, vs) {
+			result = append(result, conf_v1.PolicyReferencedBy{
+				Kind:      virtualServerKind,
+				Namespace: vs.Namespace,
+				Name:      vs.Name,
+				Problems:  lbc.policyReferenceProblems.get(getResourceKeyWithKind(virtualServerKind, &vs.ObjectMeta), policyKey),
+			})
+		}
+
+		for _, vsr := range vsConfig.VirtualServerRoutes {
+			if checker.IsReferencedByVirtualServerRoute(policyNamespace, policyName, vsr) {
+				result = append(result, conf_v1.PolicyReferencedBy{
+					Kind:      virtualServerRouteKind,
+					Namespace: vsr.Namespace,
+					Name:      vsr.Name,
+					Problems:  lbc.policyReferenceProblems.get(getResourceKeyWithKind(virtualServerRouteKind, &vsr.ObjectMeta), policyKey),
+				})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func (lbc *LoadBalancerController) getPolicyReferencedByIngress(policyKey string, ingConfig *IngressConfiguration) []conf_v1.PolicyReferencedBy {
+	checker := newPolicyReferenceChecker()
+	policyNamespace, policyName, _ := ParseNamespaceName(policyKey)
+
+	var result []conf_v1.PolicyReferencedBy
+
+	ing := ingConfig.Ingress
+	if checker.IsReferencedByIngress(policyNamespace, policyName, ing) {
+		result = append(result, conf_v1.PolicyReferencedBy{
+			Kind:      ingressKind,
+			Namespace: ing.Namespace,
+			Name:      ing.Name,
+			Problems:  lbc.policyReferenceProblems.get(getResourceKeyWithKind(ingressKind, &ing.ObjectMeta), policyKey),
+		})
+	}
+
+	for _, m := range ingConfig.Minions {
+		if checker.IsReferencedByMinion(policyNamespace, policyName, m.Ingress) {
+			result = append(result, conf_v1.PolicyReferencedBy{
+				Kind:      ingressKind,
+				Namespace: m.Ingress.Namespace,
+				Name:      m.Ingress.Name,
+				Problems:  lbc.policyReferenceProblems.get(getResourceKeyWithKind(ingressKind, &m.Ingress.ObjectMeta), policyKey),
+			})
+		}
+	}
+
+	return result
+}
+
+// updatePoliciesReferencedByStatus updates the referencing resources in the status of the Policies, keeping the rest of the status.
+func (lbc *LoadBalancerController) updatePoliciesReferencedByStatus(policyKeys []string) {
+	if !lbc.reportCustomResourceStatusEnabled() {
+		return
+	}
+
+	for _, key := range policyKeys {
+		obj, exists, err := lbc.policyLister.GetByKey(key)
+		if err != nil {
+			glog.V(3).Infof("Failed to get policy %s: %v", key, err)
+			continue
+		}
+		if !exists {
+			continue
+		}
+
+		pol := obj.(*conf_v1.Policy)
+		referencedBy := lbc.getPolicyReferencedBy(pol.Namespace, pol.Name)
+
+		err = lbc.statusUpdater.UpdatePolicyStatus(pol, pol.Status.State, pol.Status.Reason, pol.Status.Message, referencedBy)
+		if err != nil {
+			glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
+		}
+	}
+}
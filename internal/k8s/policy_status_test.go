@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+)
+
+func TestGetPolicyReferenceProblems(t *testing.T) {
+	policies := []conf_v1.PolicyReference{
+		{
+			Name: "jwt",
+		},
+		{
+			Name: "jwt-2",
+		},
+		{
+			Name:      "rate-limit",
+			Namespace: "policies",
+		},
+		{
+			Name:      "jwt",
+			Namespace: "default",
+		},
+	}
+	policyErrors := map[string][]string{
+		"default/jwt-2": {"Policy default/jwt-2 doesn't exist"},
+	}
+	warnings := map[string][]string{
+		"default/jwt": {
+			"Multiple jwt policies in the same context is not valid. JWT policy default/jwt will be ignored",
+			"Multiple jwt policies in the same context is not valid. JWT policy default/jwt will be ignored",
+		},
+	}
+
+	expected := map[string][]string{
+		"default/jwt":         {"Multiple jwt policies in the same context is not valid. JWT policy default/jwt will be ignored"},
+		"default/jwt-2":       {"Policy default/jwt-2 doesn't exist"},
+		"policies/rate-limit": nil,
+	}
+
+	result := getPolicyReferenceProblems(policies, "default", policyErrors, warnings)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("getPolicyReferenceProblems() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestPolicyReferenceProblemsErrors(t *testing.T) {
+	p := newPolicyReferenceProblems()
+
+	p.setErrors("VirtualServer/default/cafe", []error{
+		newPolicyError("default/jwt", errors.New("Policy default/jwt doesn't exist")),
+		errors.New("an error without a Policy"),
+		newPolicyError("default/jwt-2", errors.New("Policy default/jwt-2 is invalid")),
+	})
+
+	expected := map[string][]string{
+		"default/jwt":   {"Policy default/jwt doesn't exist"},
+		"default/jwt-2": {"Policy default/jwt-2 is invalid"},
+	}
+	if diff := cmp.Diff(expected, p.getErrors("VirtualServer/default/cafe")); diff != "" {
+		t.Errorf("getErrors() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// updating the problems keeps the errors, which are replaced when the errors are set again
+	p.update("VirtualServer/default/cafe", nil)
+	if diff := cmp.Diff(expected, p.getErrors("VirtualServer/default/cafe")); diff != "" {
+		t.Errorf("getErrors() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	p.setErrors("VirtualServer/default/cafe", nil)
+	if errs := p.getErrors("VirtualServer/default/cafe"); errs != nil {
+		t.Errorf("getErrors() returned %v after the errors were replaced", errs)
+	}
+
+	p.setErrors("VirtualServer/default/cafe", []error{newPolicyError("default/jwt", errors.New("Policy default/jwt doesn't exist"))})
+	p.delete("VirtualServer/default/cafe")
+	if errs := p.getErrors("VirtualServer/default/cafe"); errs != nil {
+		t.Errorf("getErrors() returned %v for a deleted resource", errs)
+	}
+}
+
+func TestPolicyReferenceProblems(t *testing.T) {
+	p := newPolicyReferenceProblems()
+
+	affected := p.update("VirtualServer/default/cafe", map[string][]string{
+		"default/jwt-policy": {"Policy default/jwt-policy is missing or invalid"},
+		"default/rate-limit": nil,
+	})
+	expectedAffected := []string{"default/jwt-policy", "default/rate-limit"}
+	if diff := cmp.Diff(expectedAffected, affected); diff != "" {
+		t.Errorf("update() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	problems := p.get("VirtualServer/default/cafe", "default/jwt-policy")
+	expectedProblems := []string{"Policy default/jwt-policy is missing or invalid"}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("get() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	affected = p.update("VirtualServer/default/cafe", map[string][]string{
+		"default/waf-policy": nil,
+	})
+	expectedAffected = []string{"default/jwt-policy", "default/rate-limit", "default/waf-policy"}
+	if diff := cmp.Diff(expectedAffected, affected); diff != "" {
+		t.Errorf("update() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	problems = p.get("VirtualServer/default/cafe", "default/jwt-policy")
+	if problems != nil {
+		t.Errorf("get() returned %v for a removed reference", problems)
+	}
+
+	affected = p.delete("VirtualServer/default/cafe")
+	expectedAffected = []string{"default/waf-policy"}
+	if diff := cmp.Diff(expectedAffected, affected); diff != "" {
+		t.Errorf("delete() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	affected = p.delete("VirtualServer/default/cafe")
+	if affected != nil {
+		t.Errorf("delete() returned %v for a deleted resource", affected)
+	}
+}
@@ -12,6 +12,7 @@ import (
 type resourceReferenceChecker interface {
 	IsReferencedByIngress(namespace string, name string, ing *networking.Ingress) bool
 	IsReferencedByMinion(namespace string, name string, ing *networking.Ingress) bool
+	IsReferencedByCanary(namespace string, name string, ing *networking.Ingress) bool
 	IsReferencedByVirtualServer(namespace string, name string, vs *v1.VirtualServer) bool
 	IsReferencedByVirtualServerRoute(namespace string, name string, vsr *v1.VirtualServerRoute) bool
 	IsReferencedByTransportServer(namespace string, name string, ts *conf_v1alpha1.TransportServer) bool
@@ -63,6 +64,10 @@ func (rc *secretReferenceChecker) IsReferencedByMinion(secretNamespace string, s
 	return false
 }
 
+func (rc *secretReferenceChecker) IsReferencedByCanary(secretNamespace string, secretName string, ing *networking.Ingress) bool {
+	return false
+}
+
 func (rc *secretReferenceChecker) IsReferencedByVirtualServer(secretNamespace string, secretName string, vs *v1.VirtualServer) bool {
 	if vs.Namespace != secretNamespace {
 		return false
@@ -119,6 +124,10 @@ func (rc *serviceReferenceChecker) IsReferencedByMinion(svcNamespace string, svc
 	return rc.IsReferencedByIngress(svcNamespace, svcName, ing)
 }
 
+func (rc *serviceReferenceChecker) IsReferencedByCanary(svcNamespace string, svcName string, ing *networking.Ingress) bool {
+	return rc.IsReferencedByIngress(svcNamespace, svcName, ing)
+}
+
 func (rc *serviceReferenceChecker) IsReferencedByVirtualServer(svcNamespace string, svcName string, vs *v1.VirtualServer) bool {
 	if vs.Namespace != svcNamespace {
 		return false
@@ -174,10 +183,14 @@ func newPolicyReferenceChecker() *policyReferenceChecker {
 }
 
 func (rc *policyReferenceChecker) IsReferencedByIngress(policyNamespace string, policyName string, ing *networking.Ingress) bool {
-	return false
+	return isPolicyReferenced(configs.GetPolicyReferences(ing), ing.Namespace, policyNamespace, policyName)
 }
 
 func (rc *policyReferenceChecker) IsReferencedByMinion(policyNamespace string, policyName string, ing *networking.Ingress) bool {
+	return isPolicyReferenced(configs.GetPolicyReferences(ing), ing.Namespace, policyNamespace, policyName)
+}
+
+func (rc *policyReferenceChecker) IsReferencedByCanary(policyNamespace string, policyName string, ing *networking.Ingress) bool {
 	return false
 }
 
@@ -236,6 +249,10 @@ func (rc *appProtectResourceReferenceChecker) IsReferencedByMinion(namespace str
 	return false
 }
 
+func (rc *appProtectResourceReferenceChecker) IsReferencedByCanary(namespace string, name string, ing *networking.Ingress) bool {
+	return false
+}
+
 func (rc *appProtectResourceReferenceChecker) IsReferencedByVirtualServer(namespace string, name string, vs *v1.VirtualServer) bool {
 	return false
 }
@@ -450,6 +450,12 @@ func TestServiceIsReferencedByIngressAndMinion(t *testing.T) {
 		if result != test.expected {
 			t.Errorf("IsReferencedByMinion() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
 		}
+
+		// same cases for Canaries
+		result = rc.IsReferencedByCanary(test.serviceNamespace, test.serviceName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByCanary() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -643,22 +649,90 @@ func TestIsServiceReferencedByTransportServer(t *testing.T) {
 	}
 }
 
-func TestPolicyIsReferencedByIngressesAndTransportServers(t *testing.T) {
+func TestPolicyIsReferencedByTransportServers(t *testing.T) {
 	rc := newPolicyReferenceChecker()
 
-	result := rc.IsReferencedByIngress("", "", nil)
+	result := rc.IsReferencedByTransportServer("", "", nil)
 	if result != false {
-		t.Error("IsReferencedByIngress() returned true but expected false")
+		t.Error("IsReferencedByTransportServer() returned true but expected false")
 	}
+}
 
-	result = rc.IsReferencedByMinion("", "", nil)
-	if result != false {
-		t.Error("IsReferencedByMinion() returned true but expected false")
+func TestPolicyIsReferencedByIngressAndMinion(t *testing.T) {
+	tests := []struct {
+		ing             *networking.Ingress
+		policyNamespace string
+		policyName      string
+		expected        bool
+		msg             string
+	}{
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						"nginx.org/policies": "rate-limit,access-control",
+					},
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "access-control",
+			expected:        true,
+			msg:             "policy in the same namespace is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						"nginx.org/policies": "policies/access-control",
+					},
+				},
+			},
+			policyNamespace: "policies",
+			policyName:      "access-control",
+			expected:        true,
+			msg:             "policy in another namespace is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						"nginx.org/policies": "access-control",
+					},
+				},
+			},
+			policyNamespace: "policies",
+			policyName:      "access-control",
+			expected:        false,
+			msg:             "policy with the same name in another namespace is not referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "access-control",
+			expected:        false,
+			msg:             "ingress without the annotation",
+		},
 	}
 
-	result = rc.IsReferencedByTransportServer("", "", nil)
-	if result != false {
-		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	rc := newPolicyReferenceChecker()
+
+	for _, test := range tests {
+		result := rc.IsReferencedByIngress(test.policyNamespace, test.policyName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByIngress() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+
+		result = rc.IsReferencedByMinion(test.policyNamespace, test.policyName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByMinion() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -0,0 +1,246 @@
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+)
+
+const (
+	policyKind = "Policy"
+	secretKind = "Secret"
+)
+
+// isReferenceAllowed checks if a resource of the fromKind from the fromNamespace can reference the resource of the toKind
+// with the toName from the toNamespace. References within the same namespace are always allowed. References to other namespaces
+// are allowed if ReferenceGrants are disabled or if a ReferenceGrant in the toNamespace allows the reference.
+func (c *Configuration) isReferenceAllowed(fromKind string, fromNamespace string, toKind string, toNamespace string, toName string) bool {
+	if !c.isReferenceGrantsEnabled || fromNamespace == toNamespace {
+		return true
+	}
+
+	for _, rg := range c.referenceGrants {
+		if rg.Namespace == toNamespace && isReferenceGrantedBy(rg, fromKind, fromNamespace, toKind, toName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isReferenceGrantedBy(rg *conf_v1alpha1.ReferenceGrant, fromKind string, fromNamespace string, toKind string, toName string) bool {
+	fromAllowed := false
+	for _, f := range rg.Spec.From {
+		if f.Kind == fromKind && f.Namespace == fromNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+
+	if !fromAllowed {
+		return false
+	}
+
+	for _, t := range rg.Spec.To {
+		if t.Kind == toKind && (t.Name == "" || t.Name == toName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsReferenceAllowed checks if a resource of the fromKind from the fromNamespace can reference the resource of the toKind
+// with the toName from the toNamespace.
+func (c *Configuration) IsReferenceAllowed(fromKind string, fromNamespace string, toKind string, toNamespace string, toName string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.isReferenceAllowed(fromKind, fromNamespace, toKind, toNamespace, toName)
+}
+
+func newReferenceNotAllowedWarning(kind string, key string, fromNamespace string) string {
+	toNamespace, _, _ := ParseNamespaceName(key)
+	return fmt.Sprintf("%s %s is not allowed to be referenced from namespace %s: no ReferenceGrant in namespace %s", kind, key, fromNamespace, toNamespace)
+}
+
+// getPolicyReferenceWarnings returns the warnings for the Policies from other namespaces that the VirtualServer
+// and its VirtualServerRoutes are not allowed to reference.
+func (c *Configuration) getPolicyReferenceWarnings(vs *conf_v1.VirtualServer, vsrs []*conf_v1.VirtualServerRoute, inheritedSubroutes map[string][]string) []string {
+	var warnings []string
+
+	checkPolicies := func(policies []conf_v1.PolicyReference, fromKind string, fromNamespace string) {
+		for _, p := range policies {
+			polNamespace := p.Namespace
+			if polNamespace == "" {
+				polNamespace = fromNamespace
+			}
+
+			if !c.isReferenceAllowed(fromKind, fromNamespace, policyKind, polNamespace, p.Name) {
+				key := fmt.Sprintf("%s/%s", polNamespace, p.Name)
+				warnings = append(warnings, newReferenceNotAllowedWarning(policyKind, key, fromNamespace))
+			}
+		}
+	}
+
+	checkPolicies(vs.Spec.Policies, virtualServerKind, vs.Namespace)
+	for _, r := range vs.Spec.Routes {
+		checkPolicies(r.Policies, virtualServerKind, vs.Namespace)
+	}
+
+	for _, vsr := range vsrs {
+		for _, sr := range vsr.Spec.Subroutes {
+			checkPolicies(getOwnSubroutePolicies(vs, vsr, sr, inheritedSubroutes), virtualServerRouteKind, vsr.Namespace)
+		}
+	}
+
+	return warnings
+}
+
+// AddOrUpdateReferenceGrant adds or updates the ReferenceGrant.
+// An invalid ReferenceGrant is removed from the Configuration, and the validation error is returned.
+func (c *Configuration) AddOrUpdateReferenceGrant(rg *conf_v1alpha1.ReferenceGrant) ([]ResourceChange, []ConfigurationProblem, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := getResourceKey(&rg.ObjectMeta)
+
+	validationErr := validation.ValidateReferenceGrant(rg)
+	if validationErr != nil {
+		delete(c.referenceGrants, key)
+	} else {
+		c.referenceGrants[key] = rg
+	}
+
+	changes, problems := c.rebuildHosts()
+
+	return changes, problems, validationErr
+}
+
+// DeleteReferenceGrant deletes the ReferenceGrant by its key.
+func (c *Configuration) DeleteReferenceGrant(key string) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, exists := c.referenceGrants[key]
+	if !exists {
+		return nil, nil
+	}
+
+	delete(c.referenceGrants, key)
+
+	return c.rebuildHosts()
+}
+
+// FindResourcesForReferenceGrant finds the VirtualServers, which, including their VirtualServerRoutes,
+// and the Ingresses, which, including their minions, reference Policies from the namespace of a ReferenceGrant.
+func (c *Configuration) FindResourcesForReferenceGrant(namespace string) []Resource {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var result []Resource
+
+	for _, h := range getSortedResourceKeys(c.hosts) {
+		switch impl := c.hosts[h].(type) {
+		case *VirtualServerConfiguration:
+			if isPolicyFromNamespaceReferenced(impl, namespace) {
+				result = append(result, impl)
+			}
+		case *IngressConfiguration:
+			if isPolicyFromNamespaceReferencedByIngress(impl, namespace) {
+				result = append(result, impl)
+			}
+		}
+	}
+
+	// a regular Ingress with multiple hosts is found for every host
+	return removeDuplicateResources(result)
+}
+
+func isPolicyFromNamespaceReferencedByIngress(ingConfig *IngressConfiguration, namespace string) bool {
+	if isPolicyFromNamespaceInReferences(configs.GetPolicyReferences(ingConfig.Ingress), ingConfig.Ingress.Namespace, namespace) {
+		return true
+	}
+
+	for _, m := range ingConfig.Minions {
+		if isPolicyFromNamespaceInReferences(configs.GetPolicyReferences(m.Ingress), m.Ingress.Namespace, namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPolicyFromNamespaceInReferences checks if the Policy references of a resource include a Policy
+// from the namespace, which is not the namespace of the resource.
+func isPolicyFromNamespaceInReferences(policies []conf_v1.PolicyReference, ownerNamespace string, namespace string) bool {
+	if ownerNamespace == namespace {
+		return false
+	}
+	for _, p := range policies {
+		if p.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func isPolicyFromNamespaceReferenced(vsConfig *VirtualServerConfiguration, namespace string) bool {
+	isReferenced := func(policies []conf_v1.PolicyReference, ownerNamespace string) bool {
+		return isPolicyFromNamespaceInReferences(policies, ownerNamespace, namespace)
+	}
+
+	vs := vsConfig.VirtualServer
+
+	if isReferenced(vs.Spec.Policies, vs.Namespace) {
+		return true
+	}
+	for _, r := range vs.Spec.Routes {
+		if isReferenced(r.Policies, vs.Namespace) {
+			return true
+		}
+	}
+
+	for _, vsr := range vsConfig.VirtualServerRoutes {
+		for _, sr := range vsr.Spec.Subroutes {
+			if isReferenced(sr.Policies, vsr.Namespace) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getPolicySecretNames returns the names of the Secrets that the Policy references. The Secrets are in the namespace of the Policy.
+func getPolicySecretNames(pol *conf_v1.Policy) []string {
+	var names []string
+
+	switch {
+	case pol.Spec.JWTAuth != nil:
+		if pol.Spec.JWTAuth.Secret != "" {
+			names = append(names, pol.Spec.JWTAuth.Secret)
+		}
+		if pol.Spec.JWTAuth.TrustedCertSecret != "" {
+			names = append(names, pol.Spec.JWTAuth.TrustedCertSecret)
+		}
+	case pol.Spec.IngressMTLS != nil:
+		names = append(names, pol.Spec.IngressMTLS.ClientCertSecret)
+	case pol.Spec.EgressMTLS != nil:
+		if pol.Spec.EgressMTLS.TLSSecret != "" {
+			names = append(names, pol.Spec.EgressMTLS.TLSSecret)
+		}
+		if pol.Spec.EgressMTLS.TrustedCertSecret != "" {
+			names = append(names, pol.Spec.EgressMTLS.TrustedCertSecret)
+		}
+	case pol.Spec.OIDC != nil:
+		names = append(names, pol.Spec.OIDC.ClientSecret)
+	case pol.Spec.APIKey != nil:
+		names = append(names, pol.Spec.APIKey.ClientSecret)
+	}
+
+	return names
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+)
+
+func TestGetPolicySecretNames(t *testing.T) {
+	tests := []struct {
+		policy   *conf_v1.Policy
+		expected []string
+		msg      string
+	}{
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					AccessControl: &conf_v1.AccessControl{
+						Allow: []string{"127.0.0.1"},
+					},
+				},
+			},
+			expected: nil,
+			msg:      "access control policy",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					JWTAuth: &conf_v1.JWTAuth{
+						Secret: "jwk-secret",
+					},
+				},
+			},
+			expected: []string{"jwk-secret"},
+			msg:      "jwt policy",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					IngressMTLS: &conf_v1.IngressMTLS{
+						ClientCertSecret: "ca-secret",
+					},
+				},
+			},
+			expected: []string{"ca-secret"},
+			msg:      "ingress mtls policy",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					EgressMTLS: &conf_v1.EgressMTLS{
+						TLSSecret:         "tls-secret",
+						TrustedCertSecret: "ca-secret",
+					},
+				},
+			},
+			expected: []string{"tls-secret", "ca-secret"},
+			msg:      "egress mtls policy",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					EgressMTLS: &conf_v1.EgressMTLS{
+						TrustedCertSecret: "ca-secret",
+					},
+				},
+			},
+			expected: []string{"ca-secret"},
+			msg:      "egress mtls policy without tls secret",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					OIDC: &conf_v1.OIDC{
+						ClientSecret: "client-secret",
+					},
+				},
+			},
+			expected: []string{"client-secret"},
+			msg:      "oidc policy",
+		},
+		{
+			policy: &conf_v1.Policy{
+				Spec: conf_v1.PolicySpec{
+					APIKey: &conf_v1.APIKey{
+						ClientSecret: "api-key-secret",
+					},
+				},
+			},
+			expected: []string{"api-key-secret"},
+			msg:      "api key policy",
+		},
+	}
+
+	for _, test := range tests {
+		result := getPolicySecretNames(test.policy)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("getPolicySecretNames() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
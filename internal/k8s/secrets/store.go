@@ -25,6 +25,7 @@ type SecretStore interface {
 	AddOrUpdateSecret(secret *api_v1.Secret)
 	DeleteSecret(key string)
 	GetSecret(key string) *SecretReference
+	GetSecretReferences() map[string]*SecretReference
 }
 
 // LocalSecretStore implements SecretStore interface.
@@ -101,6 +102,23 @@ func (s *LocalSecretStore) GetSecret(key string) *SecretReference {
 	return secretRef
 }
 
+// GetSecretReferences returns a copy of all stored SecretReferences. The key is the namespace/name of the secret.
+// Unlike GetSecret, it doesn't write any secret to the file system.
+func (s *LocalSecretStore) GetSecretReferences() map[string]*SecretReference {
+	return copySecretReferences(s.secrets)
+}
+
+func copySecretReferences(secrets map[string]*SecretReference) map[string]*SecretReference {
+	result := make(map[string]*SecretReference, len(secrets))
+
+	for k, v := range secrets {
+		secretRef := *v
+		result[k] = &secretRef
+	}
+
+	return result
+}
+
 func getResourceKey(meta *metav1.ObjectMeta) string {
 	return fmt.Sprintf("%s/%s", meta.Namespace, meta.Name)
 }
@@ -150,3 +168,8 @@ func (s *FakeSecretStore) GetSecret(key string) *SecretReference {
 
 	return secretRef
 }
+
+// GetSecretReferences is a fake implementation of GetSecretReferences.
+func (s *FakeSecretStore) GetSecretReferences() map[string]*SecretReference {
+	return copySecretReferences(s.secrets)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"regexp"
+	"strings"
 
 	api_v1 "k8s.io/api/core/v1"
 )
@@ -16,6 +17,9 @@ const JWTKeyKey = "jwk"
 // CAKey is the key of the data field of a Secret where the certificate authority must be stored.
 const CAKey = "ca.crt"
 
+// CRLKey is the key of the data field of a Secret where the optional certificate revocation list must be stored.
+const CRLKey = "ca.crl"
+
 // ClientSecretKey is the key of the data field of a Secret where the OIDC client secret must be stored.
 const ClientSecretKey = "client-secret"
 
@@ -28,6 +32,9 @@ const SecretTypeJWK api_v1.SecretType = "nginx.org/jwk"
 // SecretTypeOIDC contains an OIDC client secret for use in oauth flows. #nosec G101
 const SecretTypeOIDC api_v1.SecretType = "nginx.org/oidc"
 
+// SecretTypeAPIKey contains the SHA-256 hashes of the API keys of clients, stored under the client IDs. #nosec G101
+const SecretTypeAPIKey api_v1.SecretType = "nginx.org/apikey"
+
 // ValidateTLSSecret validates the secret. If it is valid, the function returns nil.
 func ValidateTLSSecret(secret *api_v1.Secret) error {
 	if secret.Type != api_v1.SecretTypeTLS {
@@ -83,6 +90,29 @@ func ValidateCASecret(secret *api_v1.Secret) error {
 		return fmt.Errorf("Failed to validate certificate: %w", err)
 	}
 
+	if crl, exists := secret.Data[CRLKey]; exists {
+		return validateCRL(crl)
+	}
+
+	return nil
+}
+
+func validateCRL(crl []byte) error {
+	block, _ := pem.Decode(crl)
+	if block == nil {
+		return fmt.Errorf("The data field %s must hold a valid X509 CRL PEM block", CRLKey)
+	}
+	if block.Type != "X509 CRL" {
+		return fmt.Errorf("The data field %s must hold a valid X509 CRL PEM block, but got '%s'", CRLKey, block.Type)
+	}
+
+	// x509.ParseRevocationList requires Go 1.19
+	//nolint:staticcheck
+	_, err := x509.ParseCRL(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("Failed to validate certificate revocation list: %w", err)
+	}
+
 	return nil
 }
 
@@ -103,12 +133,48 @@ func ValidateOIDCSecret(secret *api_v1.Secret) error {
 	return nil
 }
 
+var apiKeyHashRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ValidateAPIKeySecret validates the secret. If it is valid, the function returns nil.
+func ValidateAPIKeySecret(secret *api_v1.Secret) error {
+	if secret.Type != SecretTypeAPIKey {
+		return fmt.Errorf("API key secret must be of the type %v", SecretTypeAPIKey)
+	}
+
+	if len(secret.Data) == 0 {
+		return fmt.Errorf("API key secret must have at least one client")
+	}
+
+	clients := make(map[string]string)
+
+	for clientID, hash := range secret.Data {
+		normalized := NormalizeAPIKeyHash(hash)
+		if !apiKeyHashRegexp.MatchString(normalized) {
+			return fmt.Errorf("The data field %s must hold a SHA-256 hash in the hex format", clientID)
+		}
+
+		if other, exists := clients[normalized]; exists {
+			return fmt.Errorf("The data fields %s and %s hold the same hash", other, clientID)
+		}
+		clients[normalized] = clientID
+	}
+
+	return nil
+}
+
+// NormalizeAPIKeyHash converts the hash of an API key into lower case and removes the surrounding whitespace,
+// so that the output of tools like sha256sum can be stored in a secret.
+func NormalizeAPIKeyHash(hash []byte) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(string(hash)), "-")))
+}
+
 // IsSupportedSecretType checks if the secret type is supported.
 func IsSupportedSecretType(secretType api_v1.SecretType) bool {
 	return secretType == api_v1.SecretTypeTLS ||
 		secretType == SecretTypeCA ||
 		secretType == SecretTypeJWK ||
-		secretType == SecretTypeOIDC
+		secretType == SecretTypeOIDC ||
+		secretType == SecretTypeAPIKey
 }
 
 // ValidateSecret validates the secret. If it is valid, the function returns nil.
@@ -122,6 +188,8 @@ func ValidateSecret(secret *api_v1.Secret) error {
 		return ValidateCASecret(secret)
 	case SecretTypeOIDC:
 		return ValidateOIDCSecret(secret)
+	case SecretTypeAPIKey:
+		return ValidateAPIKeySecret(secret)
 	}
 
 	return fmt.Errorf("Secret is of the unsupported type %v", secret.Type)
@@ -64,20 +64,39 @@ func TestValidateJWKSecretFails(t *testing.T) {
 }
 
 func TestValidateCASecret(t *testing.T) {
-	secret := &v1.Secret{
-		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      "ingress-mtls-secret",
-			Namespace: "default",
+	tests := []struct {
+		data map[string][]byte
+		msg  string
+	}{
+		{
+			data: map[string][]byte{
+				"ca.crt": validCert,
+			},
+			msg: "CA secret",
 		},
-		Type: SecretTypeCA,
-		Data: map[string][]byte{
-			"ca.crt": validCert,
+		{
+			data: map[string][]byte{
+				"ca.crt": validCert,
+				"ca.crl": validCRL,
+			},
+			msg: "CA secret with CRL",
 		},
 	}
 
-	err := ValidateCASecret(secret)
-	if err != nil {
-		t.Errorf("ValidateCASecret() returned error %v", err)
+	for _, test := range tests {
+		secret := &v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "ingress-mtls-secret",
+				Namespace: "default",
+			},
+			Type: SecretTypeCA,
+			Data: test.data,
+		}
+
+		err := ValidateCASecret(secret)
+		if err != nil {
+			t.Errorf("ValidateCASecret() returned error %v for the case of %s", err, test.msg)
+		}
 	}
 }
 
@@ -148,6 +167,34 @@ func TestValidateCASecretFails(t *testing.T) {
 			},
 			msg: "Invalid cert",
 		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress-mtls-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeCA,
+				Data: map[string][]byte{
+					"ca.crt": validCert,
+					"ca.crl": validCert,
+				},
+			},
+			msg: "CRL with wrong PEM block",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress-mtls-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeCA,
+				Data: map[string][]byte{
+					"ca.crt": validCert,
+					"ca.crl": invalidCRL,
+				},
+			},
+			msg: "Invalid CRL",
+		},
 	}
 
 	for _, test := range tests {
@@ -312,6 +359,128 @@ func TestValidateOIDCSecretFails(t *testing.T) {
 	}
 }
 
+func TestValidateAPIKeySecret(t *testing.T) {
+	tests := []struct {
+		secret *v1.Secret
+		msg    string
+	}{
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+					"client2": []byte("2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"),
+				},
+			},
+			msg: "Valid API key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("5E884898DA28047151D0E56F8DC6292773603D0D6AABBDD62A11EF721D1542D8  -\n"),
+				},
+			},
+			msg: "Valid API key secret with the output of sha256sum",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateAPIKeySecret(test.secret)
+		if err != nil {
+			t.Errorf("ValidateAPIKeySecret() returned error %v for the case of %s", err, test.msg)
+		}
+	}
+}
+
+func TestValidateAPIKeySecretFails(t *testing.T) {
+	tests := []struct {
+		secret *v1.Secret
+		msg    string
+	}{
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: "some-type",
+				Data: map[string][]byte{
+					"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+				},
+			},
+			msg: "Incorrect type for API key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+			},
+			msg: "Missing clients in API key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("password"),
+				},
+			},
+			msg: "Plain API key in API key secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+					"client2": []byte("5E884898DA28047151D0E56F8DC6292773603D0D6AABBDD62A11EF721D1542D8"),
+				},
+			},
+			msg: "Duplicate hashes in API key secret",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateAPIKeySecret(test.secret)
+		if err == nil {
+			t.Errorf("ValidateAPIKeySecret() returned no error for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestNormalizeAPIKeyHash(t *testing.T) {
+	expected := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+
+	for _, hash := range []string{
+		"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		"5E884898DA28047151D0E56F8DC6292773603D0D6AABBDD62A11EF721D1542D8\n",
+		"5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8  -\n",
+	} {
+		result := NormalizeAPIKeyHash([]byte(hash))
+		if result != expected {
+			t.Errorf("NormalizeAPIKeyHash(%q) returned %q but expected %q", hash, result, expected)
+		}
+	}
+}
+
 func TestValidateSecret(t *testing.T) {
 	tests := []struct {
 		secret *v1.Secret
@@ -370,6 +539,19 @@ func TestValidateSecret(t *testing.T) {
 			},
 			msg: "Valid OIDC secret",
 		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+				Data: map[string][]byte{
+					"client1": []byte("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+				},
+			},
+			msg: "Valid API key secret",
+		},
 	}
 
 	for _, test := range tests {
@@ -418,6 +600,16 @@ func TestValidateSecretFails(t *testing.T) {
 			},
 			msg: "Missing jwk for JWK secret",
 		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "api-key-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeAPIKey,
+			},
+			msg: "Missing clients for API key secret",
+		},
 	}
 
 	for _, test := range tests {
@@ -449,6 +641,10 @@ func TestHasCorrectSecretType(t *testing.T) {
 			secretType: SecretTypeOIDC,
 			expected:   true,
 		},
+		{
+			secretType: SecretTypeAPIKey,
+			expected:   true,
+		},
 		{
 			secretType: "some-type",
 			expected:   false,
@@ -528,4 +724,15 @@ cpLlHMAqbLJ8WYGJCkhiWxyal6hYTyWY4cVkC0xtTl/hUE9IeNKo
 
 	invalidCACert = []byte(`-----BEGIN CERTIFICATE-----
 -----END CERTIFICATE-----`)
+
+	validCRL = []byte(`-----BEGIN X509 CRL-----
+MIHfMIGFAgEBMAoGCCqGSM49BAMCMA0xCzAJBgNVBAMTAmNhFw0yMTAxMDEwMDAw
+MDBaGA8yMDcxMDEwMTAwMDAwMFowFDASAgECFw0yMTAxMDEwMDAwMDBaoC8wLTAf
+BgNVHSMEGDAWgBTdNJVYVpXc+dIXMXltY5y7AadXDzAKBgNVHRQEAwIBATAKBggq
+hkjOPQQDAgNJADBGAiEAhkmvRm0f38QqqwQoKmtT/BLu/i/kHzGGn5s0SnrMJfEC
+IQCh0SWlhvRPb9+E804zkWjTX49MJwaLn1GzqUX5YQK1cQ==
+-----END X509 CRL-----`)
+
+	invalidCRL = []byte(`-----BEGIN X509 CRL-----
+-----END X509 CRL-----`)
 )
@@ -74,6 +74,10 @@ func (su *statusUpdater) UpdateExternalEndpointsForResource(r Resource) error {
 			ings = append(ings, *fm.Ingress)
 		}
 
+		for _, cc := range impl.Canaries {
+			ings = append(ings, *cc.Ingress)
+		}
+
 		return su.BulkUpdateIngressStatus(ings)
 	case *VirtualServerConfiguration:
 		failed := false
@@ -440,7 +444,50 @@ func hasTsStatusChanged(ts *conf_v1alpha1.TransportServer, state string, reason
 	return false
 }
 
+// UpdateVirtualServerStatusWithDefaultPolicies updates the status of a VirtualServer, including the defaultPolicies field.
+func (su *statusUpdater) UpdateVirtualServerStatusWithDefaultPolicies(vs *conf_v1.VirtualServer, state string, reason string, message string, defaultPolicies []string) error {
+	// Get an up-to-date VirtualServer from the Store
+	vsLatest, exists, err := su.virtualServerLister.Get(vs)
+	if err != nil {
+		glog.V(3).Infof("error getting VirtualServer from Store: %v", err)
+		return err
+	}
+	if !exists {
+		glog.V(3).Infof("VirtualServer doesn't exist in Store")
+		return nil
+	}
+
+	vsCopy := vsLatest.(*conf_v1.VirtualServer).DeepCopy()
+
+	if !hasVsStatusChanged(vsCopy, state, reason, message) && !haveDefaultPoliciesChanged(vsCopy.Status.DefaultPolicies, defaultPolicies) {
+		return nil
+	}
+
+	vsCopy.Status.State = state
+	vsCopy.Status.Reason = reason
+	vsCopy.Status.Message = message
+	vsCopy.Status.ExternalEndpoints = su.externalEndpoints
+	vsCopy.Status.DefaultPolicies = defaultPolicies
+
+	_, err = su.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, metav1.UpdateOptions{})
+	if err != nil {
+		glog.V(3).Infof("error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
+		return su.retryUpdateVirtualServerStatus(vsCopy)
+	}
+	return err
+}
+
+func haveDefaultPoliciesChanged(current []string, updated []string) bool {
+	if len(current) == 0 && len(updated) == 0 {
+		return false
+	}
+
+	return !reflect.DeepEqual(current, updated)
+}
+
 // UpdateVirtualServerStatus updates the status of a VirtualServer.
+// This method does not clear or update the defaultPolicies field of the status.
+// If you need to update the defaultPolicies field, use UpdateVirtualServerStatusWithDefaultPolicies instead.
 func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, state string, reason string, message string) error {
 	// Get an up-to-date VirtualServer from the Store
 	vsLatest, exists, err := su.virtualServerLister.Get(vs)
@@ -492,8 +539,9 @@ func hasVsrStatusChanged(vsr *conf_v1.VirtualServerRoute, state string, reason s
 	return false
 }
 
-// UpdateVirtualServerRouteStatusWithReferencedBy updates the status of a VirtualServerRoute, including the referencedBy field.
-func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *conf_v1.VirtualServerRoute, state string, reason string, message string, referencedBy []*v1.VirtualServer) error {
+// UpdateVirtualServerRouteStatusWithReferencedBy updates the status of a VirtualServerRoute, including the referencedBy
+// and defaultPolicies fields.
+func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *conf_v1.VirtualServerRoute, state string, reason string, message string, referencedBy []*v1.VirtualServer, defaultPolicies []string) error {
 	var referencedByString string
 	if len(referencedBy) != 0 {
 		vs := referencedBy[0]
@@ -513,7 +561,7 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 
 	vsrCopy := vsrLatest.(*conf_v1.VirtualServerRoute).DeepCopy()
 
-	if !hasVsrStatusChanged(vsrCopy, state, reason, message, referencedByString) {
+	if !hasVsrStatusChanged(vsrCopy, state, reason, message, referencedByString) && !haveDefaultPoliciesChanged(vsrCopy.Status.DefaultPolicies, defaultPolicies) {
 		return nil
 	}
 
@@ -522,6 +570,7 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 	vsrCopy.Status.Message = message
 	vsrCopy.Status.ReferencedBy = referencedByString
 	vsrCopy.Status.ExternalEndpoints = su.externalEndpoints
+	vsrCopy.Status.DefaultPolicies = defaultPolicies
 
 	_, err = su.confClient.K8sV1().VirtualServerRoutes(vsrCopy.Namespace).UpdateStatus(context.TODO(), vsrCopy, metav1.UpdateOptions{})
 	if err != nil {
@@ -532,8 +581,8 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 }
 
 // UpdateVirtualServerRouteStatus updates the status of a VirtualServerRoute.
-// This method does not clear or update the referencedBy field of the status.
-// If you need to update the referencedBy field, use UpdateVirtualServerRouteStatusWithReferencedBy instead.
+// This method does not clear or update the referencedBy and defaultPolicies fields of the status.
+// If you need to update those fields, use UpdateVirtualServerRouteStatusWithReferencedBy instead.
 func (su *statusUpdater) UpdateVirtualServerRouteStatus(vsr *conf_v1.VirtualServerRoute, state string, reason string, message string) error {
 	// Get an up-to-date VirtualServerRoute from the Store
 	vsrLatest, exists, err := su.virtualServerRouteLister.Get(vsr)
@@ -630,8 +679,16 @@ func hasPolicyStatusChanged(pol *v1.Policy, state string, reason string, message
 	return pol.Status.State != state || pol.Status.Reason != reason || pol.Status.Message != message
 }
 
-// UpdatePolicyStatus updates the status of a Policy.
-func (su *statusUpdater) UpdatePolicyStatus(pol *v1.Policy, state string, reason string, message string) error {
+func havePolicyReferencesChanged(current []v1.PolicyReferencedBy, updated []v1.PolicyReferencedBy) bool {
+	if len(current) == 0 && len(updated) == 0 {
+		return false
+	}
+
+	return !reflect.DeepEqual(current, updated)
+}
+
+// UpdatePolicyStatus updates the status of a Policy, including the resources that reference the Policy.
+func (su *statusUpdater) UpdatePolicyStatus(pol *v1.Policy, state string, reason string, message string, referencedBy []v1.PolicyReferencedBy) error {
 	// Get an up-to-date Policy from the Store
 	polLatest, exists, err := su.policyLister.Get(pol)
 	if err != nil {
@@ -648,15 +705,16 @@ func (su *statusUpdater) UpdatePolicyStatus(pol *v1.Policy, state string, reason
 		return nil
 	}
 
-	polCopy := polLatest.(*v1.Policy)
+	polCopy := polLatest.(*v1.Policy).DeepCopy()
 
-	if !hasPolicyStatusChanged(polCopy, state, reason, message) {
+	if !hasPolicyStatusChanged(polCopy, state, reason, message) && !havePolicyReferencesChanged(polCopy.Status.ReferencedBy, referencedBy) {
 		return nil
 	}
 
 	polCopy.Status.State = state
 	polCopy.Status.Reason = reason
 	polCopy.Status.Message = message
+	polCopy.Status.ReferencedBy = referencedBy
 
 	_, err = su.confClient.K8sV1().Policies(polCopy.Namespace).UpdateStatus(context.TODO(), polCopy, metav1.UpdateOptions{})
 	if err != nil {
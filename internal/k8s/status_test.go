@@ -179,6 +179,59 @@ func TestUpdateTransportServerStatusMissingTransportServer(t *testing.T) {
 	}
 }
 
+func TestUpdatePolicyStatusFailureKeepsStore(t *testing.T) {
+	pol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "default",
+		},
+		Status: conf_v1.PolicyStatus{
+			State:   "before status",
+			Reason:  "before reason",
+			Message: "before message",
+		},
+	}
+
+	// the Policy is missing in the API, so updating its status fails
+	fakeClient := fake_v1alpha1.NewSimpleClientset()
+
+	policyLister := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	err := policyLister.Add(pol)
+	if err != nil {
+		t.Errorf("Error adding Policy to the policy lister: %v", err)
+	}
+
+	su := statusUpdater{
+		policyLister:           policyLister,
+		confClient:             fakeClient,
+		keyFunc:                cache.DeletionHandlingMetaNamespaceKeyFunc,
+		hasCorrectIngressClass: func(interface{}) bool { return true },
+	}
+
+	referencedBy := []conf_v1.PolicyReferencedBy{
+		{
+			Kind:      "VirtualServer",
+			Namespace: "default",
+			Name:      "cafe",
+		},
+	}
+
+	err = su.UpdatePolicyStatus(pol, "after status", "after reason", "after message", referencedBy)
+	if err == nil {
+		t.Errorf("UpdatePolicyStatus() returned no error for a missing Policy")
+	}
+
+	expectedStatus := conf_v1.PolicyStatus{
+		State:   "before status",
+		Reason:  "before reason",
+		Message: "before message",
+	}
+
+	if diff := cmp.Diff(expectedStatus, pol.Status); diff != "" {
+		t.Errorf("UpdatePolicyStatus() modified the Policy in the store (-want +got):\n%s", diff)
+	}
+}
+
 func TestStatusUpdateWithExternalStatusAndExternalService(t *testing.T) {
 	ing := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -692,3 +745,59 @@ func TestHasPolicyStatusChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestHavePolicyReferencesChanged(t *testing.T) {
+	references := []conf_v1.PolicyReferencedBy{
+		{
+			Kind:      "VirtualServer",
+			Namespace: "default",
+			Name:      "cafe",
+		},
+	}
+
+	tests := []struct {
+		current  []conf_v1.PolicyReferencedBy
+		updated  []conf_v1.PolicyReferencedBy
+		expected bool
+		msg      string
+	}{
+		{
+			current:  nil,
+			updated:  []conf_v1.PolicyReferencedBy{},
+			expected: false,
+			msg:      "no references",
+		},
+		{
+			current:  references,
+			updated:  references,
+			expected: false,
+			msg:      "same references",
+		},
+		{
+			current:  nil,
+			updated:  references,
+			expected: true,
+			msg:      "added references",
+		},
+		{
+			current: references,
+			updated: []conf_v1.PolicyReferencedBy{
+				{
+					Kind:      "VirtualServer",
+					Namespace: "default",
+					Name:      "cafe",
+					Problems:  []string{"Policy default/jwt-policy is invalid"},
+				},
+			},
+			expected: true,
+			msg:      "added problems",
+		},
+	}
+
+	for _, test := range tests {
+		result := havePolicyReferencesChanged(test.current, test.updated)
+		if result != test.expected {
+			t.Errorf("havePolicyReferencesChanged() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -119,6 +119,9 @@ const (
 	appProtectLogConf
 	appProtectUserSig
 	ingressLink
+	hostPolicy
+	referenceGrant
+	defaultPolicy
 )
 
 // task is an element of a taskQueue
@@ -151,6 +154,12 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = globalConfiguration
 	case *conf_v1alpha1.TransportServer:
 		k = transportserver
+	case *conf_v1alpha1.HostPolicy:
+		k = hostPolicy
+	case *conf_v1alpha1.ReferenceGrant:
+		k = referenceGrant
+	case *conf_v1alpha1.DefaultPolicy:
+		k = defaultPolicy
 	case *unstructured.Unstructured:
 		if objectKind := obj.(*unstructured.Unstructured).GetKind(); objectKind == appprotect.PolicyGVK.Kind {
 			k = appProtectPolicy
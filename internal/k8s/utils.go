@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 
@@ -132,6 +133,12 @@ func isMaster(ing *networking.Ingress) bool {
 	return ing.Annotations["nginx.org/mergeable-ingress-type"] == "master"
 }
 
+// isCanary determines if an ingress is a canary or not
+func isCanary(ing *networking.Ingress) bool {
+	canary, err := configs.ParseBool(ing.Annotations[configs.CanaryAnnotation])
+	return err == nil && canary
+}
+
 // hasChanges determines if current ingress has changes compared to old ingress
 func hasChanges(old *networking.Ingress, current *networking.Ingress) bool {
 	old.Status.LoadBalancer.Ingress = current.Status.LoadBalancer.Ingress
@@ -3,12 +3,14 @@ package k8s
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -57,6 +59,14 @@ const (
 	grpcServicesAnnotation                = "nginx.org/grpc-services"
 	rewritesAnnotation                    = "nginx.org/rewrites"
 	stickyCookieServicesAnnotation        = "nginx.com/sticky-cookie-services"
+	policiesAnnotation                    = "nginx.org/policies"
+	canaryAnnotation                      = "nginx.org/canary"
+	canaryWeightAnnotation                = "nginx.org/canary-weight"
+	canaryByHeaderAnnotation              = "nginx.org/canary-by-header"
+	canaryByHeaderValueAnnotation         = "nginx.org/canary-by-header-value"
+	canaryByCookieAnnotation              = "nginx.org/canary-by-cookie"
+	minionNamespacesAnnotation            = "nginx.org/mergeable-ingress-minion-namespaces"
+	pathRegexAnnotation                   = "nginx.org/path-regex"
 )
 
 type annotationValidationContext struct {
@@ -84,6 +94,11 @@ var (
 			validateRequiredAnnotation,
 			validateMergeableIngressTypeAnnotation,
 		},
+		minionNamespacesAnnotation: {
+			validateRelatedAnnotation(mergeableIngressTypeAnnotation, validateIsMaster),
+			validateRequiredAnnotation,
+			validateNamespaceListAnnotation,
+		},
 		lbMethodAnnotation: {
 			validateRequiredAnnotation,
 			validateLBMethodAnnotation,
@@ -252,10 +267,58 @@ var (
 			validateRequiredAnnotation,
 			validateStickyServiceListAnnotation,
 		},
+		policiesAnnotation: {
+			validateRequiredAnnotation,
+			validatePolicyListAnnotation,
+		},
+		pathRegexAnnotation: {
+			validateRequiredAnnotation,
+			validatePathRegexAnnotation,
+		},
+		canaryAnnotation: {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+			validateCanaryAnnotation,
+		},
+		canaryWeightAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateCanaryWeightAnnotation,
+		},
+		canaryByHeaderAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateHeaderNameAnnotation,
+		},
+		canaryByHeaderValueAnnotation: {
+			validateRelatedAnnotation(canaryByHeaderAnnotation, validateIsHeaderName),
+			validateRequiredAnnotation,
+			validateCanaryHeaderValueAnnotation,
+		},
+		canaryByCookieAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateCookieNameAnnotation,
+		},
 	}
 	annotationNames = sortedAnnotationNames(annotationValidations)
+
+	// annotationPrefixes are the prefixes of the annotations of the Ingress Controller.
+	// An annotation with one of these prefixes that the Ingress Controller doesn't recognize is reported in a warning.
+	annotationPrefixes = []string{"nginx.org/", "nginx.com/", "appprotect.f5.com/"}
+
+	// knownAnnotations includes the annotations with validations along with the annotations that are not validated.
+	knownAnnotations = sets.NewString(annotationNames...).Insert(
+		configs.AppProtectPolicyAnnotation,
+		configs.AppProtectLogConfAnnotation,
+		configs.AppProtectLogConfDstAnnotation,
+	)
 )
 
+// maxAnnotationSuggestionDistance is the maximum edit distance between an unrecognized annotation and a known
+// annotation for the known annotation to be suggested.
+const maxAnnotationSuggestionDistance = 3
+
 func sortedAnnotationNames(annotationValidations annotationValidationConfig) []string {
 	sortedNames := make([]string, 0)
 	for annotationName := range annotationValidations {
@@ -285,15 +348,104 @@ func validateIngress(
 
 	allErrs = append(allErrs, validateIngressSpec(&ing.Spec, field.NewPath("spec"))...)
 
+	if _, exists := ing.Annotations[pathRegexAnnotation]; exists {
+		allErrs = append(allErrs, validateRegexPaths(&ing.Spec, field.NewPath("spec"))...)
+	}
+
 	if isMaster(ing) {
 		allErrs = append(allErrs, validateMasterSpec(&ing.Spec, field.NewPath("spec"))...)
 	} else if isMinion(ing) {
 		allErrs = append(allErrs, validateMinionSpec(&ing.Spec, field.NewPath("spec"))...)
+	} else if isCanary(ing) {
+		allErrs = append(allErrs, validateCanarySpec(&ing.Spec, field.NewPath("spec"))...)
 	}
 
 	return allErrs
 }
 
+// validateIngressAnnotationNames returns the warnings about the annotations of an Ingress that have the prefixes of the
+// annotations of the Ingress Controller but that the Ingress Controller doesn't recognize. Such annotations are ignored,
+// for example, when they are misspelled. A warning suggests the closest known annotation, if any.
+func validateIngressAnnotationNames(annotations map[string]string) []string {
+	var names []string
+	for name := range annotations {
+		if !knownAnnotations.Has(name) && hasAnnotationPrefix(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var warnings []string
+	for _, name := range names {
+		warning := fmt.Sprintf("annotation %s is not recognized and is ignored", name)
+		if suggestion := suggestAnnotation(name); suggestion != "" {
+			warning = fmt.Sprintf("%s; did you mean %s?", warning, suggestion)
+		}
+		warnings = append(warnings, warning)
+	}
+
+	return warnings
+}
+
+func hasAnnotationPrefix(name string) bool {
+	for _, prefix := range annotationPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestAnnotation returns the known annotation closest to the name or an empty string
+// if no known annotation is close enough.
+func suggestAnnotation(name string) string {
+	suggestion := ""
+	minDistance := maxAnnotationSuggestionDistance + 1
+
+	for _, known := range knownAnnotations.List() {
+		if d := editDistance(name, known); d < minDistance {
+			suggestion = known
+			minDistance = d
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(first int, others ...int) int {
+	result := first
+	for _, v := range others {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
 func validateIngressAnnotations(
 	annotations map[string]string,
 	specServices map[string]bool,
@@ -523,11 +675,119 @@ func validateRewriteListAnnotation(context *annotationValidationContext) field.E
 	return allErrs
 }
 
+func validatePolicyListAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := configs.ParsePolicyList(context.value); err != nil {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be a comma-separated list of policies in the format <name> or <namespace>/<name>"))
+	}
+	return allErrs
+}
+
+func validateNamespaceListAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, err := configs.ParseNamespaceList(context.value); err != nil {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be a comma-separated list of namespaces or *"))
+	}
+	return allErrs
+}
+
+func validatePathRegexAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if context.value != configs.PathRegexCaseSensitive && context.value != configs.PathRegexCaseInsensitive {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be one of: 'case_sensitive' or 'case_insensitive'"))
+	}
+	return allErrs
+}
+
+func validateCanaryAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	canary, _ := configs.ParseBool(context.value)
+	if _, exists := context.annotations[mergeableIngressTypeAnnotation]; canary && exists {
+		return append(allErrs, field.Forbidden(context.fieldPath, fmt.Sprintf("a canary Ingress can't have the %s annotation", mergeableIngressTypeAnnotation)))
+	}
+	return allErrs
+}
+
+func validateCanaryWeightAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	weight, err := configs.ParseInt(context.value)
+	if err != nil || weight < 0 || weight > 100 {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be an integer between 0 and 100"))
+	}
+	return allErrs
+}
+
+func validateHeaderNameAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsHTTPHeaderName(context.value) {
+		allErrs = append(allErrs, field.Invalid(context.fieldPath, context.value, msg))
+	}
+	return allErrs
+}
+
+const (
+	canaryHeaderValueFmt    = `[^"\\]+`
+	canaryHeaderValueErrMsg = `a valid header value must not contain '"' or '\'`
+)
+
+var canaryHeaderValueRegexp = regexp.MustCompile("^" + canaryHeaderValueFmt + "$")
+
+// canaryHeaderValueReservedValues are the special parameters of the NGINX map directive, which the header value becomes.
+var canaryHeaderValueReservedValues = map[string]bool{
+	"default":   true,
+	"hostnames": true,
+	"include":   true,
+	"volatile":  true,
+}
+
+func validateCanaryHeaderValueAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !canaryHeaderValueRegexp.MatchString(context.value) {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, validation.RegexError(canaryHeaderValueErrMsg, canaryHeaderValueFmt, "always", "v2")))
+	}
+	if canaryHeaderValueReservedValues[context.value] {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "is a reserved value"))
+	}
+	if strings.HasPrefix(context.value, "~") {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must not start with '~'"))
+	}
+	return allErrs
+}
+
+const (
+	cookieNameFmt    = "[_A-Za-z0-9]+"
+	cookieNameErrMsg = "a valid cookie name must consist of alphanumeric characters or '_'"
+)
+
+var cookieNameRegexp = regexp.MustCompile("^" + cookieNameFmt + "$")
+
+func validateCookieNameAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !cookieNameRegexp.MatchString(context.value) {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, validation.RegexError(cookieNameErrMsg, cookieNameFmt, "my_cookie_123")))
+	}
+	return allErrs
+}
+
+func validateIsHeaderName(v string) error {
+	if msgs := validation.IsHTTPHeaderName(v); len(msgs) > 0 {
+		return errors.New(msgs[0])
+	}
+	return nil
+}
+
 func validateIsBool(v string) error {
 	_, err := configs.ParseBool(v)
 	return err
 }
 
+func validateIsMaster(v string) error {
+	if v != "master" {
+		return errors.New("must be master")
+	}
+	return nil
+}
+
 func validateIsTrue(v string) error {
 	b, err := configs.ParseBool(v)
 	if err != nil {
@@ -577,6 +837,45 @@ func validateIngressSpec(spec *networking.IngressSpec, fieldPath *field.Path) fi
 	return allErrs
 }
 
+const (
+	regexPathFmt    = `([^"\\]|\\.)*`
+	regexPathErrMsg = "must have all '\"' (double quotes) escaped and must not end with an unescaped '\\' (backslash)"
+)
+
+var regexPathRegexp = regexp.MustCompile("^" + regexPathFmt + "$")
+
+// validateRegexPaths validates the ImplementationSpecific paths of an Ingress with the nginx.org/path-regex annotation,
+// which are regular expressions.
+func validateRegexPaths(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, r := range spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+
+		for j, path := range r.HTTP.Paths {
+			if path.PathType == nil || *path.PathType != networking.PathTypeImplementationSpecific {
+				continue
+			}
+
+			idxPath := fieldPath.Child("rules").Index(i).Child("http").Child("paths").Index(j).Child("path")
+
+			if _, err := regexp.Compile(path.Path); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath, path.Path, fmt.Sprintf("must be a valid regular expression: %v", err)))
+				continue
+			}
+
+			if !regexPathRegexp.MatchString(path.Path) {
+				msg := validation.RegexError(regexPathErrMsg, regexPathFmt, "/images/.*[.]jpg$", `^/api/v[0-9]+/`)
+				allErrs = append(allErrs, field.Invalid(idxPath, path.Path, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateBackend(backend *networking.IngressBackend, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -623,6 +922,19 @@ func validateMinionSpec(spec *networking.IngressSpec, fieldPath *field.Path) fie
 	return allErrs
 }
 
+func validateCanarySpec(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// at least one rule of the spec must have paths
+	for _, r := range spec.Rules {
+		if r.HTTP != nil && len(r.HTTP.Paths) > 0 {
+			return allErrs
+		}
+	}
+
+	return append(allErrs, field.Required(fieldPath.Child("rules"), "must include at least one path"))
+}
+
 func getSpecServices(ingressSpec networking.IngressSpec) map[string]bool {
 	services := make(map[string]bool)
 	if ingressSpec.DefaultBackend != nil && ingressSpec.DefaultBackend.Service != nil {
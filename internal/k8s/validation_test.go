@@ -13,6 +13,8 @@ import (
 )
 
 func TestValidateIngress(t *testing.T) {
+	implementationSpecific := networking.PathTypeImplementationSpecific
+
 	tests := []struct {
 		ing                   *networking.Ingress
 		isPlus                bool
@@ -117,6 +119,39 @@ func TestValidateIngress(t *testing.T) {
 			},
 			msg: "invalid minion",
 		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Annotations: map[string]string{
+						"nginx.org/path-regex": "case_sensitive",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							Host: "example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
+										{
+											Path:     "/images/[a-z",
+											PathType: &implementationSpecific,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"spec.rules[0].http.paths[0].path: Invalid value: \"/images/[a-z\": must be a valid regular expression: error parsing regexp: missing closing ]: `[a-z`",
+			},
+			msg: "invalid regex path",
+		},
 	}
 
 	for _, test := range tests {
@@ -1651,6 +1686,292 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			},
 			msg: "invalid nginx.com/sticky-cookie-services annotation",
 		},
+
+		{
+			annotations: map[string]string{
+				"nginx.org/policies": "access-control,policies/rate-limit",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/policies annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/policies": "",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/policies: Required value",
+			},
+			msg: "invalid nginx.org/policies annotation, empty",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/policies": "policies/rate-limit/v1",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/policies: Invalid value: "policies/rate-limit/v1": must be a comma-separated list of policies in the format <name> or <namespace>/<name>`,
+			},
+			msg: "invalid nginx.org/policies annotation",
+		},
+
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-type":              "master",
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea,coffee",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/mergeable-ingress-minion-namespaces annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-type":              "master",
+				"nginx.org/mergeable-ingress-minion-namespaces": "tea,*",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/mergeable-ingress-minion-namespaces: Invalid value: "tea,*": must be a comma-separated list of namespaces or *`,
+			},
+			msg: "invalid nginx.org/mergeable-ingress-minion-namespaces annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/mergeable-ingress-type":              "minion",
+				"nginx.org/mergeable-ingress-minion-namespaces": "*",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/mergeable-ingress-minion-namespaces: Forbidden: related annotation nginx.org/mergeable-ingress-type: must be master",
+			},
+			msg: "invalid nginx.org/mergeable-ingress-minion-namespaces annotation, not a master",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "case_sensitive",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/path-regex annotation, case_sensitive",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "case_insensitive",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/path-regex annotation, case_insensitive",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "true",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/path-regex: Invalid value: "true": must be one of: 'case_sensitive' or 'case_insensitive'`,
+			},
+			msg: "invalid nginx.org/path-regex annotation",
+		},
+
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-weight":          "20",
+				"nginx.org/canary-by-header":       "X-Canary",
+				"nginx.org/canary-by-header-value": "v2",
+				"nginx.org/canary-by-cookie":       "canary",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid canary annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/mergeable-ingress-type": "minion",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/canary: Forbidden: a canary Ingress can't have the nginx.org/mergeable-ingress-type annotation",
+			},
+			msg: "invalid nginx.org/canary annotation, mergeable Ingress",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary": "yes",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary: Invalid value: "yes": must be a boolean`,
+			},
+			msg: "invalid nginx.org/canary annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary-weight": "20",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/canary-weight: Forbidden: related annotation nginx.org/canary: must be set",
+			},
+			msg: "invalid nginx.org/canary-weight annotation, canary not set",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":        "true",
+				"nginx.org/canary-weight": "101",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-weight: Invalid value: "101": must be an integer between 0 and 100`,
+			},
+			msg: "invalid nginx.org/canary-weight annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":           "true",
+				"nginx.org/canary-by-header": "X Canary",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header: Invalid value: "X Canary": a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')`,
+			},
+			msg: "invalid nginx.org/canary-by-header annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-by-header-value": "v2",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/canary-by-header-value: Forbidden: related annotation nginx.org/canary-by-header: must be set",
+			},
+			msg: "invalid nginx.org/canary-by-header-value annotation, header not set",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-by-header":       "X-Canary",
+				"nginx.org/canary-by-header-value": `v2"`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header-value: Invalid value: "v2\"": a valid header value must not contain '"' or '\' (e.g. 'always',  or 'v2', regex used for validation is '[^"\\]+')`,
+			},
+			msg: "invalid nginx.org/canary-by-header-value annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-by-header":       "X-Canary",
+				"nginx.org/canary-by-header-value": "default",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header-value: Invalid value: "default": is a reserved value`,
+			},
+			msg: "invalid nginx.org/canary-by-header-value annotation, reserved value",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-by-header":       "X-Canary",
+				"nginx.org/canary-by-header-value": "volatile",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header-value: Invalid value: "volatile": is a reserved value`,
+			},
+			msg: "invalid nginx.org/canary-by-header-value annotation, reserved value volatile",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/canary-by-header":       "X-Canary",
+				"nginx.org/canary-by-header-value": "~^v[0-9]",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header-value: Invalid value: "~^v[0-9]": must not start with '~'`,
+			},
+			msg: "invalid nginx.org/canary-by-header-value annotation, regex",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":           "true",
+				"nginx.org/canary-by-cookie": "canary-cookie",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-cookie: Invalid value: "canary-cookie": a valid cookie name must consist of alphanumeric characters or '_' (e.g. 'my_cookie_123', regex used for validation is '[_A-Za-z0-9]+')`,
+			},
+			msg: "invalid nginx.org/canary-by-cookie annotation",
+		},
 	}
 
 	for _, test := range tests {
@@ -1671,6 +1992,74 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 	}
 }
 
+func TestValidateIngressAnnotationNames(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    []string
+		msg         string
+	}{
+		{
+			annotations: map[string]string{
+				"kubernetes.io/ingress.class":          "nginx",
+				"nginx.org/proxy-read-timeout":         "30s",
+				"nginx.com/health-checks":              "true",
+				"appprotect.f5.com/app-protect-policy": "default/dataguard-alarm",
+				"custom.nginx.org/rate-limiting":       "on",
+				"nsm.nginx.com/internal-route":         "true",
+			},
+			expected: nil,
+			msg:      "known annotations and annotations with other prefixes",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/proxy-read-timout":             "30s",
+				"nginx.org/slow-start":                    "10s",
+				"appprotect.f5.com/app-protect-enabled":   "true",
+				"nginx.com/my-feature":                    "true",
+				"nginx.org/lb_method":                     "round_robin",
+				"nginx.org/proxy-connect-timeout-seconds": "30",
+			},
+			expected: []string{
+				"annotation appprotect.f5.com/app-protect-enabled is not recognized and is ignored; did you mean appprotect.f5.com/app-protect-enable?",
+				"annotation nginx.com/my-feature is not recognized and is ignored",
+				"annotation nginx.org/lb_method is not recognized and is ignored; did you mean nginx.org/lb-method?",
+				"annotation nginx.org/proxy-connect-timeout-seconds is not recognized and is ignored",
+				"annotation nginx.org/proxy-read-timout is not recognized and is ignored; did you mean nginx.org/proxy-read-timeout?",
+				"annotation nginx.org/slow-start is not recognized and is ignored; did you mean nginx.com/slow-start?",
+			},
+			msg: "unknown annotations",
+		},
+	}
+
+	for _, test := range tests {
+		warnings := validateIngressAnnotationNames(test.annotations)
+		if !reflect.DeepEqual(test.expected, warnings) {
+			t.Errorf("validateIngressAnnotationNames() returned %v but expected %v for the case of %s", warnings, test.expected, test.msg)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "", b: "", expected: 0},
+		{a: "", b: "abc", expected: 3},
+		{a: "abc", b: "abc", expected: 0},
+		{a: "timeout", b: "timout", expected: 1},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+
+	for _, test := range tests {
+		result := editDistance(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("editDistance(%q, %q) returned %d but expected %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
 func TestValidateIngressSpec(t *testing.T) {
 	tests := []struct {
 		spec           *networking.IngressSpec
@@ -1804,6 +2193,79 @@ func TestValidateIngressSpec(t *testing.T) {
 	}
 }
 
+func TestValidateRegexPaths(t *testing.T) {
+	implementationSpecific := networking.PathTypeImplementationSpecific
+	prefix := networking.PathTypePrefix
+
+	createSpec := func(paths ...networking.HTTPIngressPath) *networking.IngressSpec {
+		return &networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "foo.example.com",
+				},
+				{
+					Host: "bar.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: paths,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		spec           *networking.IngressSpec
+		expectedErrors []string
+		msg            string
+	}{
+		{
+			spec: createSpec(
+				networking.HTTPIngressPath{Path: "/images/.*[.]jpg$", PathType: &implementationSpecific},
+				networking.HTTPIngressPath{Path: `^/api/v[0-9]+/\"quoted\"`, PathType: &implementationSpecific},
+			),
+			expectedErrors: nil,
+			msg:            "valid regular expressions",
+		},
+		{
+			spec: createSpec(
+				networking.HTTPIngressPath{Path: "/images/(jpg", PathType: &prefix},
+				networking.HTTPIngressPath{Path: "/images/(jpg"},
+			),
+			expectedErrors: nil,
+			msg:            "paths that are not ImplementationSpecific are not validated",
+		},
+		{
+			spec: createSpec(
+				networking.HTTPIngressPath{Path: "/", PathType: &implementationSpecific},
+				networking.HTTPIngressPath{Path: "/images/(jpg", PathType: &implementationSpecific},
+			),
+			expectedErrors: []string{
+				"spec.rules[1].http.paths[1].path: Invalid value: \"/images/(jpg\": must be a valid regular expression: error parsing regexp: missing closing ): `/images/(jpg`",
+			},
+			msg: "invalid regular expression",
+		},
+		{
+			spec: createSpec(
+				networking.HTTPIngressPath{Path: `/"images"`, PathType: &implementationSpecific},
+			),
+			expectedErrors: []string{
+				`spec.rules[1].http.paths[0].path: Invalid value: "/\"images\"": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. '/images/.*[.]jpg$',  or '^/api/v[0-9]+/', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+			msg: "unescaped double quotes",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRegexPaths(test.spec, field.NewPath("spec"))
+		assertion := assertErrors("validateRegexPaths()", test.msg, allErrs, test.expectedErrors)
+		if assertion != "" {
+			t.Error(assertion)
+		}
+	}
+}
+
 func TestValidateMasterSpec(t *testing.T) {
 	tests := []struct {
 		spec           *networking.IngressSpec
@@ -1973,6 +2435,59 @@ func TestValidateMinionSpec(t *testing.T) {
 	}
 }
 
+func TestValidateCanarySpec(t *testing.T) {
+	tests := []struct {
+		spec           *networking.IngressSpec
+		expectedErrors []string
+		msg            string
+	}{
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+					},
+					{
+						Host: "bar.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: nil,
+			msg:            "valid input",
+		},
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+					},
+				},
+			},
+			expectedErrors: []string{
+				"spec.rules: Required value: must include at least one path",
+			},
+			msg: "no paths",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCanarySpec(test.spec, field.NewPath("spec"))
+		assertion := assertErrors("validateCanarySpec()", test.msg, allErrs, test.expectedErrors)
+		if assertion != "" {
+			t.Error(assertion)
+		}
+	}
+}
+
 func assertErrors(funcName string, msg string, allErrs field.ErrorList, expectedErrors []string) string {
 	errors := errorListToStrings(allErrs)
 	if !reflect.DeepEqual(errors, expectedErrors) {
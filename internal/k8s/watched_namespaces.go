@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"sync"
+
+	"github.com/golang/glog"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchedNamespaces holds the namespaces watched by the Ingress Controller, when it watches a list of namespaces
+// and/or the namespaces selected by a label selector.
+// The namespaces selected by the label selector are updated at runtime as the labels of the namespaces change.
+type watchedNamespaces struct {
+	namespaces map[string]bool
+	selector   labels.Selector
+	selected   map[string]bool
+	lock       sync.RWMutex
+}
+
+func newWatchedNamespaces(namespaces []string, selector labels.Selector) *watchedNamespaces {
+	w := &watchedNamespaces{
+		namespaces: make(map[string]bool),
+		selector:   selector,
+		selected:   make(map[string]bool),
+	}
+
+	for _, ns := range namespaces {
+		w.namespaces[ns] = true
+	}
+
+	return w
+}
+
+// isWatched checks if the namespace is watched.
+func (w *watchedNamespaces) isWatched(namespace string) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	return w.namespaces[namespace] || w.selected[namespace]
+}
+
+// addOrUpdateNamespace updates the selected namespaces with the namespace.
+// It returns whether the namespace was watched before and after the update.
+func (w *watchedNamespaces) addOrUpdateNamespace(ns *api_v1.Namespace) (wasWatched bool, isWatched bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	wasWatched = w.namespaces[ns.Name] || w.selected[ns.Name]
+
+	if w.selector != nil && w.selector.Matches(labels.Set(ns.Labels)) {
+		w.selected[ns.Name] = true
+	} else {
+		delete(w.selected, ns.Name)
+	}
+
+	isWatched = w.namespaces[ns.Name] || w.selected[ns.Name]
+
+	return wasWatched, isWatched
+}
+
+// deleteNamespace removes the namespace from the selected namespaces.
+// It returns whether the namespace was watched before and after the removal.
+func (w *watchedNamespaces) deleteNamespace(name string) (wasWatched bool, isWatched bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	wasWatched = w.namespaces[name] || w.selected[name]
+	delete(w.selected, name)
+	isWatched = w.namespaces[name]
+
+	return wasWatched, isWatched
+}
+
+// namespaceFilteringStore is a cache.Store that only returns the objects from the watched namespaces.
+// Adding, updating and deleting objects is not affected.
+type namespaceFilteringStore struct {
+	cache.Store
+	isWatched func(namespace string) bool
+}
+
+func (s *namespaceFilteringStore) isObjectWatched(obj interface{}) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return s.isWatched(accessor.GetNamespace())
+}
+
+// List returns the objects from the watched namespaces.
+func (s *namespaceFilteringStore) List() []interface{} {
+	var result []interface{}
+	for _, obj := range s.Store.List() {
+		if s.isObjectWatched(obj) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// ListKeys returns the keys of the objects from the watched namespaces.
+func (s *namespaceFilteringStore) ListKeys() []string {
+	var result []string
+	for _, key := range s.Store.ListKeys() {
+		namespace, _, err := cache.SplitMetaNamespaceKey(key)
+		if err == nil && s.isWatched(namespace) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// Get returns the object if it exists in a watched namespace.
+func (s *namespaceFilteringStore) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	item, exists, err = s.Store.Get(obj)
+	if err != nil || !exists || !s.isObjectWatched(item) {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+// GetByKey returns the object with the key if it exists in a watched namespace.
+func (s *namespaceFilteringStore) GetByKey(key string) (item interface{}, exists bool, err error) {
+	item, exists, err = s.Store.GetByKey(key)
+	if err != nil || !exists || !s.isObjectWatched(item) {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+// namespacedInformer holds the indexer and the handlers of an informer of namespaced resources, so that
+// the handlers can receive the resources of a namespace when the namespace becomes watched or is no longer watched.
+type namespacedInformer struct {
+	indexer  cache.Indexer
+	handlers cache.ResourceEventHandler
+}
+
+// isObjectInWatchedNamespace checks if the object belongs to a watched namespace. The object can also be
+// a cache.DeletedFinalStateUnknown.
+func (lbc *LoadBalancerController) isObjectInWatchedNamespace(obj interface{}) bool {
+	key, err := keyFunc(obj)
+	if err != nil {
+		glog.V(3).Infof("Error getting the key of the object %v: %v", obj, err)
+		return false
+	}
+
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		glog.V(3).Infof("Error splitting the key %v: %v", key, err)
+		return false
+	}
+
+	return lbc.watchedNamespaces.isWatched(namespace)
+}
+
+// addNamespacedInformerHandlers adds the handlers to the informer of namespaced resources and returns the store of the informer.
+// If the Ingress Controller watches multiple namespaces, the handlers only receive and the store only returns the resources
+// from the watched namespaces.
+func (lbc *LoadBalancerController) addNamespacedInformerHandlers(informer cache.SharedIndexInformer, handlers cache.ResourceEventHandler) cache.Store {
+	if lbc.watchedNamespaces == nil {
+		informer.AddEventHandler(handlers)
+		return informer.GetStore()
+	}
+
+	informer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: lbc.isObjectInWatchedNamespace,
+		Handler:    handlers,
+	})
+
+	lbc.namespacedInformers = append(lbc.namespacedInformers, namespacedInformer{
+		indexer:  informer.GetIndexer(),
+		handlers: handlers,
+	})
+
+	return &namespaceFilteringStore{
+		Store:     informer.GetStore(),
+		isWatched: lbc.watchedNamespaces.isWatched,
+	}
+}
+
+// syncWatchedNamespace notifies the handlers about the resources of the namespace if the namespace
+// became watched (the resources are added) or is no longer watched (the resources are deleted).
+func (lbc *LoadBalancerController) syncWatchedNamespace(namespace string, wasWatched bool, isWatched bool) {
+	if wasWatched == isWatched {
+		return
+	}
+
+	if isWatched {
+		glog.V(2).Infof("Namespace %v is now watched", namespace)
+	} else {
+		glog.V(2).Infof("Namespace %v is no longer watched", namespace)
+	}
+
+	for _, ni := range lbc.namespacedInformers {
+		objects, err := ni.indexer.ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			glog.Errorf("Error getting the resources of the namespace %v: %v", namespace, err)
+			continue
+		}
+
+		for _, obj := range objects {
+			if isWatched {
+				ni.handlers.OnAdd(obj)
+			} else {
+				ni.handlers.OnDelete(obj)
+			}
+		}
+	}
+}
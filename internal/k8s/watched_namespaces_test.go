@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	api_v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+func createTestNamespace(name string, nsLabels map[string]string) *api_v1.Namespace {
+	return &api_v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nsLabels,
+		},
+	}
+}
+
+func TestWatchedNamespaces(t *testing.T) {
+	w := newWatchedNamespaces([]string{"static"}, labels.SelectorFromSet(labels.Set{"team": "a"}))
+
+	tests := []struct {
+		update             func() (bool, bool)
+		expectedWasWatched bool
+		expectedIsWatched  bool
+		msg                string
+	}{
+		{
+			update: func() (bool, bool) {
+				return w.addOrUpdateNamespace(createTestNamespace("ns-1", map[string]string{"team": "a"}))
+			},
+			expectedWasWatched: false,
+			expectedIsWatched:  true,
+			msg:                "add matching namespace",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.addOrUpdateNamespace(createTestNamespace("ns-2", map[string]string{"team": "b"}))
+			},
+			expectedWasWatched: false,
+			expectedIsWatched:  false,
+			msg:                "add not matching namespace",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.addOrUpdateNamespace(createTestNamespace("ns-1", map[string]string{"team": "b"}))
+			},
+			expectedWasWatched: true,
+			expectedIsWatched:  false,
+			msg:                "namespace no longer matches",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.addOrUpdateNamespace(createTestNamespace("ns-2", map[string]string{"team": "a"}))
+			},
+			expectedWasWatched: false,
+			expectedIsWatched:  true,
+			msg:                "namespace starts matching",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.deleteNamespace("ns-2")
+			},
+			expectedWasWatched: true,
+			expectedIsWatched:  false,
+			msg:                "delete matching namespace",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.addOrUpdateNamespace(createTestNamespace("static", nil))
+			},
+			expectedWasWatched: true,
+			expectedIsWatched:  true,
+			msg:                "add listed namespace",
+		},
+		{
+			update: func() (bool, bool) {
+				return w.deleteNamespace("static")
+			},
+			expectedWasWatched: true,
+			expectedIsWatched:  true,
+			msg:                "delete listed namespace",
+		},
+	}
+
+	for _, test := range tests {
+		wasWatched, isWatched := test.update()
+		if wasWatched != test.expectedWasWatched || isWatched != test.expectedIsWatched {
+			t.Errorf("watchedNamespaces returned (%v, %v) but expected (%v, %v) for the case of %s",
+				wasWatched, isWatched, test.expectedWasWatched, test.expectedIsWatched, test.msg)
+		}
+	}
+}
+
+type recordingHandler struct {
+	added   []string
+	deleted []string
+}
+
+func (h *recordingHandler) OnAdd(obj interface{}) {
+	key, _ := keyFunc(obj)
+	h.added = append(h.added, key)
+}
+
+func (h *recordingHandler) OnUpdate(_, _ interface{}) {}
+
+func (h *recordingHandler) OnDelete(obj interface{}) {
+	key, _ := keyFunc(obj)
+	h.deleted = append(h.deleted, key)
+}
+
+func TestNamespacedInformerHandlers(t *testing.T) {
+	lbc := &LoadBalancerController{
+		watchedNamespaces: newWatchedNamespaces([]string{"ns-1"}, labels.SelectorFromSet(labels.Set{"team": "a"})),
+	}
+
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &networking.Ingress{}, 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	handler := &recordingHandler{}
+
+	store := lbc.addNamespacedInformerHandlers(informer, handler)
+
+	for _, key := range []string{"ns-1/ingress", "ns-2/ingress", "ns-2/ingress-2", "ns-3/ingress"} {
+		ns, name, _ := cache.SplitMetaNamespaceKey(key)
+		ing := createTestIngress(name, "example.com")
+		ing.Namespace = ns
+		err := informer.GetIndexer().Add(ing)
+		if err != nil {
+			t.Fatalf("failed to add Ingress to the indexer: %v", err)
+		}
+	}
+
+	expectedKeys := []string{"ns-1/ingress"}
+	keys := store.ListKeys()
+	if diff := cmp.Diff(expectedKeys, keys); diff != "" {
+		t.Errorf("ListKeys() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if _, exists, _ := store.GetByKey("ns-2/ingress"); exists {
+		t.Errorf("GetByKey() returned an Ingress from a namespace which is not watched")
+	}
+
+	// ns-2 becomes watched
+
+	wasWatched, isWatched := lbc.watchedNamespaces.addOrUpdateNamespace(createTestNamespace("ns-2", map[string]string{"team": "a"}))
+	lbc.syncWatchedNamespace("ns-2", wasWatched, isWatched)
+
+	sort.Strings(handler.added)
+	expectedAdded := []string{"ns-2/ingress", "ns-2/ingress-2"}
+	if diff := cmp.Diff(expectedAdded, handler.added); diff != "" {
+		t.Errorf("syncWatchedNamespace() added unexpected resources (-want +got):\n%s", diff)
+	}
+	if len(store.List()) != 3 {
+		t.Errorf("List() returned %d resources but expected 3", len(store.List()))
+	}
+
+	// ns-2 is no longer watched
+
+	wasWatched, isWatched = lbc.watchedNamespaces.addOrUpdateNamespace(createTestNamespace("ns-2", nil))
+	lbc.syncWatchedNamespace("ns-2", wasWatched, isWatched)
+
+	sort.Strings(handler.deleted)
+	expectedDeleted := []string{"ns-2/ingress", "ns-2/ingress-2"}
+	if diff := cmp.Diff(expectedDeleted, handler.deleted); diff != "" {
+		t.Errorf("syncWatchedNamespace() deleted unexpected resources (-want +got):\n%s", diff)
+	}
+	if _, exists, _ := store.GetByKey("ns-2/ingress"); exists {
+		t.Errorf("GetByKey() returned an Ingress from a namespace which is no longer watched")
+	}
+}
@@ -12,6 +12,7 @@ import (
 // FakeManager provides a fake implementation of the Manager interface.
 type FakeManager struct {
 	confdPath       string
+	streamConfdPath string
 	secretsPath     string
 	dhparamFilename string
 }
@@ -20,6 +21,7 @@ type FakeManager struct {
 func NewFakeManager(confPath string) *FakeManager {
 	return &FakeManager{
 		confdPath:       path.Join(confPath, "conf.d"),
+		streamConfdPath: path.Join(confPath, "stream-conf.d"),
 		secretsPath:     path.Join(confPath, "secrets"),
 		dhparamFilename: path.Join(confPath, "secrets", "dhparam.pem"),
 	}
@@ -90,6 +92,16 @@ func (fm *FakeManager) GetFilenameForSecret(name string) string {
 	return path.Join(fm.secretsPath, name)
 }
 
+// GetFilenameForConfig provides a fake implementation of GetFilenameForConfig.
+func (fm *FakeManager) GetFilenameForConfig(name string) string {
+	return path.Join(fm.confdPath, name+".conf")
+}
+
+// GetFilenameForStreamConfig provides a fake implementation of GetFilenameForStreamConfig.
+func (fm *FakeManager) GetFilenameForStreamConfig(name string) string {
+	return path.Join(fm.streamConfdPath, name+".conf")
+}
+
 // CreateDHParam provides a fake implementation of CreateDHParam.
 func (fm *FakeManager) CreateDHParam(content string) (string, error) {
 	glog.V(3).Infof("Writing dhparam file")
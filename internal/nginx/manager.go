@@ -62,6 +62,8 @@ type Manager interface {
 	DeleteAppProtectResourceFile(name string)
 	ClearAppProtectFolder(name string)
 	GetFilenameForSecret(name string) string
+	GetFilenameForConfig(name string) string
+	GetFilenameForStreamConfig(name string) string
 	CreateDHParam(content string) (string, error)
 	CreateOpenTracingTracerConfig(content string) error
 	Start(done chan error)
@@ -139,7 +141,7 @@ func (lm *LocalManager) CreateMainConfig(content []byte) {
 
 // CreateConfig creates a configuration file. If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateConfig(name string, content []byte) {
-	createConfig(lm.getFilenameForConfig(name), content)
+	createConfig(lm.GetFilenameForConfig(name), content)
 }
 
 func createConfig(filename string, content []byte) {
@@ -154,7 +156,7 @@ func createConfig(filename string, content []byte) {
 
 // DeleteConfig deletes the configuration file from the conf.d folder.
 func (lm *LocalManager) DeleteConfig(name string) {
-	deleteConfig(lm.getFilenameForConfig(name))
+	deleteConfig(lm.GetFilenameForConfig(name))
 }
 
 func deleteConfig(filename string) {
@@ -165,22 +167,24 @@ func deleteConfig(filename string) {
 	}
 }
 
-func (lm *LocalManager) getFilenameForConfig(name string) string {
+// GetFilenameForConfig constructs the filename for the configuration file in the conf.d folder.
+func (lm *LocalManager) GetFilenameForConfig(name string) string {
 	return path.Join(lm.confdPath, name+".conf")
 }
 
 // CreateStreamConfig creates a configuration file for stream module.
 // If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateStreamConfig(name string, content []byte) {
-	createConfig(lm.getFilenameForStreamConfig(name), content)
+	createConfig(lm.GetFilenameForStreamConfig(name), content)
 }
 
 // DeleteStreamConfig deletes the configuration file from the stream-conf.d folder.
 func (lm *LocalManager) DeleteStreamConfig(name string) {
-	deleteConfig(lm.getFilenameForStreamConfig(name))
+	deleteConfig(lm.GetFilenameForStreamConfig(name))
 }
 
-func (lm *LocalManager) getFilenameForStreamConfig(name string) string {
+// GetFilenameForStreamConfig constructs the filename for the configuration file in the stream-conf.d folder.
+func (lm *LocalManager) GetFilenameForStreamConfig(name string) string {
 	return path.Join(lm.streamConfdPath, name+".conf")
 }
 
@@ -266,7 +270,7 @@ func (lm *LocalManager) ClearAppProtectFolder(name string) {
 func (lm *LocalManager) Start(done chan error) {
 	glog.V(3).Info("Starting nginx")
 
-	binaryFilename := getBinaryFileName(lm.debug)
+	binaryFilename := GetBinaryFileName(lm.debug)
 	cmd := exec.Command(binaryFilename)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -293,7 +297,7 @@ func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
 
 	t1 := time.Now()
 
-	binaryFilename := getBinaryFileName(lm.debug)
+	binaryFilename := GetBinaryFileName(lm.debug)
 	if err := shellOut(fmt.Sprintf("%v -s %v", binaryFilename, "reload")); err != nil {
 		lm.metricsCollector.IncNginxReloadErrors()
 		return fmt.Errorf("nginx reload failed: %w", err)
@@ -315,7 +319,7 @@ func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
 func (lm *LocalManager) Quit() {
 	glog.V(3).Info("Quitting nginx")
 
-	binaryFilename := getBinaryFileName(lm.debug)
+	binaryFilename := GetBinaryFileName(lm.debug)
 	if err := shellOut(fmt.Sprintf("%v -s %v", binaryFilename, "quit")); err != nil {
 		glog.Fatalf("Failed to quit nginx: %v", err)
 	}
@@ -323,7 +327,7 @@ func (lm *LocalManager) Quit() {
 
 // Version returns NGINX version
 func (lm *LocalManager) Version() string {
-	binaryFilename := getBinaryFileName(lm.debug)
+	binaryFilename := GetBinaryFileName(lm.debug)
 	out, err := exec.Command(binaryFilename, "-v").CombinedOutput()
 	if err != nil {
 		glog.Fatalf("Failed to get nginx version: %v", err)
@@ -512,7 +516,8 @@ func (lm *LocalManager) AppProtectPluginQuit() {
 	}
 }
 
-func getBinaryFileName(debug bool) string {
+// GetBinaryFileName returns the path of the NGINX binary, depending on whether the debug binary is used.
+func GetBinaryFileName(debug bool) string {
 	if debug {
 		return nginxBinaryPathDebug
 	}
@@ -13,6 +13,17 @@ const (
 	StateInvalid = "Invalid"
 )
 
+const (
+	// JWTClaimOperatorEquals requires the claim to be equal to the value.
+	JWTClaimOperatorEquals = "Equals"
+	// JWTClaimOperatorIn requires the claim to be equal to one of the values.
+	JWTClaimOperatorIn = "In"
+	// JWTClaimOperatorContains requires the array claim to contain one of the values.
+	JWTClaimOperatorContains = "Contains"
+	// JWTClaimOperatorExists requires the claim to be present.
+	JWTClaimOperatorExists = "Exists"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:validation:Optional
@@ -41,8 +52,11 @@ type VirtualServerSpec struct {
 	Policies       []PolicyReference `json:"policies"`
 	Upstreams      []Upstream        `json:"upstreams"`
 	Routes         []Route           `json:"routes"`
+	Headers        *Headers          `json:"headers"`
 	HTTPSnippets   string            `json:"http-snippets"`
 	ServerSnippets string            `json:"server-snippets"`
+	// IgnoreDefaultPolicies opts the VirtualServer out of the default Policies.
+	IgnoreDefaultPolicies bool `json:"ignoreDefaultPolicies"`
 }
 
 // PolicyReference references a policy by name and an optional namespace.
@@ -163,10 +177,27 @@ type ActionReturn struct {
 type ActionProxy struct {
 	Upstream        string                `json:"upstream"`
 	RewritePath     string                `json:"rewritePath"`
+	Rewrites        []RewriteRule         `json:"rewrites"`
 	RequestHeaders  *ProxyRequestHeaders  `json:"requestHeaders"`
 	ResponseHeaders *ProxyResponseHeaders `json:"responseHeaders"`
 }
 
+// RewriteRule defines a rewrite of the request URI in an ActionProxy.
+type RewriteRule struct {
+	Match           string `json:"match"`
+	Replacement     string `json:"replacement"`
+	DropQueryString bool   `json:"dropQueryString"`
+}
+
+// Headers defines the headers manipulation for all routes of a VirtualServer or a VirtualServerRoute.
+// The headers of a VirtualServerRoute override the headers of the VirtualServer with the same name,
+// and the headers of an ActionProxy of a route override both.
+type Headers struct {
+	Set  []Header    `json:"set"`
+	Add  []AddHeader `json:"add"`
+	Hide []string    `json:"hide"`
+}
+
 // ProxyRequestHeaders defines the request headers manipulation in an ActionProxy.
 type ProxyRequestHeaders struct {
 	Pass *bool    `json:"pass"`
@@ -246,6 +277,7 @@ type VirtualServerStatus struct {
 	Reason            string             `json:"reason"`
 	Message           string             `json:"message"`
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	DefaultPolicies   []string           `json:"defaultPolicies,omitempty"`
 }
 
 // ExternalEndpoint defines the IP and ports used to connect to this resource.
@@ -290,6 +322,9 @@ type VirtualServerRouteSpec struct {
 	Host         string     `json:"host"`
 	Upstreams    []Upstream `json:"upstreams"`
 	Subroutes    []Route    `json:"subroutes"`
+	Headers      *Headers   `json:"headers"`
+	// IgnoreDefaultPolicies opts the VirtualServerRoute out of the default Policies.
+	IgnoreDefaultPolicies bool `json:"ignoreDefaultPolicies"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -314,6 +349,7 @@ type VirtualServerRouteStatus struct {
 	Message           string             `json:"message"`
 	ReferencedBy      string             `json:"referencedBy"`
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	DefaultPolicies   []string           `json:"defaultPolicies,omitempty"`
 }
 
 // +genclient
@@ -336,23 +372,34 @@ type Policy struct {
 
 // PolicyStatus is the status of the policy resource
 type PolicyStatus struct {
-	State   string `json:"state"`
-	Reason  string `json:"reason"`
-	Message string `json:"message"`
+	State        string               `json:"state"`
+	Reason       string               `json:"reason"`
+	Message      string               `json:"message"`
+	ReferencedBy []PolicyReferencedBy `json:"referencedBy,omitempty"`
+}
+
+// PolicyReferencedBy defines a resource that references the policy and the problems of that reference.
+type PolicyReferencedBy struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Problems  []string `json:"problems,omitempty"`
 }
 
 // PolicySpec is the spec of the Policy resource.
 // The spec includes multiple fields, where each field represents a different policy.
 // Only one policy (field) is allowed.
 type PolicySpec struct {
-	IngressClass  string         `json:"ingressClassName"`
-	AccessControl *AccessControl `json:"accessControl"`
-	RateLimit     *RateLimit     `json:"rateLimit"`
-	JWTAuth       *JWTAuth       `json:"jwt"`
-	IngressMTLS   *IngressMTLS   `json:"ingressMTLS"`
-	EgressMTLS    *EgressMTLS    `json:"egressMTLS"`
-	OIDC          *OIDC          `json:"oidc"`
-	WAF           *WAF           `json:"waf"`
+	IngressClass    string           `json:"ingressClassName"`
+	AccessControl   *AccessControl   `json:"accessControl"`
+	RateLimit       *RateLimit       `json:"rateLimit"`
+	JWTAuth         *JWTAuth         `json:"jwt"`
+	IngressMTLS     *IngressMTLS     `json:"ingressMTLS"`
+	EgressMTLS      *EgressMTLS      `json:"egressMTLS"`
+	OIDC            *OIDC            `json:"oidc"`
+	WAF             *WAF             `json:"waf"`
+	APIKey          *APIKey          `json:"apiKey"`
+	SecurityHeaders *SecurityHeaders `json:"securityHeaders"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -375,31 +422,64 @@ type AccessControl struct {
 // RateLimit defines a rate limit policy.
 // policy status: preview
 type RateLimit struct {
-	Rate       string `json:"rate"`
-	Key        string `json:"key"`
-	Delay      *int   `json:"delay"`
-	NoDelay    *bool  `json:"noDelay"`
-	Burst      *int   `json:"burst"`
-	ZoneSize   string `json:"zoneSize"`
-	DryRun     *bool  `json:"dryRun"`
-	LogLevel   string `json:"logLevel"`
-	RejectCode *int   `json:"rejectCode"`
+	Rate         string          `json:"rate"`
+	Key          string          `json:"key"`
+	Delay        *int            `json:"delay"`
+	NoDelay      *bool           `json:"noDelay"`
+	Burst        *int            `json:"burst"`
+	ZoneSize     string          `json:"zoneSize"`
+	DryRun       *bool           `json:"dryRun"`
+	LogLevel     string          `json:"logLevel"`
+	RejectCode   *int            `json:"rejectCode"`
+	TierSelector string          `json:"tierSelector"`
+	Tiers        []RateLimitTier `json:"tiers"`
+}
+
+// RateLimitTier defines the rate limit for the requests whose tier selector has one of the values of the tier.
+type RateLimitTier struct {
+	Name    string   `json:"name"`
+	Values  []string `json:"values"`
+	Default bool     `json:"default"`
+	Rate    string   `json:"rate"`
+	Burst   *int     `json:"burst"`
 }
 
 // JWTAuth holds JWT authentication configuration.
 // policy status: preview
 type JWTAuth struct {
-	Realm  string `json:"realm"`
-	Secret string `json:"secret"`
-	Token  string `json:"token"`
+	Realm             string                `json:"realm"`
+	Secret            string                `json:"secret"`
+	Token             string                `json:"token"`
+	JwksURI           string                `json:"jwksURI"`
+	KeyCache          string                `json:"keyCache"`
+	SNIEnabled        bool                  `json:"sniEnabled"`
+	SNIName           string                `json:"sniName"`
+	TrustedCertSecret string                `json:"trustedCertSecret"`
+	Claims            []JWTClaimRequirement `json:"claims"`
+}
+
+// JWTClaimRequirement defines a requirement for a claim of a JWT. A request with a JWT that doesn't meet the requirement is rejected.
+type JWTClaimRequirement struct {
+	Name     string   `json:"name"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
 }
 
 // IngressMTLS defines an Ingress MTLS policy.
 // policy status: preview
 type IngressMTLS struct {
-	ClientCertSecret string `json:"clientCertSecret"`
-	VerifyClient     string `json:"verifyClient"`
-	VerifyDepth      *int   `json:"verifyDepth"`
+	ClientCertSecret  string             `json:"clientCertSecret"`
+	VerifyClient      string             `json:"verifyClient"`
+	VerifyDepth       *int               `json:"verifyDepth"`
+	ClientCertHeaders *ClientCertHeaders `json:"clientCertHeaders"`
+}
+
+// ClientCertHeaders defines the names of the request headers that pass the details of the client certificate to the upstreams.
+type ClientCertHeaders struct {
+	SubjectDN   string `json:"subjectDN"`
+	Fingerprint string `json:"fingerprint"`
+	Serial      string `json:"serial"`
+	Cert        string `json:"cert"`
 }
 
 // EgressMTLS defines an Egress MTLS policy.
@@ -418,13 +498,62 @@ type EgressMTLS struct {
 
 // OIDC defines an Open ID Connect policy.
 type OIDC struct {
-	AuthEndpoint  string `json:"authEndpoint"`
-	TokenEndpoint string `json:"tokenEndpoint"`
-	JWKSURI       string `json:"jwksURI"`
-	ClientID      string `json:"clientID"`
-	ClientSecret  string `json:"clientSecret"`
-	Scope         string `json:"scope"`
-	RedirectURI   string `json:"redirectURI"`
+	Issuer                string             `json:"issuer"`
+	AuthEndpoint          string             `json:"authEndpoint"`
+	TokenEndpoint         string             `json:"tokenEndpoint"`
+	JWKSURI               string             `json:"jwksURI"`
+	ClientID              string             `json:"clientID"`
+	ClientSecret          string             `json:"clientSecret"`
+	Scope                 string             `json:"scope"`
+	RedirectURI           string             `json:"redirectURI"`
+	PKCE                  bool               `json:"pkce"`
+	EndSessionEndpoint    string             `json:"endSessionEndpoint"`
+	PostLogoutRedirectURI string             `json:"postLogoutRedirectURI"`
+	LogoutURI             string             `json:"logoutURI"`
+	SessionCookie         *OIDCSessionCookie `json:"sessionCookie"`
+}
+
+// OIDCSessionCookie defines the cookie that stores the session of an OIDC policy.
+type OIDCSessionCookie struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Domain   string `json:"domain"`
+	SameSite string `json:"sameSite"`
+}
+
+// APIKey defines an API key authentication policy.
+// policy status: preview
+type APIKey struct {
+	SuppliedIn   *SuppliedIn `json:"suppliedIn"`
+	ClientSecret string      `json:"clientSecret"`
+	RejectCode   *int        `json:"rejectCode"`
+}
+
+// SuppliedIn defines the request headers and query parameters that carry the API key.
+type SuppliedIn struct {
+	Header []string `json:"header"`
+	Query  []string `json:"query"`
+}
+
+// SecurityHeaders defines a policy that adds a preset of security headers to the responses.
+// A header can be overridden with a custom value or omitted with an empty value.
+// policy status: preview
+type SecurityHeaders struct {
+	HSTS                  *HSTS   `json:"hsts"`
+	FrameOptions          *string `json:"frameOptions"`
+	ContentTypeOptions    *string `json:"contentTypeOptions"`
+	ReferrerPolicy        *string `json:"referrerPolicy"`
+	ContentSecurityPolicy *string `json:"contentSecurityPolicy"`
+	PermissionsPolicy     *string `json:"permissionsPolicy"`
+}
+
+// HSTS defines the Strict-Transport-Security header of a SecurityHeaders policy.
+type HSTS struct {
+	Enable            *bool `json:"enable"`
+	MaxAge            *int  `json:"maxAge"`
+	IncludeSubdomains *bool `json:"includeSubdomains"`
+	Preload           *bool `json:"preload"`
+	BehindProxy       bool  `json:"behindProxy"`
 }
 
 // WAF defines an WAF policy.
@@ -32,6 +32,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GlobalConfigurationList{},
 		&TransportServer{},
 		&TransportServerList{},
+		&HostPolicy{},
+		&HostPolicyList{},
+		&ReferenceGrant{},
+		&ReferenceGrantList{},
+		&DefaultPolicy{},
+		&DefaultPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -229,3 +229,123 @@ type PolicyList struct {
 
 	Items []Policy `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:scope=Cluster,shortName=hp
+
+// HostPolicy defines the HostPolicy resource. It restricts the namespaces whose resources can claim the hosts.
+type HostPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostPolicySpec `json:"spec"`
+}
+
+// HostPolicySpec is the spec of the HostPolicy resource.
+type HostPolicySpec struct {
+	Rules []HostPolicyRule `json:"rules"`
+}
+
+// HostPolicyRule allows the resources from the namespaces to claim the hosts that match the host.
+// The host is either an exact host (cafe.example.com) or a wildcard host (*.example.com),
+// which matches all the subdomains of the domain.
+type HostPolicyRule struct {
+	Host       string   `json:"host"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostPolicyList is a list of the HostPolicy resources.
+type HostPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HostPolicy `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:shortName=rg
+
+// ReferenceGrant defines the ReferenceGrant resource. It allows the resources from other namespaces
+// to reference the resources in the namespace of the ReferenceGrant.
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReferenceGrantSpec `json:"spec"`
+}
+
+// ReferenceGrantSpec is the spec of the ReferenceGrant resource.
+type ReferenceGrantSpec struct {
+	From []ReferenceGrantFrom `json:"from"`
+	To   []ReferenceGrantTo   `json:"to"`
+}
+
+// ReferenceGrantFrom defines the kind and the namespace of the resources that are allowed to make references.
+type ReferenceGrantFrom struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo defines the kind and optionally the name of the resources that can be referenced.
+// If the name is empty, all the resources of the kind can be referenced.
+type ReferenceGrantTo struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReferenceGrantList is a list of the ReferenceGrant resources.
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ReferenceGrant `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:scope=Cluster,shortName=dpol
+
+// DefaultPolicy defines the DefaultPolicy resource. It applies the Policies to all the VirtualServers and VirtualServerRoutes
+// of the namespaces and of the ingress class, unless they opt out.
+type DefaultPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DefaultPolicySpec `json:"spec"`
+}
+
+// DefaultPolicySpec is the spec of the DefaultPolicy resource.
+// An empty IngressClass matches any ingress class, and empty Namespaces match all namespaces.
+type DefaultPolicySpec struct {
+	IngressClass string                   `json:"ingressClassName"`
+	Namespaces   []string                 `json:"namespaces"`
+	Policies     []DefaultPolicyReference `json:"policies"`
+}
+
+// DefaultPolicyReference references a Policy by name and an optional namespace.
+// If the namespace is not set, the Policy is referenced in the namespace of the resource the Policy is applied to.
+type DefaultPolicyReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DefaultPolicyList is a list of the DefaultPolicy resources.
+type DefaultPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DefaultPolicy `json:"items"`
+}
@@ -10,6 +10,14 @@ func createPointerFromInt(n int) *int {
 	return &n
 }
 
+func createPointerFromBool(b bool) *bool {
+	return &b
+}
+
+func createPointerFromString(s string) *string {
+	return &s
+}
+
 func TestValidateVariable(t *testing.T) {
 	validVars := map[string]bool{
 		"scheme":                 true,
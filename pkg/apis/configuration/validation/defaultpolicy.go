@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateDefaultPolicy validates a DefaultPolicy.
+func ValidateDefaultPolicy(defaultPolicy *v1alpha1.DefaultPolicy) error {
+	allErrs := validateDefaultPolicySpec(&defaultPolicy.Spec, field.NewPath("spec"))
+	return allErrs.ToAggregate()
+}
+
+func validateDefaultPolicySpec(spec *v1alpha1.DefaultPolicySpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.IngressClass != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.IngressClass) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("ingressClassName"), spec.IngressClass, msg))
+		}
+	}
+
+	// an empty list of namespaces means all namespaces
+	if len(spec.Namespaces) > 0 {
+		allErrs = append(allErrs, validateHostPolicyNamespaces(spec.Namespaces, fieldPath.Child("namespaces"))...)
+	}
+
+	allErrs = append(allErrs, validateDefaultPolicyReferences(spec.Policies, fieldPath.Child("policies"))...)
+
+	return allErrs
+}
+
+func validateDefaultPolicyReferences(policies []v1alpha1.DefaultPolicyReference, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(policies) == 0 {
+		return append(allErrs, field.Required(fieldPath, "must include at least one policy"))
+	}
+
+	policyKeys := sets.String{}
+
+	for i, p := range policies {
+		idxPath := fieldPath.Index(i)
+
+		// a reference without a namespace is resolved in the namespace of each resource, so it is only a duplicate of another such reference
+		key := fmt.Sprintf("%s/%s", p.Namespace, p.Name)
+
+		if policyKeys.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		} else {
+			policyKeys.Insert(key)
+		}
+
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(p.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), p.Name, msg))
+			}
+		}
+
+		if p.Namespace != "" {
+			for _, msg := range validation.IsDNS1123Label(p.Namespace) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("namespace"), p.Namespace, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+func TestValidateDefaultPolicy(t *testing.T) {
+	tests := []struct {
+		spec v1alpha1.DefaultPolicySpec
+		msg  string
+	}{
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name:      "waf-policy",
+						Namespace: "security",
+					},
+				},
+			},
+			msg: "all namespaces and ingress classes",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				IngressClass: "nginx",
+				Namespaces:   []string{"team-a", "team-b"},
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name: "rate-limit-policy",
+					},
+					{
+						Name:      "rate-limit-policy",
+						Namespace: "security",
+					},
+				},
+			},
+			msg: "namespaces, ingress class and the same policy name with and without namespace",
+		},
+	}
+
+	for _, test := range tests {
+		defaultPolicy := v1alpha1.DefaultPolicy{
+			Spec: test.spec,
+		}
+
+		err := ValidateDefaultPolicy(&defaultPolicy)
+		if err != nil {
+			t.Errorf("ValidateDefaultPolicy() returned error %v for valid input for the case of %s", err, test.msg)
+		}
+	}
+}
+
+func TestValidateDefaultPolicyFails(t *testing.T) {
+	tests := []struct {
+		spec v1alpha1.DefaultPolicySpec
+		msg  string
+	}{
+		{
+			spec: v1alpha1.DefaultPolicySpec{},
+			msg:  "no policies",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name:      "waf-policy",
+						Namespace: "security",
+					},
+					{
+						Name:      "waf-policy",
+						Namespace: "security",
+					},
+				},
+			},
+			msg: "duplicated policy",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Namespace: "security",
+					},
+				},
+			},
+			msg: "missing policy name",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name:      "waf-policy",
+						Namespace: "security.example",
+					},
+				},
+			},
+			msg: "invalid policy namespace",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				Namespaces: []string{"team-a", "team-a"},
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name: "waf-policy",
+					},
+				},
+			},
+			msg: "duplicated namespace",
+		},
+		{
+			spec: v1alpha1.DefaultPolicySpec{
+				IngressClass: "NGINX",
+				Policies: []v1alpha1.DefaultPolicyReference{
+					{
+						Name: "waf-policy",
+					},
+				},
+			},
+			msg: "invalid ingress class",
+		},
+	}
+
+	for _, test := range tests {
+		defaultPolicy := v1alpha1.DefaultPolicy{
+			Spec: test.spec,
+		}
+
+		err := ValidateDefaultPolicy(&defaultPolicy)
+		if err == nil {
+			t.Errorf("ValidateDefaultPolicy() returned no error for invalid input for the case of %s", test.msg)
+		}
+	}
+}
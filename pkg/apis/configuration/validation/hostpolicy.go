@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateHostPolicy validates a HostPolicy.
+func ValidateHostPolicy(hostPolicy *v1alpha1.HostPolicy) error {
+	allErrs := validateHostPolicySpec(&hostPolicy.Spec, field.NewPath("spec"))
+	return allErrs.ToAggregate()
+}
+
+func validateHostPolicySpec(spec *v1alpha1.HostPolicySpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	rulesPath := fieldPath.Child("rules")
+
+	if len(spec.Rules) == 0 {
+		return append(allErrs, field.Required(rulesPath, "must include at least one rule"))
+	}
+
+	hosts := sets.String{}
+
+	for i, r := range spec.Rules {
+		idxPath := rulesPath.Index(i)
+
+		ruleErrs := validateHostPolicyRule(r, idxPath)
+		if len(ruleErrs) > 0 {
+			allErrs = append(allErrs, ruleErrs...)
+		} else if hosts.Has(r.Host) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("host"), r.Host))
+		} else {
+			hosts.Insert(r.Host)
+		}
+	}
+
+	return allErrs
+}
+
+func validateHostPolicyRule(rule v1alpha1.HostPolicyRule, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateHostPolicyHost(rule.Host, fieldPath.Child("host"))...)
+	allErrs = append(allErrs, validateHostPolicyNamespaces(rule.Namespaces, fieldPath.Child("namespaces"))...)
+
+	return allErrs
+}
+
+// validateHostPolicyHost validates an exact host (cafe.example.com) or a wildcard host (*.example.com).
+func validateHostPolicyHost(host string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if host == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+
+	if strings.HasPrefix(host, "*.") {
+		for _, msg := range validation.IsWildcardDNS1123Subdomain(host) {
+			allErrs = append(allErrs, field.Invalid(fieldPath, host, msg))
+		}
+		return allErrs
+	}
+
+	return validateHost(host, fieldPath)
+}
+
+func validateHostPolicyNamespaces(namespaces []string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(namespaces) == 0 {
+		return append(allErrs, field.Required(fieldPath, "must include at least one namespace"))
+	}
+
+	unique := sets.String{}
+
+	for i, ns := range namespaces {
+		idxPath := fieldPath.Index(i)
+
+		nsErrs := field.ErrorList{}
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			nsErrs = append(nsErrs, field.Invalid(idxPath, ns, msg))
+		}
+
+		if len(nsErrs) > 0 {
+			allErrs = append(allErrs, nsErrs...)
+		} else if unique.Has(ns) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, ns))
+		} else {
+			unique.Insert(ns)
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateHostPolicy(t *testing.T) {
+	hostPolicy := v1alpha1.HostPolicy{
+		Spec: v1alpha1.HostPolicySpec{
+			Rules: []v1alpha1.HostPolicyRule{
+				{
+					Host:       "cafe.example.com",
+					Namespaces: []string{"cafe"},
+				},
+				{
+					Host:       "*.example.com",
+					Namespaces: []string{"team-a", "team-b"},
+				},
+			},
+		},
+	}
+
+	err := ValidateHostPolicy(&hostPolicy)
+	if err != nil {
+		t.Errorf("ValidateHostPolicy() returned error %v for valid input", err)
+	}
+}
+
+func TestValidateHostPolicyFails(t *testing.T) {
+	tests := []struct {
+		spec v1alpha1.HostPolicySpec
+		msg  string
+	}{
+		{
+			spec: v1alpha1.HostPolicySpec{},
+			msg:  "no rules",
+		},
+		{
+			spec: v1alpha1.HostPolicySpec{
+				Rules: []v1alpha1.HostPolicyRule{
+					{
+						Host:       "cafe.example.com",
+						Namespaces: []string{"cafe"},
+					},
+					{
+						Host:       "cafe.example.com",
+						Namespaces: []string{"tea"},
+					},
+				},
+			},
+			msg: "duplicated host",
+		},
+		{
+			spec: v1alpha1.HostPolicySpec{
+				Rules: []v1alpha1.HostPolicyRule{
+					{
+						Host:       "cafe.example.com",
+						Namespaces: []string{"cafe", "cafe"},
+					},
+				},
+			},
+			msg: "duplicated namespace",
+		},
+		{
+			spec: v1alpha1.HostPolicySpec{
+				Rules: []v1alpha1.HostPolicyRule{
+					{
+						Host: "cafe.example.com",
+					},
+				},
+			},
+			msg: "no namespaces",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateHostPolicy(&v1alpha1.HostPolicy{Spec: test.spec})
+		if err == nil {
+			t.Errorf("ValidateHostPolicy() returned no error for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateHostPolicyHost(t *testing.T) {
+	validHosts := []string{
+		"cafe.example.com",
+		"*.example.com",
+		"*.cafe.example.com",
+	}
+
+	for _, host := range validHosts {
+		allErrs := validateHostPolicyHost(host, field.NewPath("host"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateHostPolicyHost(%q) returned errors %v for valid input", host, allErrs)
+		}
+	}
+
+	invalidHosts := []string{
+		"",
+		"*",
+		"*.",
+		"cafe.*.com",
+		"*cafe.example.com",
+		"Cafe.example.com",
+	}
+
+	for _, host := range invalidHosts {
+		allErrs := validateHostPolicyHost(host, field.NewPath("host"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateHostPolicyHost(%q) returned no errors for invalid input", host)
+		}
+	}
+}
+
+func TestValidateHostPolicyNamespaces(t *testing.T) {
+	invalidNamespaces := [][]string{
+		nil,
+		{""},
+		{"Cafe"},
+		{"cafe.example"},
+		{"cafe", "cafe"},
+	}
+
+	for _, namespaces := range invalidNamespaces {
+		allErrs := validateHostPolicyNamespaces(namespaces, field.NewPath("namespaces"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateHostPolicyNamespaces(%v) returned no errors for invalid input", namespaces)
+		}
+	}
+}
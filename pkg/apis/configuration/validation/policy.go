@@ -83,6 +83,29 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 		fieldCount++
 	}
 
+	if spec.APIKey != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("apiKey"),
+				"apiKey is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+		if !isPlus {
+			return append(allErrs, field.Forbidden(fieldPath.Child("apiKey"), "API key authentication is only supported in NGINX Plus"))
+		}
+
+		allErrs = append(allErrs, validateAPIKey(spec.APIKey, fieldPath.Child("apiKey"))...)
+		fieldCount++
+	}
+
+	if spec.SecurityHeaders != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("securityHeaders"),
+				"securityHeaders is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateSecurityHeaders(spec.SecurityHeaders, fieldPath.Child("securityHeaders"))...)
+		fieldCount++
+	}
+
 	if spec.WAF != nil {
 		if !enablePreviewPolicies {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("waf"),
@@ -101,9 +124,9 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 	}
 
 	if fieldCount != 1 {
-		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`"
+		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `securityHeaders`"
 		if isPlus {
-			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `waf`")
+			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `apiKey`, `waf`")
 		}
 		allErrs = append(allErrs, field.Invalid(fieldPath, "", msg))
 	}
@@ -141,7 +164,17 @@ func validateRateLimit(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bo
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateRateLimitZoneSize(rateLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
-	allErrs = append(allErrs, validateRate(rateLimit.Rate, fieldPath.Child("rate"))...)
+
+	if len(rateLimit.Tiers) > 0 {
+		allErrs = append(allErrs, validateRateLimitTiers(rateLimit, fieldPath, isPlus)...)
+	} else {
+		allErrs = append(allErrs, validateRate(rateLimit.Rate, fieldPath.Child("rate"))...)
+
+		if rateLimit.TierSelector != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("tierSelector"), "can only be set with tiers"))
+		}
+	}
+
 	allErrs = append(allErrs, validateRateLimitKey(rateLimit.Key, fieldPath.Child("key"), isPlus)...)
 
 	if rateLimit.Delay != nil {
@@ -171,12 +204,170 @@ func validateJWT(jwt *v1.JWTAuth, fieldPath *field.Path) field.ErrorList {
 
 	allErrs = append(allErrs, validateJWTRealm(jwt.Realm, fieldPath.Child("realm"))...)
 
-	if jwt.Secret == "" {
-		return append(allErrs, field.Required(fieldPath.Child("secret"), ""))
+	if jwt.Secret == "" && jwt.JwksURI == "" {
+		return append(allErrs, field.Required(fieldPath.Child("secret"), "either secret or jwksURI must be set"))
+	}
+
+	if jwt.Secret != "" && jwt.JwksURI != "" {
+		return append(allErrs, field.Forbidden(fieldPath.Child("jwksURI"), "jwksURI cannot be set together with secret"))
+	}
+
+	if jwt.Secret != "" {
+		allErrs = append(allErrs, validateSecretName(jwt.Secret, fieldPath.Child("secret"))...)
+
+		if jwt.KeyCache != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("keyCache"), "can only be set with jwksURI"))
+		}
+		if jwt.SNIEnabled {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sniEnabled"), "can only be set with jwksURI"))
+		}
+		if jwt.SNIName != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sniName"), "can only be set with jwksURI"))
+		}
+		if jwt.TrustedCertSecret != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("trustedCertSecret"), "can only be set with jwksURI"))
+		}
+	} else {
+		allErrs = append(allErrs, validateJWKSURI(jwt, fieldPath)...)
 	}
-	allErrs = append(allErrs, validateSecretName(jwt.Secret, fieldPath.Child("secret"))...)
 
 	allErrs = append(allErrs, validateJWTToken(jwt.Token, fieldPath.Child("token"))...)
+	allErrs = append(allErrs, validateJWTClaimRequirements(jwt.Claims, fieldPath.Child("claims"))...)
+
+	return allErrs
+}
+
+var validJWTClaimOperators = []string{
+	v1.JWTClaimOperatorEquals,
+	v1.JWTClaimOperatorIn,
+	v1.JWTClaimOperatorContains,
+	v1.JWTClaimOperatorExists,
+}
+
+const (
+	jwtClaimNameFmt    = `[a-zA-Z0-9_]+`
+	jwtClaimNameErrMsg = "must consist of alphanumeric characters or '_'"
+)
+
+var jwtClaimNameRegexp = regexp.MustCompile("^" + jwtClaimNameFmt + "$")
+
+func validateJWTClaimRequirements(claims []v1.JWTClaimRequirement, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, c := range claims {
+		allErrs = append(allErrs, validateJWTClaimRequirement(c, fieldPath.Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateJWTClaimRequirement(claim v1.JWTClaimRequirement, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	namePath := fieldPath.Child("name")
+	if claim.Name == "" {
+		allErrs = append(allErrs, field.Required(namePath, ""))
+	} else if !jwtClaimNameRegexp.MatchString(claim.Name) {
+		msg := validation.RegexError(jwtClaimNameErrMsg, jwtClaimNameFmt, "groups", "aud", "custom_claim")
+		allErrs = append(allErrs, field.Invalid(namePath, claim.Name, msg))
+	}
+
+	valuesPath := fieldPath.Child("values")
+
+	switch claim.Operator {
+	case "":
+		return append(allErrs, field.Required(fieldPath.Child("operator"), ""))
+	case v1.JWTClaimOperatorExists:
+		if len(claim.Values) > 0 {
+			allErrs = append(allErrs, field.Forbidden(valuesPath, "cannot be set for the operator Exists"))
+		}
+		return allErrs
+	case v1.JWTClaimOperatorEquals:
+		if len(claim.Values) != 1 {
+			allErrs = append(allErrs, field.Invalid(valuesPath, claim.Values, "must include exactly one value for the operator Equals"))
+		}
+	case v1.JWTClaimOperatorIn, v1.JWTClaimOperatorContains:
+		if len(claim.Values) == 0 {
+			allErrs = append(allErrs, field.Required(valuesPath, fmt.Sprintf("must include at least one value for the operator %s", claim.Operator)))
+		}
+	default:
+		return append(allErrs, field.NotSupported(fieldPath.Child("operator"), claim.Operator, validJWTClaimOperators))
+	}
+
+	for i, v := range claim.Values {
+		idxPath := valuesPath.Index(i)
+
+		if v == "" {
+			allErrs = append(allErrs, field.Required(idxPath, ""))
+			continue
+		}
+
+		if strings.ContainsAny(v, "\"\\") {
+			allErrs = append(allErrs, field.Invalid(idxPath, v, `must not contain '"' or '\'`))
+		}
+
+		// the elements of an array claim are separated by commas in the claim variable
+		if claim.Operator == v1.JWTClaimOperatorContains && strings.Contains(v, ",") {
+			allErrs = append(allErrs, field.Invalid(idxPath, v, "must not contain ',' for the operator Contains"))
+		}
+	}
+
+	return allErrs
+}
+
+// jwksURIForbiddenChars are the characters that cannot appear in a JWKS URI, because the URI is used
+// in the NGINX configuration as is.
+const jwksURIForbiddenChars = " \t\n\r;{}$\"'\\#"
+
+func validateJWKSURI(jwt *v1.JWTAuth, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	uriPath := fieldPath.Child("jwksURI")
+
+	if strings.ContainsAny(jwt.JwksURI, jwksURIForbiddenChars) {
+		return append(allErrs, field.Invalid(uriPath, jwt.JwksURI, "must not contain whitespace, quotes or any of the following characters: ; { } $ \\ #"))
+	}
+
+	uriErrs := validateURL(jwt.JwksURI, uriPath)
+	if len(uriErrs) > 0 {
+		return append(allErrs, uriErrs...)
+	}
+
+	// validateURL ensures the URL can be parsed
+	u, _ := url.Parse(jwt.JwksURI)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return append(allErrs, field.Invalid(uriPath, jwt.JwksURI, "scheme must be http or https"))
+	}
+	if u.User != nil {
+		allErrs = append(allErrs, field.Invalid(uriPath, jwt.JwksURI, "must not include user information"))
+	}
+
+	if jwt.KeyCache == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("keyCache"), "must be set with jwksURI"))
+	} else {
+		allErrs = append(allErrs, validateTime(jwt.KeyCache, fieldPath.Child("keyCache"))...)
+	}
+
+	if jwt.SNIEnabled && u.Scheme != "https" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sniEnabled"), "requires a jwksURI with the https scheme"))
+	}
+
+	if jwt.SNIName != "" {
+		if !jwt.SNIEnabled {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sniName"), "requires sniEnabled"))
+		} else {
+			allErrs = append(allErrs, validateSSLName(jwt.SNIName, fieldPath.Child("sniName"))...)
+		}
+	}
+
+	// the certificate of an https jwksURI is always verified, by default against the system CA bundle
+	if jwt.TrustedCertSecret != "" {
+		if u.Scheme != "https" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("trustedCertSecret"), "requires a jwksURI with the https scheme"))
+		} else {
+			allErrs = append(allErrs, validateSecretName(jwt.TrustedCertSecret, fieldPath.Child("trustedCertSecret"))...)
+		}
+	}
 
 	return allErrs
 }
@@ -194,6 +385,44 @@ func validateIngressMTLS(ingressMTLS *v1.IngressMTLS, fieldPath *field.Path) fie
 	if ingressMTLS.VerifyDepth != nil {
 		allErrs = append(allErrs, validatePositiveIntOrZero(*ingressMTLS.VerifyDepth, fieldPath.Child("verifyDepth"))...)
 	}
+
+	if ingressMTLS.ClientCertHeaders != nil {
+		allErrs = append(allErrs, validateClientCertHeaders(ingressMTLS.ClientCertHeaders, fieldPath.Child("clientCertHeaders"))...)
+	}
+	return allErrs
+}
+
+func validateClientCertHeaders(headers *v1.ClientCertHeaders, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{name: "subjectDN", value: headers.SubjectDN},
+		{name: "fingerprint", value: headers.Fingerprint},
+		{name: "serial", value: headers.Serial},
+		{name: "cert", value: headers.Cert},
+	}
+
+	seen := make(map[string]bool)
+
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+
+		for _, msg := range validation.IsHTTPHeaderName(f.value) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child(f.name), f.value, msg))
+		}
+
+		header := strings.ToLower(f.value)
+		if seen[header] {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Child(f.name), f.value))
+		}
+		seen[header] = true
+	}
+
 	return allErrs
 }
 
@@ -219,14 +448,17 @@ func validateEgressMTLS(egressMTLS *v1.EgressMTLS, fieldPath *field.Path) field.
 func validateOIDC(oidc *v1.OIDC, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if oidc.AuthEndpoint == "" {
-		return append(allErrs, field.Required(fieldPath.Child("authEndpoint"), ""))
-	}
-	if oidc.TokenEndpoint == "" {
-		return append(allErrs, field.Required(fieldPath.Child("tokenEndpoint"), ""))
-	}
-	if oidc.JWKSURI == "" {
-		return append(allErrs, field.Required(fieldPath.Child("jwksURI"), ""))
+	// the endpoints are discovered from the issuer unless they are specified
+	if oidc.Issuer == "" {
+		if oidc.AuthEndpoint == "" {
+			return append(allErrs, field.Required(fieldPath.Child("authEndpoint"), "must be specified when issuer is not specified"))
+		}
+		if oidc.TokenEndpoint == "" {
+			return append(allErrs, field.Required(fieldPath.Child("tokenEndpoint"), "must be specified when issuer is not specified"))
+		}
+		if oidc.JWKSURI == "" {
+			return append(allErrs, field.Required(fieldPath.Child("jwksURI"), "must be specified when issuer is not specified"))
+		}
 	}
 	if oidc.ClientID == "" {
 		return append(allErrs, field.Required(fieldPath.Child("clientID"), ""))
@@ -243,15 +475,297 @@ func validateOIDC(oidc *v1.OIDC, fieldPath *field.Path) field.ErrorList {
 		allErrs = append(allErrs, validatePath(oidc.RedirectURI, fieldPath.Child("redirectURI"))...)
 	}
 
-	allErrs = append(allErrs, validateURL(oidc.AuthEndpoint, fieldPath.Child("authEndpoint"))...)
-	allErrs = append(allErrs, validateURL(oidc.TokenEndpoint, fieldPath.Child("tokenEndpoint"))...)
-	allErrs = append(allErrs, validateURL(oidc.JWKSURI, fieldPath.Child("jwksURI"))...)
+	if oidc.LogoutURI != "" {
+		allErrs = append(allErrs, validatePath(oidc.LogoutURI, fieldPath.Child("logoutURI"))...)
+	}
+
+	if generateOIDCPath(oidc.RedirectURI, "/_codexch") == generateOIDCPath(oidc.LogoutURI, "/logout") {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("logoutURI"), oidc.LogoutURI, "must be different from redirectURI"))
+	}
+
+	if oidc.EndSessionEndpoint != "" {
+		allErrs = append(allErrs, validateURL(oidc.EndSessionEndpoint, fieldPath.Child("endSessionEndpoint"))...)
+	}
+
+	if oidc.PostLogoutRedirectURI != "" {
+		allErrs = append(allErrs, validateOIDCPostLogoutRedirectURI(oidc.PostLogoutRedirectURI, fieldPath.Child("postLogoutRedirectURI"))...)
+	}
+
+	if oidc.SessionCookie != nil {
+		allErrs = append(allErrs, validateOIDCSessionCookie(oidc.SessionCookie, fieldPath.Child("sessionCookie"))...)
+	}
+
+	if oidc.Issuer != "" {
+		allErrs = append(allErrs, validateOIDCIssuer(oidc.Issuer, fieldPath.Child("issuer"))...)
+	}
+
+	if oidc.AuthEndpoint != "" {
+		allErrs = append(allErrs, validateURL(oidc.AuthEndpoint, fieldPath.Child("authEndpoint"))...)
+	}
+	if oidc.TokenEndpoint != "" {
+		allErrs = append(allErrs, validateURL(oidc.TokenEndpoint, fieldPath.Child("tokenEndpoint"))...)
+	}
+	if oidc.JWKSURI != "" {
+		allErrs = append(allErrs, validateURL(oidc.JWKSURI, fieldPath.Child("jwksURI"))...)
+	}
+
 	allErrs = append(allErrs, validateSecretName(oidc.ClientSecret, fieldPath.Child("clientSecret"))...)
 	allErrs = append(allErrs, validateClientID(oidc.ClientID, fieldPath.Child("clientID"))...)
 
 	return allErrs
 }
 
+func validateAPIKey(apiKey *v1.APIKey, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if apiKey.SuppliedIn == nil || (len(apiKey.SuppliedIn.Header) == 0 && len(apiKey.SuppliedIn.Query) == 0) {
+		return append(allErrs, field.Required(fieldPath.Child("suppliedIn"), "must specify at least one header or query parameter"))
+	}
+	if apiKey.ClientSecret == "" {
+		return append(allErrs, field.Required(fieldPath.Child("clientSecret"), ""))
+	}
+
+	allErrs = append(allErrs, validateAPIKeySuppliedIn(apiKey.SuppliedIn, fieldPath.Child("suppliedIn"))...)
+	allErrs = append(allErrs, validateSecretName(apiKey.ClientSecret, fieldPath.Child("clientSecret"))...)
+
+	if apiKey.RejectCode != nil {
+		if *apiKey.RejectCode < 400 || *apiKey.RejectCode > 599 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("rejectCode"), apiKey.RejectCode,
+				"must be within the range [400-599]"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAPIKeySuppliedIn(suppliedIn *v1.SuppliedIn, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	headers := make(map[string]bool)
+
+	for i, header := range suppliedIn.Header {
+		idxPath := fieldPath.Child("header").Index(i)
+
+		// the header is read from the $http_ variable, where '-' is replaced with '_'
+		for _, msg := range isValidSpecialHeaderLikeVariable(strings.ReplaceAll(header, "-", "_")) {
+			allErrs = append(allErrs, field.Invalid(idxPath, header, msg))
+		}
+
+		if headers[strings.ToLower(header)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, header))
+		}
+		headers[strings.ToLower(header)] = true
+	}
+
+	queries := make(map[string]bool)
+
+	for i, query := range suppliedIn.Query {
+		idxPath := fieldPath.Child("query").Index(i)
+
+		for _, msg := range isArgumentName(query) {
+			allErrs = append(allErrs, field.Invalid(idxPath, query, msg))
+		}
+
+		if queries[query] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, query))
+		}
+		queries[query] = true
+	}
+
+	return allErrs
+}
+
+// hstsPreloadMinMaxAge is the minimum max-age required for the inclusion in the HSTS preload list.
+// https://hstspreload.org/#submission-requirements
+const hstsPreloadMinMaxAge = 31536000
+
+var validFrameOptions = map[string]bool{
+	"DENY":       true,
+	"SAMEORIGIN": true,
+}
+
+var validContentTypeOptions = map[string]bool{
+	"nosniff": true,
+}
+
+var validReferrerPolicies = map[string]bool{
+	"no-referrer":                     true,
+	"no-referrer-when-downgrade":      true,
+	"origin":                          true,
+	"origin-when-cross-origin":        true,
+	"same-origin":                     true,
+	"strict-origin":                   true,
+	"strict-origin-when-cross-origin": true,
+	"unsafe-url":                      true,
+}
+
+const (
+	securityHeaderValueFmt    = `([^"$\\]|\\[^$])*`
+	securityHeaderValueErrMsg = `a valid header value must have all '"' escaped and must not contain any '$' or end with an unescaped '\'`
+)
+
+var securityHeaderValueFmtRegexp = regexp.MustCompile("^" + securityHeaderValueFmt + "$")
+
+func validateSecurityHeaders(securityHeaders *v1.SecurityHeaders, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if securityHeaders.HSTS != nil {
+		allErrs = append(allErrs, validateHSTS(securityHeaders.HSTS, fieldPath.Child("hsts"))...)
+	}
+
+	if securityHeaders.FrameOptions != nil && *securityHeaders.FrameOptions != "" && !validFrameOptions[*securityHeaders.FrameOptions] {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("frameOptions"), *securityHeaders.FrameOptions,
+			fmt.Sprintf("Accepted values: %s", mapToPrettyString(validFrameOptions))))
+	}
+
+	if securityHeaders.ContentTypeOptions != nil && *securityHeaders.ContentTypeOptions != "" &&
+		!validContentTypeOptions[*securityHeaders.ContentTypeOptions] {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("contentTypeOptions"), *securityHeaders.ContentTypeOptions,
+			fmt.Sprintf("Accepted values: %s", mapToPrettyString(validContentTypeOptions))))
+	}
+
+	if securityHeaders.ReferrerPolicy != nil && *securityHeaders.ReferrerPolicy != "" {
+		// a list of policies is allowed for fallback to the policies unsupported by a browser
+		for _, policy := range strings.Split(*securityHeaders.ReferrerPolicy, ",") {
+			policy = strings.TrimSpace(policy)
+			if !validReferrerPolicies[policy] {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("referrerPolicy"), policy,
+					fmt.Sprintf("Accepted values: %s", mapToPrettyString(validReferrerPolicies))))
+			}
+		}
+	}
+
+	if securityHeaders.ContentSecurityPolicy != nil {
+		allErrs = append(allErrs, validateSecurityHeaderValue(*securityHeaders.ContentSecurityPolicy, fieldPath.Child("contentSecurityPolicy"))...)
+	}
+
+	if securityHeaders.PermissionsPolicy != nil {
+		allErrs = append(allErrs, validateSecurityHeaderValue(*securityHeaders.PermissionsPolicy, fieldPath.Child("permissionsPolicy"))...)
+	}
+
+	return allErrs
+}
+
+func validateHSTS(hsts *v1.HSTS, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if hsts.Enable != nil && !*hsts.Enable {
+		return allErrs
+	}
+
+	if hsts.MaxAge != nil && *hsts.MaxAge < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxAge"), *hsts.MaxAge, "must be non-negative"))
+	}
+
+	if hsts.Preload != nil && !*hsts.Preload {
+		return allErrs
+	}
+
+	if hsts.MaxAge != nil && *hsts.MaxAge < hstsPreloadMinMaxAge {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxAge"), *hsts.MaxAge,
+			fmt.Sprintf("must be at least %d (1 year) when preload is enabled", hstsPreloadMinMaxAge)))
+	}
+
+	if hsts.IncludeSubdomains != nil && !*hsts.IncludeSubdomains {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("includeSubdomains"), *hsts.IncludeSubdomains,
+			"must be enabled when preload is enabled"))
+	}
+
+	return allErrs
+}
+
+func validateSecurityHeaderValue(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !securityHeaderValueFmtRegexp.MatchString(value) {
+		msg := validation.RegexError(securityHeaderValueErrMsg, securityHeaderValueFmt, "default-src 'self'", `geolocation=(self \"https://example.com\")`)
+		allErrs = append(allErrs, field.Invalid(fieldPath, value, msg))
+	}
+
+	return allErrs
+}
+
+// validateOIDCIssuer validates the issuer URL, which the discovery document is fetched from.
+// https://openid.net/specs/openid-connect-discovery-1_0.html#IssuerDiscovery
+func validateOIDCIssuer(issuer string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, issuer, err.Error()))
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return append(allErrs, field.Invalid(fieldPath, issuer, "scheme must be http or https"))
+	}
+	if u.Host == "" {
+		return append(allErrs, field.Invalid(fieldPath, issuer, "hostname required"))
+	}
+	if u.User != nil {
+		return append(allErrs, field.Invalid(fieldPath, issuer, "user info is not allowed"))
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return append(allErrs, field.Invalid(fieldPath, issuer, "query and fragment are not allowed"))
+	}
+
+	return allErrs
+}
+
+func generateOIDCPath(path string, defaultPath string) string {
+	if path == "" {
+		return defaultPath
+	}
+	return path
+}
+
+// validateOIDCPostLogoutRedirectURI validates the post logout redirect URI, which is either a path on the same host
+// or an absolute URL.
+func validateOIDCPostLogoutRedirectURI(uri string, fieldPath *field.Path) field.ErrorList {
+	if strings.HasPrefix(uri, "/") {
+		return validatePath(uri, fieldPath)
+	}
+	return validateURL(uri, fieldPath)
+}
+
+const (
+	oidcCookieNameFmt    = `[a-zA-Z0-9_]+`
+	oidcCookieNameErrMsg = "must consist of alphanumeric characters or '_'"
+)
+
+var oidcCookieNameRegexp = regexp.MustCompile("^" + oidcCookieNameFmt + "$")
+
+var validOIDCCookieSameSiteValues = map[string]bool{
+	"Strict": true,
+	"Lax":    true,
+	"None":   true,
+}
+
+func validateOIDCSessionCookie(cookie *v1.OIDCSessionCookie, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// the name is used in the name of the NGINX variable $cookie_<name>
+	if cookie.Name != "" && !oidcCookieNameRegexp.MatchString(cookie.Name) {
+		msg := validation.RegexError(oidcCookieNameErrMsg, oidcCookieNameFmt, "auth_token", "session_1")
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), cookie.Name, msg))
+	}
+
+	if cookie.Path != "" {
+		allErrs = append(allErrs, validatePath(cookie.Path, fieldPath.Child("path"))...)
+	}
+
+	if cookie.Domain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(cookie.Domain) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("domain"), cookie.Domain, msg))
+		}
+	}
+
+	if cookie.SameSite != "" {
+		allErrs = append(allErrs, validateParameter(cookie.SameSite, validOIDCCookieSameSiteValues, fieldPath.Child("sameSite"))...)
+	}
+
+	return allErrs
+}
+
 func validateWAF(waf *v1.WAF, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -439,7 +953,7 @@ func validateRateLimitZoneSize(zoneSize string, fieldPath *field.Path) field.Err
 	return allErrs
 }
 
-var rateLimitKeySpecialVariables = []string{"arg_", "http_", "cookie_"}
+var rateLimitKeySpecialVariables = []string{"arg_", "http_", "cookie_", "jwt_claim_"}
 
 // rateLimitKeyVariables includes NGINX variables allowed to be used in a rateLimit policy key.
 var rateLimitKeyVariables = map[string]bool{
@@ -447,6 +961,7 @@ var rateLimitKeyVariables = map[string]bool{
 	"request_uri":        true,
 	"uri":                true,
 	"args":               true,
+	"apikey_client_id":   true,
 }
 
 func validateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.ErrorList {
@@ -466,6 +981,107 @@ func validateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.
 	return allErrs
 }
 
+func validateRateLimitTiers(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit.Rate != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rate"), "cannot be set together with tiers"))
+	}
+
+	// the tier selector allows the same variables as the key
+	allErrs = append(allErrs, validateRateLimitKey(rateLimit.TierSelector, fieldPath.Child("tierSelector"), isPlus)...)
+
+	names := make(map[string]bool)
+	values := make(map[string]bool)
+	hasDefault := false
+
+	for i, t := range rateLimit.Tiers {
+		idxPath := fieldPath.Child("tiers").Index(i)
+
+		allErrs = append(allErrs, validateRateLimitTier(t, idxPath)...)
+
+		if names[t.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), t.Name))
+		}
+		names[t.Name] = true
+
+		// a value in multiple tiers would apply the rate limits of all those tiers
+		for j, v := range t.Values {
+			if values[v] {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("values").Index(j), v))
+			}
+			values[v] = true
+		}
+
+		if t.Default {
+			if hasDefault {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("default"), "only one tier can be the default tier"))
+			}
+			hasDefault = true
+		}
+	}
+
+	return allErrs
+}
+
+func validateRateLimitTier(tier v1.RateLimitTier, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tier.Name == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(tier.Name) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), tier.Name, msg))
+		}
+	}
+
+	if len(tier.Values) == 0 && !tier.Default {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("values"), "must be specified unless the tier is the default tier"))
+	}
+
+	for i, v := range tier.Values {
+		allErrs = append(allErrs, validateRateLimitTierValue(v, fieldPath.Child("values").Index(i))...)
+	}
+
+	allErrs = append(allErrs, validateRate(tier.Rate, fieldPath.Child("rate"))...)
+
+	if tier.Burst != nil {
+		allErrs = append(allErrs, validatePositiveInt(*tier.Burst, fieldPath.Child("burst"))...)
+	}
+
+	return allErrs
+}
+
+const (
+	rateLimitTierValueFmt    = `[a-zA-Z0-9][a-zA-Z0-9_.:@+/=-]*`
+	rateLimitTierValueErrMsg = "must start with an alphanumeric character and consist of alphanumeric characters or '_', '.', ':', '@', '+', '/', '=', '-'"
+)
+
+var rateLimitTierValueRegexp = regexp.MustCompile("^" + rateLimitTierValueFmt + "$")
+
+// rateLimitTierReservedValues are the special parameters of the NGINX map directive.
+var rateLimitTierReservedValues = map[string]bool{
+	"default":   true,
+	"hostnames": true,
+	"include":   true,
+	"volatile":  true,
+}
+
+func validateRateLimitTierValue(value string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !rateLimitTierValueRegexp.MatchString(value) {
+		msg := validation.RegexError(rateLimitTierValueErrMsg, rateLimitTierValueFmt, "premium", "enterprise-2023")
+		return append(allErrs, field.Invalid(fieldPath, value, msg))
+	}
+
+	if rateLimitTierReservedValues[value] {
+		allErrs = append(allErrs, field.Invalid(fieldPath, value, "is a reserved value"))
+	}
+
+	return allErrs
+}
+
 var jwtTokenSpecialVariables = []string{"arg_", "http_", "cookie_"}
 
 func validateJWTToken(token string, fieldPath *field.Path) field.ErrorList {
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// referenceGrantFromKinds defines the kinds of the resources that can reference resources in other namespaces.
+var referenceGrantFromKinds = map[string]bool{
+	"VirtualServer":      true,
+	"VirtualServerRoute": true,
+}
+
+// referenceGrantToKinds defines the kinds of the resources that can be referenced from other namespaces.
+var referenceGrantToKinds = map[string]bool{
+	"VirtualServerRoute": true,
+	"Policy":             true,
+	"Secret":             true,
+}
+
+// ValidateReferenceGrant validates a ReferenceGrant.
+func ValidateReferenceGrant(referenceGrant *v1alpha1.ReferenceGrant) error {
+	allErrs := validateReferenceGrantSpec(&referenceGrant.Spec, field.NewPath("spec"))
+	return allErrs.ToAggregate()
+}
+
+func validateReferenceGrantSpec(spec *v1alpha1.ReferenceGrantSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fromPath := fieldPath.Child("from")
+	if len(spec.From) == 0 {
+		allErrs = append(allErrs, field.Required(fromPath, "must include at least one element"))
+	}
+	for i, f := range spec.From {
+		allErrs = append(allErrs, validateReferenceGrantFrom(f, fromPath.Index(i))...)
+	}
+
+	toPath := fieldPath.Child("to")
+	if len(spec.To) == 0 {
+		allErrs = append(allErrs, field.Required(toPath, "must include at least one element"))
+	}
+	for i, t := range spec.To {
+		allErrs = append(allErrs, validateReferenceGrantTo(t, toPath.Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateReferenceGrantFrom(from v1alpha1.ReferenceGrantFrom, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateReferenceGrantKind(from.Kind, referenceGrantFromKinds, fieldPath.Child("kind"))
+
+	namespacePath := fieldPath.Child("namespace")
+	if from.Namespace == "" {
+		return append(allErrs, field.Required(namespacePath, ""))
+	}
+	for _, msg := range validation.IsDNS1123Label(from.Namespace) {
+		allErrs = append(allErrs, field.Invalid(namespacePath, from.Namespace, msg))
+	}
+
+	return allErrs
+}
+
+func validateReferenceGrantTo(to v1alpha1.ReferenceGrantTo, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateReferenceGrantKind(to.Kind, referenceGrantToKinds, fieldPath.Child("kind"))
+
+	if to.Name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(to.Name) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("name"), to.Name, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateReferenceGrantKind(kind string, validKinds map[string]bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if kind == "" {
+		msg := fmt.Sprintf("must specify kind. Accepted values: %s", mapToPrettyString(validKinds))
+		return append(allErrs, field.Required(fieldPath, msg))
+	}
+
+	if !validKinds[kind] {
+		msg := fmt.Sprintf("invalid kind. Accepted values: %s", mapToPrettyString(validKinds))
+		allErrs = append(allErrs, field.Invalid(fieldPath, kind, msg))
+	}
+
+	return allErrs
+}
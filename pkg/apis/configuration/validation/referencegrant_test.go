@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+func TestValidateReferenceGrant(t *testing.T) {
+	referenceGrant := v1alpha1.ReferenceGrant{
+		Spec: v1alpha1.ReferenceGrantSpec{
+			From: []v1alpha1.ReferenceGrantFrom{
+				{
+					Kind:      "VirtualServer",
+					Namespace: "cafe",
+				},
+				{
+					Kind:      "VirtualServerRoute",
+					Namespace: "tea",
+				},
+			},
+			To: []v1alpha1.ReferenceGrantTo{
+				{
+					Kind: "Policy",
+					Name: "rate-limit",
+				},
+				{
+					Kind: "VirtualServerRoute",
+				},
+				{
+					Kind: "Secret",
+				},
+			},
+		},
+	}
+
+	err := ValidateReferenceGrant(&referenceGrant)
+	if err != nil {
+		t.Errorf("ValidateReferenceGrant() returned error %v for valid input", err)
+	}
+}
+
+func TestValidateReferenceGrantFails(t *testing.T) {
+	validFrom := []v1alpha1.ReferenceGrantFrom{
+		{
+			Kind:      "VirtualServer",
+			Namespace: "cafe",
+		},
+	}
+	validTo := []v1alpha1.ReferenceGrantTo{
+		{
+			Kind: "Policy",
+		},
+	}
+
+	tests := []struct {
+		spec v1alpha1.ReferenceGrantSpec
+		msg  string
+	}{
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				To: validTo,
+			},
+			msg: "no from",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: validFrom,
+			},
+			msg: "no to",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: []v1alpha1.ReferenceGrantFrom{
+					{
+						Kind:      "Ingress",
+						Namespace: "cafe",
+					},
+				},
+				To: validTo,
+			},
+			msg: "invalid from kind",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: []v1alpha1.ReferenceGrantFrom{
+					{
+						Kind: "VirtualServer",
+					},
+				},
+				To: validTo,
+			},
+			msg: "no from namespace",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: []v1alpha1.ReferenceGrantFrom{
+					{
+						Kind:      "VirtualServer",
+						Namespace: "Cafe",
+					},
+				},
+				To: validTo,
+			},
+			msg: "invalid from namespace",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: validFrom,
+				To: []v1alpha1.ReferenceGrantTo{
+					{
+						Kind: "VirtualServer",
+					},
+				},
+			},
+			msg: "invalid to kind",
+		},
+		{
+			spec: v1alpha1.ReferenceGrantSpec{
+				From: validFrom,
+				To: []v1alpha1.ReferenceGrantTo{
+					{
+						Kind: "Secret",
+						Name: "-secret",
+					},
+				},
+			},
+			msg: "invalid to name",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateReferenceGrant(&v1alpha1.ReferenceGrant{Spec: test.spec})
+		if err == nil {
+			t.Errorf("ValidateReferenceGrant() returned no error for the case of %s", test.msg)
+		}
+	}
+}
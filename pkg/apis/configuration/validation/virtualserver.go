@@ -43,6 +43,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerSpec(spec *v1.VirtualSer
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRoutes(spec.Routes, fieldPath.Child("routes"), upstreamNames, namespace)...)
+	allErrs = append(allErrs, vsv.validateHeaders(spec.Headers, fieldPath.Child("headers"))...)
 
 	return allErrs
 }
@@ -895,6 +896,11 @@ func (vsv *VirtualServerValidator) validateActionProxy(p *v1.ActionProxy, fieldP
 	allErrs = append(allErrs, vsv.validateActionProxyRequestHeaders(p.RequestHeaders, fieldPath.Child("requestHeaders"))...)
 	allErrs = append(allErrs, vsv.validateActionProxyResponseHeaders(p.ResponseHeaders, fieldPath.Child("responseHeaders"))...)
 
+	if len(p.Rewrites) > 0 && p.RewritePath != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("rewrites"), "cannot be used together with rewritePath"))
+	}
+	allErrs = append(allErrs, validateActionProxyRewrites(p.Rewrites, fieldPath.Child("rewrites"))...)
+
 	if strings.HasPrefix(path, "~") || internal {
 		allErrs = append(allErrs, validateActionProxyRewritePathForRegexp(p.RewritePath, fieldPath.Child("rewritePath"))...)
 	} else {
@@ -948,6 +954,65 @@ func validateActionProxyRewritePathForRegexp(rewritePath string, fieldPath *fiel
 	return allErrs
 }
 
+var rewriteCaptureGroupRegexp = regexp.MustCompile(`\$(\d)`)
+
+func validateActionProxyRewrites(rewrites []v1.RewriteRule, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, r := range rewrites {
+		allErrs = append(allErrs, validateRewriteRule(r, fieldPath.Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateRewriteRule(r v1.RewriteRule, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	matchPath := fieldPath.Child("match")
+	replacementPath := fieldPath.Child("replacement")
+
+	captureGroups := -1
+
+	if r.Match == "" {
+		allErrs = append(allErrs, field.Required(matchPath, ""))
+	} else if re, err := regexp.Compile(r.Match); err != nil {
+		allErrs = append(allErrs, field.Invalid(matchPath, r.Match, fmt.Sprintf("must be a valid regular expression: %v", err)))
+	} else if !escapedStringsFmtRegexp.MatchString(r.Match) {
+		msg := validation.RegexError(escapedStringsErrMsg, escapedStringsFmt, "^/api/v1/(.*)$", `^/images/(.*)\.png$`)
+		allErrs = append(allErrs, field.Invalid(matchPath, r.Match, msg))
+	} else {
+		captureGroups = re.NumSubexp()
+	}
+
+	if r.Replacement == "" {
+		return append(allErrs, field.Required(replacementPath, ""))
+	}
+
+	// a replacement that doesn't start with / or a capture group, like http://example.com, makes NGINX send a redirect
+	if !strings.HasPrefix(r.Replacement, "/") && !strings.HasPrefix(r.Replacement, "$") {
+		allErrs = append(allErrs, field.Invalid(replacementPath, r.Replacement, "must start with / or a capture group"))
+	}
+
+	allErrs = append(allErrs, validateStringNoVariables(r.Replacement, replacementPath)...)
+
+	if !escapedStringsFmtRegexp.MatchString(r.Replacement) {
+		msg := validation.RegexError(escapedStringsErrMsg, escapedStringsFmt, "/$1", "/images/$1.png?size=small")
+		allErrs = append(allErrs, field.Invalid(replacementPath, r.Replacement, msg))
+	}
+
+	if captureGroups >= 0 {
+		for _, m := range rewriteCaptureGroupRegexp.FindAllStringSubmatch(r.Replacement, -1) {
+			if n, _ := strconv.Atoi(m[1]); n > captureGroups {
+				allErrs = append(allErrs, field.Invalid(replacementPath, r.Replacement, fmt.Sprintf("capture group $%d doesn't exist in match", n)))
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
 var actionProxyHeaderVariables = map[string]bool{
 	"request_uri":             true,
 	"request_method":          true,
@@ -1056,6 +1121,30 @@ func (vsv *VirtualServerValidator) validateActionProxyResponseHeaders(responseHe
 	return allErrs
 }
 
+func (vsv *VirtualServerValidator) validateHeaders(headers *v1.Headers, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if headers == nil {
+		return allErrs
+	}
+
+	for i, header := range headers.Set {
+		allErrs = append(allErrs, vsv.validateActionProxyHeader(header, fieldPath.Child("set").Index(i))...)
+	}
+
+	for i, header := range headers.Add {
+		allErrs = append(allErrs, vsv.validateActionProxyHeader(header.Header, fieldPath.Child("add").Index(i))...)
+	}
+
+	for i, header := range headers.Hide {
+		for _, msg := range validation.IsHTTPHeaderName(header) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("hide").Index(i), header, msg))
+		}
+	}
+
+	return allErrs
+}
+
 var validIgnoreHeaders = map[string]bool{
 	"X-Accel-Redirect":   true,
 	"X-Accel-Expires":    true,
@@ -1335,6 +1424,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSpec(spec *v1.Virtu
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRouteSubroutes(spec.Subroutes, fieldPath.Child("subroutes"), upstreamNames, vsPath, namespace)...)
+	allErrs = append(allErrs, vsv.validateHeaders(spec.Headers, fieldPath.Child("headers"))...)
 
 	return allErrs
 }
@@ -3046,6 +3046,144 @@ func TestValidateActionProxyRewritePathForRegexpFails(t *testing.T) {
 	}
 }
 
+func TestValidateActionProxyWithRewrites(t *testing.T) {
+	upstreamNames := map[string]sets.Empty{
+		"upstream1": {},
+	}
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	tests := []struct {
+		path        string
+		actionProxy *v1.ActionProxy
+		valid       bool
+		msg         string
+	}{
+		{
+			path: "/api",
+			actionProxy: &v1.ActionProxy{
+				Upstream: "upstream1",
+				Rewrites: []v1.RewriteRule{
+					{Match: "^/api/v[0-9]+/(.*)$", Replacement: "/$1"},
+				},
+			},
+			valid: true,
+			msg:   "rewrites for a prefix route",
+		},
+		{
+			path: "~ ^/api/v[0-9]+",
+			actionProxy: &v1.ActionProxy{
+				Upstream: "upstream1",
+				Rewrites: []v1.RewriteRule{
+					{Match: "^/api/v[0-9]+(/.*)$", Replacement: "$1"},
+				},
+			},
+			valid: true,
+			msg:   "rewrites for a regex route",
+		},
+		{
+			path: "/api",
+			actionProxy: &v1.ActionProxy{
+				Upstream:    "upstream1",
+				RewritePath: "/",
+				Rewrites: []v1.RewriteRule{
+					{Match: "^/api/(.*)$", Replacement: "/$1"},
+				},
+			},
+			valid: false,
+			msg:   "rewrites with rewritePath",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := vsv.validateActionProxy(test.actionProxy, field.NewPath("proxy"), upstreamNames, test.path, false)
+		if test.valid && len(allErrs) != 0 {
+			t.Errorf("validateActionProxy() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+		if !test.valid && len(allErrs) == 0 {
+			t.Errorf("validateActionProxy() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRewriteRule(t *testing.T) {
+	tests := []v1.RewriteRule{
+		{Match: "^/api/v1/(.*)$", Replacement: "/$1"},
+		{Match: "^/(users|orders)/([0-9]+)$", Replacement: "/$1?id=$2", DropQueryString: true},
+		{Match: `^/images/(.*)\.png$`, Replacement: "$1.png"},
+		{Match: `^/old/[a-z]{3}$`, Replacement: "/new"},
+		{Match: `^/(.*)$`, Replacement: `/\"$1\"`},
+	}
+	for _, test := range tests {
+		allErrs := validateRewriteRule(test, field.NewPath("rewrites").Index(0))
+		if len(allErrs) != 0 {
+			t.Errorf("validateRewriteRule(%+v) returned errors for valid input: %v", test, allErrs)
+		}
+	}
+}
+
+func TestValidateRewriteRuleFails(t *testing.T) {
+	tests := []struct {
+		rule           v1.RewriteRule
+		expectedErrors []string
+	}{
+		{
+			rule: v1.RewriteRule{},
+			expectedErrors: []string{
+				"rewrites[0].match: Required value",
+				"rewrites[0].replacement: Required value",
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: "^/api/(.*", Replacement: "/$1"},
+			expectedErrors: []string{
+				"rewrites[0].match: Invalid value: \"^/api/(.*\": must be a valid regular expression: error parsing regexp: missing closing ): `^/api/(.*`",
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: `^/"api"/(.*)$`, Replacement: "/$1"},
+			expectedErrors: []string{
+				`rewrites[0].match: Invalid value: "^/\"api\"/(.*)$": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. '^/api/v1/(.*)$',  or '^/images/(.*)\.png$', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: "^/api/(.*)$", Replacement: "/$2"},
+			expectedErrors: []string{
+				`rewrites[0].replacement: Invalid value: "/$2": capture group $2 doesn't exist in match`,
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: "^/api/(.*)$", Replacement: "https://example.com/$1"},
+			expectedErrors: []string{
+				`rewrites[0].replacement: Invalid value: "https://example.com/$1": must start with / or a capture group`,
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: "^/api/(.*)$", Replacement: "/$request_uri"},
+			expectedErrors: []string{
+				"rewrites[0].replacement: Invalid value: \"/$request_uri\": `$` character can be only followed by a number",
+			},
+		},
+		{
+			rule: v1.RewriteRule{Match: "^/api/(.*)$", Replacement: `/"$1"`},
+			expectedErrors: []string{
+				`rewrites[0].replacement: Invalid value: "/\"$1\"": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. '/$1',  or '/images/$1.png?size=small', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateRewriteRule(test.rule, field.NewPath("rewrites").Index(0))
+
+		var errs []string
+		for _, err := range allErrs {
+			errs = append(errs, err.Error())
+		}
+		if !reflect.DeepEqual(errs, test.expectedErrors) {
+			t.Errorf("validateRewriteRule(%+v) returned errors %v but expected %v", test.rule, errs, test.expectedErrors)
+		}
+	}
+}
+
 func TestValidateActionProxyHeader(t *testing.T) {
 	tests := []struct {
 		header v1.Header
@@ -3093,6 +3231,70 @@ func TestValidateActionProxyHeader(t *testing.T) {
 	}
 }
 
+func TestValidateHeaders(t *testing.T) {
+	tests := []*v1.Headers{
+		nil,
+		{},
+		{
+			Set: []v1.Header{
+				{Name: "X-Server", Value: "${host}"},
+			},
+			Add: []v1.AddHeader{
+				{Header: v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+			},
+			Hide: []string{"X-Powered-By"},
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateHeaders(test, field.NewPath("headers"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateHeaders() returned errors %v for valid input %v", allErrs, test)
+		}
+	}
+}
+
+func TestValidateHeadersFails(t *testing.T) {
+	tests := []struct {
+		headers *v1.Headers
+		msg     string
+	}{
+		{
+			headers: &v1.Headers{
+				Set: []v1.Header{
+					{Name: "X Server", Value: "cafe"},
+				},
+			},
+			msg: "invalid set header name",
+		},
+		{
+			headers: &v1.Headers{
+				Add: []v1.AddHeader{
+					{Header: v1.Header{Name: "X-Frame-Options", Value: `"DENY`}},
+				},
+			},
+			msg: "invalid add header value",
+		},
+		{
+			headers: &v1.Headers{
+				Hide: []string{"X Powered By"},
+			},
+			msg: "invalid hide header name",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateHeaders(test.headers, field.NewPath("headers"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateHeaders() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateActionProxyHeaderFails(t *testing.T) {
 	tests := []struct {
 		header v1.Header
@@ -10,7 +10,10 @@ import (
 
 type K8sV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	DefaultPoliciesGetter
 	GlobalConfigurationsGetter
+	HostPoliciesGetter
+	ReferenceGrantsGetter
 	TransportServersGetter
 }
 
@@ -19,10 +22,22 @@ type K8sV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *K8sV1alpha1Client) DefaultPolicies() DefaultPolicyInterface {
+	return newDefaultPolicies(c)
+}
+
 func (c *K8sV1alpha1Client) GlobalConfigurations(namespace string) GlobalConfigurationInterface {
 	return newGlobalConfigurations(c, namespace)
 }
 
+func (c *K8sV1alpha1Client) HostPolicies() HostPolicyInterface {
+	return newHostPolicies(c)
+}
+
+func (c *K8sV1alpha1Client) ReferenceGrants(namespace string) ReferenceGrantInterface {
+	return newReferenceGrants(c, namespace)
+}
+
 func (c *K8sV1alpha1Client) TransportServers(namespace string) TransportServerInterface {
 	return newTransportServers(c, namespace)
 }
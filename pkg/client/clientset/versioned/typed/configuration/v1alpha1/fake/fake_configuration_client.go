@@ -12,10 +12,22 @@ type FakeK8sV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeK8sV1alpha1) DefaultPolicies() v1alpha1.DefaultPolicyInterface {
+	return &FakeDefaultPolicies{c}
+}
+
 func (c *FakeK8sV1alpha1) GlobalConfigurations(namespace string) v1alpha1.GlobalConfigurationInterface {
 	return &FakeGlobalConfigurations{c, namespace}
 }
 
+func (c *FakeK8sV1alpha1) HostPolicies() v1alpha1.HostPolicyInterface {
+	return &FakeHostPolicies{c}
+}
+
+func (c *FakeK8sV1alpha1) ReferenceGrants(namespace string) v1alpha1.ReferenceGrantInterface {
+	return &FakeReferenceGrants{c, namespace}
+}
+
 func (c *FakeK8sV1alpha1) TransportServers(namespace string) v1alpha1.TransportServerInterface {
 	return &FakeTransportServers{c, namespace}
 }
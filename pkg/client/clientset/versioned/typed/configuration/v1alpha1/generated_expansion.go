@@ -2,6 +2,12 @@
 
 package v1alpha1
 
+type DefaultPolicyExpansion interface{}
+
 type GlobalConfigurationExpansion interface{}
 
+type HostPolicyExpansion interface{}
+
+type ReferenceGrantExpansion interface{}
+
 type TransportServerExpansion interface{}
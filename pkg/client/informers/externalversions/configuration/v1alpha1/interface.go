@@ -8,8 +8,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// DefaultPolicies returns a DefaultPolicyInformer.
+	DefaultPolicies() DefaultPolicyInformer
 	// GlobalConfigurations returns a GlobalConfigurationInformer.
 	GlobalConfigurations() GlobalConfigurationInformer
+	// HostPolicies returns a HostPolicyInformer.
+	HostPolicies() HostPolicyInformer
+	// ReferenceGrants returns a ReferenceGrantInformer.
+	ReferenceGrants() ReferenceGrantInformer
 	// TransportServers returns a TransportServerInformer.
 	TransportServers() TransportServerInformer
 }
@@ -25,11 +31,26 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// DefaultPolicies returns a DefaultPolicyInformer.
+func (v *version) DefaultPolicies() DefaultPolicyInformer {
+	return &defaultPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // GlobalConfigurations returns a GlobalConfigurationInformer.
 func (v *version) GlobalConfigurations() GlobalConfigurationInformer {
 	return &globalConfigurationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// HostPolicies returns a HostPolicyInformer.
+func (v *version) HostPolicies() HostPolicyInformer {
+	return &hostPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ReferenceGrants returns a ReferenceGrantInformer.
+func (v *version) ReferenceGrants() ReferenceGrantInformer {
+	return &referenceGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TransportServers returns a TransportServerInformer.
 func (v *version) TransportServers() TransportServerInformer {
 	return &transportServerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
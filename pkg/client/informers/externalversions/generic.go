@@ -46,8 +46,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1().VirtualServerRoutes().Informer()}, nil
 
 		// Group=k8s.nginx.org, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("defaultpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().DefaultPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("globalconfigurations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().GlobalConfigurations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("hostpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().HostPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("referencegrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().ReferenceGrants().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("transportservers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.K8s().V1alpha1().TransportServers().Informer()}, nil
 
@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+// DefaultPolicyListerExpansion allows custom methods to be added to
+// DefaultPolicyLister.
+type DefaultPolicyListerExpansion interface{}
+
 // GlobalConfigurationListerExpansion allows custom methods to be added to
 // GlobalConfigurationLister.
 type GlobalConfigurationListerExpansion interface{}
@@ -10,6 +14,18 @@ type GlobalConfigurationListerExpansion interface{}
 // GlobalConfigurationNamespaceLister.
 type GlobalConfigurationNamespaceListerExpansion interface{}
 
+// HostPolicyListerExpansion allows custom methods to be added to
+// HostPolicyLister.
+type HostPolicyListerExpansion interface{}
+
+// ReferenceGrantListerExpansion allows custom methods to be added to
+// ReferenceGrantLister.
+type ReferenceGrantListerExpansion interface{}
+
+// ReferenceGrantNamespaceListerExpansion allows custom methods to be added to
+// ReferenceGrantNamespaceLister.
+type ReferenceGrantNamespaceListerExpansion interface{}
+
 // TransportServerListerExpansion allows custom methods to be added to
 // TransportServerLister.
 type TransportServerListerExpansion interface{}